@@ -0,0 +1,70 @@
+// Command export writes a content archive (posts as Markdown, comments,
+// revisions, taxonomy and uploads) to a zip file.
+//
+//	export -o backup.zip [-author ID] [-category SLUG] [-from DATE] [-to DATE] [-uploads DIR]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/rapidtest/netpulse-api/internal/bootstrap"
+	"github.com/rapidtest/netpulse-api/internal/config"
+	"github.com/rapidtest/netpulse-api/internal/domain/export"
+	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
+	"github.com/rs/zerolog/log"
+)
+
+func main() {
+	out := flag.String("o", "", "output file (default netpulse-export-<timestamp>.zip)")
+	author := flag.String("author", "", "only posts by this author ID")
+	category := flag.String("category", "", "only posts in this category slug (and its subcategories)")
+	from := flag.String("from", "", "only posts published on or after this date (YYYY-MM-DD or RFC 3339)")
+	to := flag.String("to", "", "only posts published on or before this date (YYYY-MM-DD or RFC 3339)")
+	uploads := flag.String("uploads", "./uploads", "directory media uploads are stored in")
+	flag.Parse()
+
+	cfg := config.Load()
+	bootstrap.InitLogger(cfg.AppEnv)
+
+	filter := export.Filter{AuthorID: *author, CategorySlug: *category}
+	if err := filter.SetRange(*from, *to); err != nil {
+		log.Fatal().Err(err).Msg("invalid date range")
+	}
+
+	db, err := bootstrap.NewDB(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to connect to database")
+	}
+	defer db.Close()
+
+	exporter := export.NewExporter(postgres.NewExportRepo(db), *uploads, cfg.BaseURL)
+	archive, err := exporter.Load(context.Background(), filter)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to load content")
+	}
+
+	path := *out
+	if path == "" {
+		path = export.Filename(archive.GeneratedAt())
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to create output file")
+	}
+	m, err := archive.Write(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(path)
+		log.Fatal().Err(err).Msg("failed to write archive")
+	}
+
+	fmt.Printf("%s: %d posts, %d comments, %d revisions, %d files\n", path, m.Posts, m.Comments, m.Revisions, m.Files)
+	for _, name := range m.MissingFiles {
+		fmt.Fprintf(os.Stderr, "warning: upload not found: %s\n", name)
+	}
+}
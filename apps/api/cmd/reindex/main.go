@@ -0,0 +1,43 @@
+// Command reindex rebuilds the full-text search vectors of all posts with
+// the text search configuration of each post's language. Run it after
+// changing the search configuration or dictionaries.
+//
+//	reindex [-detect]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/rapidtest/netpulse-api/internal/bootstrap"
+	"github.com/rapidtest/netpulse-api/internal/config"
+	"github.com/rapidtest/netpulse-api/internal/domain/posts"
+	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
+	redisRepo "github.com/rapidtest/netpulse-api/internal/repository/redis"
+	"github.com/rs/zerolog/log"
+)
+
+func main() {
+	detect := flag.Bool("detect", false, "re-detect each post's language (id or en) from its content first")
+	flag.Parse()
+
+	cfg := config.Load()
+	bootstrap.InitLogger(cfg.AppEnv)
+
+	db, err := bootstrap.NewDB(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to connect to database")
+	}
+	defer db.Close()
+
+	rdb := bootstrap.NewRedis(cfg)
+	defer rdb.Close()
+
+	svc := posts.NewService(postgres.NewPostsRepo(db), redisRepo.NewCache(rdb))
+	n, err := svc.ReindexSearch(context.Background(), *detect)
+	if err != nil {
+		log.Fatal().Err(err).Int("reindexed", n).Msg("reindex failed")
+	}
+	fmt.Printf("reindexed %d posts\n", n)
+}
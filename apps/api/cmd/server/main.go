@@ -32,8 +32,18 @@ func main() {
 	rdb := bootstrap.NewRedis(cfg)
 	defer rdb.Close()
 
-	// Bootstrap HTTP server
-	router := bootstrap.NewHTTP(cfg, db, rdb)
+	// Bootstrap HTTP server; its background work lasts until shutdown
+	bgCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	router, waitBackground := bootstrap.NewHTTP(bgCtx, cfg, db, rdb)
+
+	// Background jobs (scheduled publishing, etc.)
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	jobRunner := bootstrap.NewJobs(cfg, db, rdb)
+	if cfg.JobsEnabled {
+		jobRunner.Start(jobsCtx)
+	}
 
 	srv := &http.Server{
 		Addr:         fmt.Sprintf(":%s", cfg.APIPort),
@@ -56,11 +66,19 @@ func main() {
 	<-quit
 
 	log.Info().Msg("shutting down server...")
+	stopJobs()
+	jobRunner.Wait()
+
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
 	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatal().Err(err).Msg("forced shutdown")
+		log.Error().Err(err).Msg("forced shutdown")
 	}
+
+	// Requests are done; let background work (import jobs, queued search
+	// log entries) finish before exiting
+	stopBackground()
+	waitBackground()
 	log.Info().Msg("server stopped")
 }
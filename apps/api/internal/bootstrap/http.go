@@ -1,9 +1,12 @@
 package bootstrap
 
 import (
+	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -14,7 +17,12 @@ import (
 	"github.com/redis/go-redis/v9"
 
 	"github.com/rapidtest/netpulse-api/internal/config"
+	"github.com/rapidtest/netpulse-api/internal/domain/export"
+	"github.com/rapidtest/netpulse-api/internal/domain/imports"
 	"github.com/rapidtest/netpulse-api/internal/domain/posts"
+	"github.com/rapidtest/netpulse-api/internal/domain/related"
+	"github.com/rapidtest/netpulse-api/internal/domain/searchlog"
+	"github.com/rapidtest/netpulse-api/internal/domain/spam"
 	"github.com/rapidtest/netpulse-api/internal/gateway"
 	"github.com/rapidtest/netpulse-api/internal/http/handlers"
 	adminHandlers "github.com/rapidtest/netpulse-api/internal/http/handlers/admin"
@@ -26,10 +34,14 @@ import (
 	redisRepo "github.com/rapidtest/netpulse-api/internal/repository/redis"
 	"github.com/rapidtest/netpulse-api/internal/security"
 	"github.com/rapidtest/netpulse-api/internal/utils"
+	"github.com/rs/zerolog/log"
 )
 
 // NewHTTP wires up all dependencies and returns a configured router.
-func NewHTTP(cfg *config.Config, db *pgxpool.Pool, rdb *redis.Client) http.Handler {
+// Background work started for the router (import jobs, the search log)
+// stops when ctx is cancelled; the returned wait function blocks until it
+// has, including writing out queued search log entries.
+func NewHTTP(ctx context.Context, cfg *config.Config, db *pgxpool.Pool, rdb *redis.Client) (http.Handler, func()) {
 	r := chi.NewRouter()
 
 	// ── Global middleware ────────────────────────────────
@@ -40,8 +52,8 @@ func NewHTTP(cfg *config.Config, db *pgxpool.Pool, rdb *redis.Client) http.Handl
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"http://localhost:3000", "http://localhost:3001", cfg.BaseURL, cfg.StoreURL},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-Request-ID"},
-		ExposedHeaders:   []string{"X-Request-ID"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "If-Match", "X-Request-ID"},
+		ExposedHeaders:   []string{"ETag", "X-Request-ID"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -56,6 +68,9 @@ func NewHTTP(cfg *config.Config, db *pgxpool.Pool, rdb *redis.Client) http.Handl
 	settingsRepo := postgres.NewSettingsRepo(db)
 	authRepo := postgres.NewAuthRepo(db)
 	commentsRepo := postgres.NewCommentsRepo(db)
+	spamRepo := postgres.NewSpamRepo(db)
+	reportsRepo := postgres.NewReportsRepo(db)
+	notificationsRepo := postgres.NewNotificationsRepo(db)
 	engagementRepo := postgres.NewEngagementRepo(db)
 	referralRepo := postgres.NewReferralRepo(db)
 	mediaRepo := postgres.NewMediaRepo(db)
@@ -65,6 +80,7 @@ func NewHTTP(cfg *config.Config, db *pgxpool.Pool, rdb *redis.Client) http.Handl
 	cacheRepo := redisRepo.NewCache(rdb)
 	engCache := redisRepo.NewEngagementCache(rdb)
 	rateLimiter := redisRepo.NewRateLimiter(rdb)
+	editLocks := redisRepo.NewEditLocks(rdb)
 
 	// Store repositories
 	listingsRepo := postgres.NewListingsRepo(db)
@@ -72,12 +88,32 @@ func NewHTTP(cfg *config.Config, db *pgxpool.Pool, rdb *redis.Client) http.Handl
 	portfolioRepo := postgres.NewPortfolioRepo(db)
 	paymentRepo := postgres.NewPaymentRepo(db)
 
+	// Content repositories
+	seriesRepo := postgres.NewSeriesRepo(db)
+	feedRepo := postgres.NewFeedRepo(db)
+	searchLogRepo := postgres.NewSearchLogRepo(db)
+
 	// ── Security ─────────────────────────────────────────
 	tokenSvc := security.NewTokenService(cfg)
+	previewSigner := security.NewPreviewSigner(cfg)
 	encKeyBytes := []byte(cfg.EncryptionKey)
 
 	// ── Services ─────────────────────────────────────────
 	postsSvc := posts.NewService(postsRepo, cacheRepo)
+	var background sync.WaitGroup
+	searchRecorder := searchlog.NewRecorder(searchLogRepo)
+	background.Add(1)
+	go func() {
+		defer background.Done()
+		searchRecorder.Run(ctx)
+	}()
+	spamClassifier := spam.NewClassifier(settingsRepo, spamRepo,
+		spam.LinkCheck{},
+		spam.BlocklistCheck{},
+		spam.NewDuplicateCheck(spamRepo),
+		spam.NewVelocityCheck(redisRepo.NewCounter(rdb)),
+		spam.NewBayesCheck(spamRepo),
+	)
 
 	// ── Permission loader ────────────────────────────────
 	permLoader := middleware.NewPermissionLoader(db)
@@ -85,21 +121,47 @@ func NewHTTP(cfg *config.Config, db *pgxpool.Pool, rdb *redis.Client) http.Handl
 	// ── Handlers ─────────────────────────────────────────
 	healthH := handlers.NewHealthHandler(db, rdb)
 	publicPostsH := publicHandlers.NewPostsHandler(postsSvc)
+	publicPreviewH := publicHandlers.NewPreviewHandler(postsSvc, previewSigner)
 	publicCategoriesH := publicHandlers.NewCategoriesHandler(categoriesRepo)
 	publicTagsH := publicHandlers.NewTagsHandler(tagsRepo)
-	publicSearchH := publicHandlers.NewSearchHandler(postsRepo, cacheRepo)
-	engagementH := publicHandlers.NewEngagementHandler(commentsRepo, engagementRepo, engCache, auditRepo)
+	publicSeriesH := publicHandlers.NewSeriesHandler(seriesRepo)
+	publicFeedsH := publicHandlers.NewFeedsHandler(feedRepo, settingsRepo, cacheRepo, cfg.SiteURL, cfg.StoreURL)
+	publicSearchH := publicHandlers.NewSearchHandler(postsRepo, postgres.NewSearchRepo(db), cacheRepo, searchRecorder, cfg.SiteURL, cfg.StoreURL)
+	relatedSvc := related.NewService(postgres.NewRelatedRepo(db), settingsRepo, cacheRepo)
+	publicRelatedH := publicHandlers.NewRelatedHandler(relatedSvc)
+	reportsH := publicHandlers.NewReportsHandler(reportsRepo, commentsRepo, engagementRepo, settingsRepo, cacheRepo, auditRepo)
+	engagementH := publicHandlers.NewEngagementHandler(commentsRepo, engagementRepo, settingsRepo, spamClassifier, notificationsRepo, engCache, auditRepo)
 
 	adminAuthH := adminHandlers.NewAuthHandler(usersRepo, authRepo, referralRepo, auditRepo, tokenSvc, engCache, cfg)
-	adminPostsH := adminHandlers.NewPostsHandler(postsSvc, auditRepo)
+	adminPostsH := adminHandlers.NewPostsHandler(postsSvc, auditRepo, usersRepo, previewSigner, editLocks)
+	adminSeriesH := adminHandlers.NewSeriesHandler(seriesRepo, auditRepo)
+	adminCategoriesH := adminHandlers.NewCategoriesHandler(categoriesRepo, auditRepo, cacheRepo)
+	adminTagsH := adminHandlers.NewTagsHandler(tagsRepo, auditRepo, cacheRepo)
 	adminUsersH := adminHandlers.NewUsersHandler(usersRepo, rolesRepo, auditRepo, referralRepo, authRepo)
-	adminSettingsH := adminHandlers.NewSettingsHandler(settingsRepo, auditRepo)
-	adminCommentsH := adminHandlers.NewCommentsHandler(commentsRepo, engagementRepo, auditRepo)
-	adminStatsH := adminHandlers.NewStatsHandler(engagementRepo)
+	adminSettingsH := adminHandlers.NewSettingsHandler(settingsRepo, auditRepo, cacheRepo)
+	adminCommentsH := adminHandlers.NewCommentsHandler(commentsRepo, engagementRepo, spamRepo, notificationsRepo, auditRepo)
+	adminReportsH := adminHandlers.NewReportsHandler(reportsRepo, commentsRepo, engagementRepo, cacheRepo, auditRepo)
+	adminStatsH := adminHandlers.NewStatsHandler(engagementRepo, searchLogRepo)
 	adminReferralH := adminHandlers.NewReferralHandler(referralRepo)
 	adminRolesH := adminHandlers.NewRolesHandler(rolesRepo, auditRepo)
 	adminAuditH := adminHandlers.NewAuditHandler(auditRepo)
 	adminMediaH := adminHandlers.NewMediaHandler(mediaRepo, auditRepo, cfg.BaseURL)
+	importsSvc := imports.NewService(ctx, postgres.NewImportsRepo(db), postsSvc, categoriesRepo, tagsRepo,
+		func(ctx context.Context, name string, src io.Reader, uploadedBy string) (string, error) {
+			item, err := adminMediaH.Store(ctx, name, src, uploadedBy)
+			if err != nil {
+				return "", err
+			}
+			return item.URL, nil
+		})
+	if n, err := importsSvc.FailInterrupted(ctx); err != nil {
+		log.Warn().Err(err).Msg("failed to clean up interrupted import jobs")
+	} else if n > 0 {
+		log.Warn().Int64("jobs", n).Msg("marked interrupted import jobs as failed")
+	}
+	adminImportsH := adminHandlers.NewImportsHandler(importsSvc, usersRepo, auditRepo)
+	exporter := export.NewExporter(postgres.NewExportRepo(db), "./uploads", cfg.BaseURL)
+	adminExportH := adminHandlers.NewExportHandler(exporter, auditRepo)
 	adminAdsH := adminHandlers.NewAdsHandler(adsRepo, auditRepo)
 	adminAffiliateH := adminHandlers.NewAffiliateHandler(affiliateRepo, auditRepo, encKeyBytes)
 
@@ -118,9 +180,11 @@ func NewHTTP(cfg *config.Config, db *pgxpool.Pool, rdb *redis.Client) http.Handl
 
 	// Author/User panel handlers
 	authorPostsH := authorHandlers.NewPostsHandler(postsSvc, auditRepo)
+	authorExportH := authorHandlers.NewExportHandler(exporter, auditRepo)
 	authorAffiliateH := authorHandlers.NewAffiliateHandler(affiliateRepo, referralRepo, auditRepo, encKeyBytes)
 	authorProfileH := authorHandlers.NewProfileHandler(usersRepo, authRepo, auditRepo)
 	userFeaturesH := authorHandlers.NewUserFeaturesHandler(savesRepo)
+	userNotificationsH := authorHandlers.NewNotificationsHandler(notificationsRepo)
 	userAuthorReqH := authorHandlers.NewAuthorRequestHandler(authorRequestRepo)
 
 	// Admin author requests handler
@@ -159,34 +223,75 @@ func NewHTTP(cfg *config.Config, db *pgxpool.Pool, rdb *redis.Client) http.Handl
 		r.Get("/", publicPostsH.List)
 
 		r.Route("/{id}", func(r chi.Router) {
+			// Likes and comments tell signed-in readers from guests.
+			r.Use(authMW.OptionalAuthenticate)
 			r.Get("/", publicPostsH.GetBySlug)
 			r.Get("/comments", engagementH.ListComments)
 			r.Post("/comments", engagementH.CreateComment)
+			r.Get("/comments/{commentId}/replies", engagementH.ListReplies)
+			r.Post("/comments/{commentId}/like", engagementH.LikeComment)
+			r.Delete("/comments/{commentId}/like", engagementH.UnlikeComment)
+			r.Post("/comments/{commentId}/report", reportsH.ReportComment)
+			r.Post("/report", reportsH.ReportPost)
 			r.Post("/like", engagementH.ToggleLike)
 			r.Post("/view", engagementH.RecordView)
 			r.Get("/stats", engagementH.GetPostStats)
+			r.Get("/related", publicRelatedH.List)
 
 			// Authenticated save/unsave (within public posts route)
 			r.Group(func(r chi.Router) {
 				r.Use(authMW.Authenticate)
 				r.Post("/save", userFeaturesH.ToggleSave)
 				r.Get("/saved", userFeaturesH.CheckSaved)
+				r.Patch("/comments/{commentId}", engagementH.UpdateComment)
+				r.Delete("/comments/{commentId}", engagementH.DeleteComment)
 			})
 		})
 	})
 
 	r.Route("/categories", func(r chi.Router) {
 		r.Get("/", publicCategoriesH.List)
+		r.Get("/{slug}", publicCategoriesH.GetBySlug)
+		r.Get("/{slug}/feed.xml", publicFeedsH.CategoryRSS)
+		r.Get("/{slug}/atom.xml", publicFeedsH.CategoryAtom)
+		r.Get("/{slug}/feed.json", publicFeedsH.CategoryJSON)
 	})
 
 	r.Route("/tags", func(r chi.Router) {
 		r.Get("/", publicTagsH.List)
+		r.Get("/{slug}", publicTagsH.GetBySlug)
+		r.Get("/{slug}/feed.xml", publicFeedsH.TagRSS)
+		r.Get("/{slug}/atom.xml", publicFeedsH.TagAtom)
+		r.Get("/{slug}/feed.json", publicFeedsH.TagJSON)
 	})
 
-	r.Route("/search", func(r chi.Router) {
+	// Sitemap & syndication feeds
+	r.Get("/sitemap.xml", publicFeedsH.Sitemap)
+	r.Get("/sitemap-{page}.xml", publicFeedsH.SitemapPage)
+	r.Get("/feed.xml", publicFeedsH.RSS)
+	r.Get("/atom.xml", publicFeedsH.Atom)
+	r.Get("/feed.json", publicFeedsH.JSON)
+
+	r.Route("/series", func(r chi.Router) {
+		r.Get("/", publicSeriesH.List)
+		r.Get("/{slug}", publicSeriesH.GetBySlug)
+	})
+
+	// Signed preview links for unpublished posts
+	r.Route("/preview", func(r chi.Router) {
 		r.Use(httprate.LimitByIP(30, 1*time.Minute))
-		r.Get("/", publicSearchH.Search)
-		r.Get("/suggest", publicSearchH.Suggest)
+		r.Get("/{token}", publicPreviewH.Get)
+	})
+
+	r.Route("/search", func(r chi.Router) {
+		r.Group(func(r chi.Router) {
+			r.Use(httprate.LimitByIP(30, 1*time.Minute))
+			r.Get("/", publicSearchH.Search)
+			r.Get("/suggest", publicSearchH.Suggest)
+		})
+		// Click beacons get their own budget, so typing in the search box
+		// (one suggest call per keystroke) cannot get them rejected
+		r.With(httprate.LimitByIP(60, 1*time.Minute)).Post("/click", publicSearchH.Click)
 	})
 
 	// Public referral redirect
@@ -231,6 +336,68 @@ func NewHTTP(cfg *config.Config, db *pgxpool.Pool, rdb *redis.Client) http.Handl
 			r.Post("/{id}/submit-review", adminPostsH.SubmitReview)
 			r.Post("/{id}/publish", adminPostsH.Publish)
 			r.Post("/{id}/schedule", adminPostsH.Schedule)
+			r.Put("/{id}/expiry", adminPostsH.SetExpiry)
+			r.Get("/{id}/revisions", adminPostsH.ListRevisions)
+			r.Get("/{id}/revisions/diff", adminPostsH.DiffRevisions)
+			r.Get("/{id}/review-notes", adminPostsH.ListReviewNotes)
+			r.Post("/{id}/review-notes/{noteId}/replies", adminPostsH.ReplyToNote)
+			r.Post("/{id}/review-notes/{noteId}/resolve", adminPostsH.ResolveNote)
+			r.Post("/{id}/review-notes/{noteId}/reopen", adminPostsH.ReopenNote)
+			r.Group(func(r chi.Router) {
+				r.Use(middleware.RBAC("posts.edit_any"))
+				r.Post("/{id}/request-changes", adminPostsH.RequestChanges)
+				r.Put("/{id}/authors", adminPostsH.SetAuthors)
+				r.Post("/{id}/revisions/{revisionId}/restore", adminPostsH.RestoreRevision)
+				r.Get("/{id}/previews", adminPostsH.ListPreviews)
+				r.Post("/{id}/previews", adminPostsH.CreatePreview)
+				r.Delete("/{id}/previews/{previewId}", adminPostsH.RevokePreview)
+				r.Get("/{id}/lock", adminPostsH.GetLock)
+				r.Post("/{id}/lock", adminPostsH.AcquireLock)
+				r.Delete("/{id}/lock", adminPostsH.ReleaseLock)
+			})
+		})
+
+		r.Route("/series", func(r chi.Router) {
+			r.Use(middleware.RBAC("posts.edit_any"))
+			r.Get("/", adminSeriesH.List)
+			r.Post("/", adminSeriesH.Create)
+			r.Get("/{id}", adminSeriesH.GetByID)
+			r.Patch("/{id}", adminSeriesH.Update)
+			r.Delete("/{id}", adminSeriesH.Delete)
+			r.Put("/{id}/posts", adminSeriesH.SetPosts)
+			r.Post("/{id}/posts", adminSeriesH.AddPost)
+			r.Delete("/{id}/posts/{postId}", adminSeriesH.RemovePost)
+		})
+
+		r.Route("/imports", func(r chi.Router) {
+			r.Use(middleware.RBAC("posts.edit_any"))
+			r.Get("/", adminImportsH.List)
+			r.Post("/", adminImportsH.Create)
+			r.Get("/{id}", adminImportsH.GetByID)
+		})
+
+		r.Route("/export", func(r chi.Router) {
+			r.Use(middleware.RBAC("posts.edit_any"))
+			r.Get("/", adminExportH.Export)
+		})
+
+		r.Route("/categories", func(r chi.Router) {
+			r.Use(middleware.RBAC("posts.edit_any"))
+			r.Get("/", adminCategoriesH.List)
+			r.Post("/", adminCategoriesH.Create)
+			r.Get("/{id}", adminCategoriesH.GetByID)
+			r.Patch("/{id}", adminCategoriesH.Update)
+			r.Delete("/{id}", adminCategoriesH.Delete)
+		})
+
+		r.Route("/tags", func(r chi.Router) {
+			r.Use(middleware.RBAC("posts.edit_any"))
+			r.Get("/", adminTagsH.List)
+			r.Post("/", adminTagsH.Create)
+			r.Get("/{id}", adminTagsH.GetByID)
+			r.Patch("/{id}", adminTagsH.Update)
+			r.Delete("/{id}", adminTagsH.Delete)
+			r.Post("/{id}/merge", adminTagsH.Merge)
 		})
 
 		r.Route("/users", func(r chi.Router) {
@@ -251,6 +418,12 @@ func NewHTTP(cfg *config.Config, db *pgxpool.Pool, rdb *redis.Client) http.Handl
 			r.Patch("/{id}/moderate", adminCommentsH.Moderate)
 			r.Post("/bulk-moderate", adminCommentsH.BulkModerate)
 			r.Delete("/{id}", adminCommentsH.Delete)
+			r.Get("/{id}/edits", adminCommentsH.Edits)
+
+			// Reader reports on comments and posts
+			r.Get("/reports", adminReportsH.List)
+			r.Get("/reports/{id}", adminReportsH.Get)
+			r.Post("/reports/{id}/resolve", adminReportsH.Resolve)
 		})
 
 		r.Route("/roles", func(r chi.Router) {
@@ -266,6 +439,7 @@ func NewHTTP(cfg *config.Config, db *pgxpool.Pool, rdb *redis.Client) http.Handl
 			r.Get("/top-posts", adminStatsH.TopPosts)
 			r.Get("/traffic", adminStatsH.TrafficOverview)
 			r.Get("/posts/{id}", adminStatsH.PostStats)
+			r.Get("/search", adminStatsH.Search)
 		})
 
 		r.Route("/referrals", func(r chi.Router) {
@@ -443,10 +617,18 @@ func NewHTTP(cfg *config.Config, db *pgxpool.Pool, rdb *redis.Client) http.Handl
 			r.Get("/", authorPostsH.List)
 			r.Post("/", authorPostsH.Create)
 			r.Get("/stats", authorPostsH.Stats)
+			r.Get("/export", authorExportH.Export)
 			r.Get("/{id}", authorPostsH.GetByID)
 			r.Patch("/{id}", authorPostsH.Update)
 			r.Delete("/{id}", authorPostsH.Delete)
 			r.Post("/{id}/submit-review", authorPostsH.SubmitReview)
+			r.Put("/{id}/authors", authorPostsH.SetAuthors)
+			r.Get("/{id}/revisions", authorPostsH.ListRevisions)
+			r.Get("/{id}/revisions/diff", authorPostsH.DiffRevisions)
+			r.Post("/{id}/revisions/{revisionId}/restore", authorPostsH.RestoreRevision)
+			r.Get("/{id}/review-notes", authorPostsH.ListReviewNotes)
+			r.Post("/{id}/review-notes/{noteId}/replies", authorPostsH.ReplyToNote)
+			r.Post("/{id}/review-notes/{noteId}/resolve", authorPostsH.ResolveNote)
 		})
 
 		// ── User features: saves, likes, comments ──────
@@ -454,6 +636,16 @@ func NewHTTP(cfg *config.Config, db *pgxpool.Pool, rdb *redis.Client) http.Handl
 		r.Get("/likes", userFeaturesH.ListLiked)
 		r.Get("/comments", userFeaturesH.ListMyComments)
 
+		// ── Notifications ────────────────────────────────
+		r.Route("/notifications", func(r chi.Router) {
+			r.Get("/", userNotificationsH.List)
+			r.Get("/unread-count", userNotificationsH.UnreadCount)
+			r.Post("/read-all", userNotificationsH.MarkAllRead)
+			r.Get("/preferences", userNotificationsH.GetPreferences)
+			r.Put("/preferences", userNotificationsH.UpdatePreferences)
+			r.Post("/{id}/read", userNotificationsH.MarkRead)
+		})
+
 		// ── Author request ───────────────────────────────
 		r.Post("/author-request", userAuthorReqH.Create)
 		r.Get("/author-request", userAuthorReqH.GetStatus)
@@ -561,5 +753,8 @@ func NewHTTP(cfg *config.Config, db *pgxpool.Pool, rdb *redis.Client) http.Handl
 		json.NewEncoder(w).Encode(public)
 	})
 
-	return r
+	return r, func() {
+		importsSvc.Wait()
+		background.Wait()
+	}
 }
@@ -0,0 +1,28 @@
+package bootstrap
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/redis/go-redis/v9"
+
+	"github.com/rapidtest/netpulse-api/internal/config"
+	"github.com/rapidtest/netpulse-api/internal/domain/posts"
+	"github.com/rapidtest/netpulse-api/internal/jobs"
+	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
+	redisRepo "github.com/rapidtest/netpulse-api/internal/repository/redis"
+)
+
+// NewJobs wires up the in-process background job runner.
+func NewJobs(cfg *config.Config, db *pgxpool.Pool, rdb *redis.Client) *jobs.Runner {
+	postsRepo := postgres.NewPostsRepo(db)
+	auditRepo := postgres.NewAuditRepo(db)
+	cacheRepo := redisRepo.NewCache(rdb)
+
+	postsSvc := posts.NewService(postsRepo, cacheRepo)
+
+	runner := jobs.NewRunner()
+	runner.Register("publish_scheduled_posts", cfg.SchedulerInterval, jobs.PublishScheduledPosts(postsSvc, auditRepo))
+	runner.Register("archive_expired_posts", cfg.SchedulerInterval, jobs.ArchiveExpiredPosts(postsSvc, auditRepo))
+	runner.Register("refresh_search_terms", cfg.SearchTermsInterval, jobs.RefreshSearchTerms(postsRepo))
+
+	return runner
+}
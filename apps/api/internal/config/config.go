@@ -3,6 +3,8 @@ package config
 import (
 	"os"
 	"time"
+
+	"github.com/rs/zerolog/log"
 )
 
 // Config holds all application configuration.
@@ -25,6 +27,7 @@ type Config struct {
 	// API
 	APIPort string
 	BaseURL string
+	SiteURL string
 
 	// JWT
 	JWTAccessSecret  string
@@ -35,6 +38,9 @@ type Config struct {
 	// Encryption
 	EncryptionKey string
 
+	// Post preview links (falls back to JWTAccessSecret when empty)
+	PreviewSecret string
+
 	// Media
 	MediaStorage string
 
@@ -55,6 +61,11 @@ type Config struct {
 	// Payment - Paydisini
 	PaydisiniAPIKey  string
 	PaydisiniSandbox bool
+
+	// Background jobs
+	JobsEnabled         bool
+	SchedulerInterval   time.Duration
+	SearchTermsInterval time.Duration
 }
 
 func (c *Config) DatabaseDSN() string {
@@ -77,13 +88,16 @@ func getEnv(key, fallback string) string {
 	return fallback
 }
 
+// getEnvDuration reads a positive duration; invalid, zero and negative
+// values fall back to the default.
 func getEnvDuration(key string, fallback time.Duration) time.Duration {
 	v := os.Getenv(key)
 	if v == "" {
 		return fallback
 	}
 	d, err := time.ParseDuration(v)
-	if err != nil {
+	if err != nil || d <= 0 {
+		log.Warn().Str("key", key).Str("value", v).Dur("default", fallback).Msg("invalid duration, using default")
 		return fallback
 	}
 	return d
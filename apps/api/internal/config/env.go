@@ -33,6 +33,7 @@ func Load() *Config {
 
 		APIPort: getEnv("API_PORT", "8080"),
 		BaseURL: getEnv("API_BASE_URL", "http://localhost:8080"),
+		SiteURL: getEnv("SITE_URL", "http://localhost:3000"),
 
 		JWTAccessSecret:  getEnv("JWT_ACCESS_SECRET", "dev-access-secret"),
 		JWTRefreshSecret: getEnv("JWT_REFRESH_SECRET", "dev-refresh-secret"),
@@ -40,6 +41,7 @@ func Load() *Config {
 		JWTRefreshExpiry: getEnvDuration("JWT_REFRESH_EXPIRY", 720*time.Hour),
 
 		EncryptionKey: getEnv("ENCRYPTION_KEY", ""),
+		PreviewSecret: getEnv("PREVIEW_SECRET", ""),
 		MediaStorage:  getEnv("MEDIA_STORAGE", "local"),
 
 		GoogleClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
@@ -55,5 +57,9 @@ func Load() *Config {
 
 		PaydisiniAPIKey:  getEnv("PAYDISINI_API_KEY", ""),
 		PaydisiniSandbox: getEnv("PAYDISINI_SANDBOX", "true") == "true",
+
+		JobsEnabled:         getEnv("JOBS_ENABLED", "true") == "true",
+		SchedulerInterval:   getEnvDuration("SCHEDULER_INTERVAL", 1*time.Minute),
+		SearchTermsInterval: getEnvDuration("SEARCH_TERMS_INTERVAL", 10*time.Minute),
 	}
 }
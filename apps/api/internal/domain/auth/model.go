@@ -6,6 +6,7 @@ import "time"
 type RegisterInput struct {
 	Email       string `json:"email"`
 	Name        string `json:"name"`
+	Username    string `json:"username,omitempty"`
 	Password    string `json:"password"`
 	ReferralCode string `json:"referral_code,omitempty"`
 }
@@ -1,6 +1,11 @@
 package comments
 
-import "time"
+import (
+	"strconv"
+	"time"
+
+	"github.com/rapidtest/netpulse-api/internal/domain/settings"
+)
 
 // CommentStatus represents the status of a comment.
 type CommentStatus string
@@ -24,15 +29,131 @@ type Comment struct {
 	Status     CommentStatus `json:"status"`
 	IPAddress  string        `json:"-"`
 	UserAgent  string        `json:"-"`
+	// ContentHash identifies duplicate content; SpamScore is the spam
+	// score (0..1) the comment got when it was posted or last edited,
+	// shown to moderators only (see AdminComment).
+	ContentHash string  `json:"-"`
+	SpamScore   float64 `json:"-"`
 	CreatedAt  time.Time     `json:"created_at"`
 	UpdatedAt  time.Time     `json:"updated_at"`
+	EditedAt   *time.Time    `json:"edited_at,omitempty"`
 	DeletedAt  *time.Time    `json:"deleted_at,omitempty"`
 
 	// Joined fields
 	AuthorName   string     `json:"author_name,omitempty"`
 	AuthorAvatar string     `json:"author_avatar,omitempty"`
+	// AuthorUsername is the handle replies @mention; empty for guests.
+	AuthorUsername string   `json:"author_username,omitempty"`
 	Replies      []Comment  `json:"replies,omitempty"`
 	ReplyCount   int        `json:"reply_count,omitempty"`
+
+	// Thread fields, filled on public reads. Depth is the nesting level
+	// (0 for top-level comments), capped at the flattening depth; ReplyTo
+	// names the author replied to when a reply is shown flat.
+	LikesCount int    `json:"likes_count"`
+	Liked      bool   `json:"liked,omitempty"`
+	Depth      int    `json:"depth"`
+	ReplyTo    string `json:"reply_to,omitempty"`
+
+	// Deleted marks a tombstone: a deleted comment kept in a thread
+	// because it still has replies.
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// DeletedContent replaces the content of tombstones.
+const DeletedContent = "[deleted]"
+
+// Tombstone strips a deleted comment down to its place in the thread.
+func (c *Comment) Tombstone() {
+	c.Deleted = true
+	c.Content = DeletedContent
+	c.UserID = nil
+	c.GuestName = ""
+	c.GuestEmail = ""
+	c.AuthorName = ""
+	c.AuthorAvatar = ""
+	c.AuthorUsername = ""
+	c.EditedAt = nil
+	c.LikesCount = 0
+	c.Liked = false
+}
+
+// Edit is an earlier version of a comment's content.
+type Edit struct {
+	ID        string    `json:"id"`
+	CommentID string    `json:"comment_id"`
+	Content   string    `json:"content"`
+	EditedBy  *string   `json:"edited_by,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Sort orders for public comment threads.
+const (
+	SortNewest = "newest"
+	SortOldest = "oldest"
+	SortTop    = "top" // most liked first
+)
+
+// ValidSort reports whether s is a supported thread sort.
+func ValidSort(s string) bool {
+	return s == SortNewest || s == SortOldest || s == SortTop
+}
+
+// Viewer identifies who reads or likes comments: a user, or a guest by the
+// key derived from IP and user agent.
+type Viewer struct {
+	UserID   string
+	GuestKey string
+}
+
+// ThreadFilter selects one page of a comment thread: the top-level comments
+// of PostID, or the replies to ParentID. Depth is the nesting level of the
+// listed comments; at MaxDepth the whole subtree of ParentID is listed flat.
+type ThreadFilter struct {
+	PostID   string
+	ParentID string
+	Depth    int
+	MaxDepth int
+	Sort     string
+	Viewer   Viewer
+	Page     int
+	Limit    int
+}
+
+// Flat reports whether the filter lists all descendants of ParentID.
+func (f ThreadFilter) Flat() bool {
+	return f.ParentID != "" && f.Depth >= f.MaxDepth
+}
+
+// DefaultMaxDepth is the number of nested reply levels shown before
+// deeper replies are flattened.
+const DefaultMaxDepth = 3
+
+// MaxDepthFromSettings reads comment_max_depth, falling back to
+// DefaultMaxDepth when it is missing or not a positive number.
+func MaxDepthFromSettings(s map[string]string) int {
+	if n, err := strconv.Atoi(s[settings.KeyCommentMaxDepth]); err == nil && n > 0 {
+		return n
+	}
+	return DefaultMaxDepth
+}
+
+// DefaultEditWindow is how long owners may edit or delete a comment when
+// comment_edit_window_minutes is not set.
+const DefaultEditWindow = 15 * time.Minute
+
+// EditWindowFromSettings reads comment_edit_window_minutes. Zero disables
+// owner edits and deletes; missing or invalid values use DefaultEditWindow.
+func EditWindowFromSettings(s map[string]string) time.Duration {
+	if n, err := strconv.Atoi(s[settings.KeyCommentEditWindow]); err == nil && n >= 0 {
+		return time.Duration(n) * time.Minute
+	}
+	return DefaultEditWindow
+}
+
+// Editable reports whether the owner may still edit or delete c at now.
+func (c *Comment) Editable(window time.Duration, now time.Time) bool {
+	return window > 0 && now.Sub(c.CreatedAt) <= window
 }
 
 // CreateCommentInput from public API.
@@ -43,6 +164,11 @@ type CreateCommentInput struct {
 	GuestEmail string  `json:"guest_email,omitempty"`
 }
 
+// UpdateCommentInput from the comment owner.
+type UpdateCommentInput struct {
+	Content string `json:"content"`
+}
+
 // ModerateCommentInput from admin API.
 type ModerateCommentInput struct {
 	Status CommentStatus `json:"status"`
@@ -64,6 +190,12 @@ type CommentFilter struct {
 	Limit    int           `json:"limit"`
 }
 
+// AdminComment is a Comment as moderators see it, with its spam score.
+type AdminComment struct {
+	Comment
+	SpamScore float64 `json:"spam_score"`
+}
+
 // CommentListResult paginated.
 type CommentListResult struct {
 	Items      []Comment `json:"items"`
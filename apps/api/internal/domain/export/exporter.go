@@ -0,0 +1,300 @@
+package export
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rapidtest/netpulse-api/internal/domain/posts"
+)
+
+// Repository loads everything an archive contains.
+type Repository interface {
+	FindPosts(ctx context.Context, f Filter) ([]posts.Post, error)
+	FindComments(ctx context.Context, postIDs []string) ([]Comment, error)
+	FindRevisions(ctx context.Context, postIDs []string) ([]posts.Revision, error)
+	FindCategories(ctx context.Context) ([]posts.Category, error)
+	FindTags(ctx context.Context) ([]posts.Tag, error)
+}
+
+// Exporter writes portable zip archives:
+//
+//	manifest.json
+//	posts/<slug>.md             Markdown with YAML front-matter
+//	comments/<slug>.json
+//	revisions/<slug>.json
+//	taxonomy/categories.json
+//	taxonomy/tags.json
+//	uploads/<yyyy>/<mm>/<file>  media referenced by the exported posts
+//
+// Links to local uploads are rewritten to ../uploads/... so the archive can
+// be re-imported as a Markdown zip.
+type Exporter struct {
+	repo      Repository
+	uploadDir string
+	uploadRe  *regexp.Regexp
+}
+
+// NewExporter reads upload files from uploadDir; baseURL is the public URL
+// prefix of /uploads links.
+func NewExporter(repo Repository, uploadDir, baseURL string) *Exporter {
+	prefix := "(?:" + regexp.QuoteMeta(strings.TrimRight(baseURL, "/")) + ")?"
+	return &Exporter{
+		repo:      repo,
+		uploadDir: uploadDir,
+		uploadRe:  regexp.MustCompile(prefix + `/uploads/([A-Za-z0-9._/-]+)`),
+	}
+}
+
+// Archive is the loaded content of an export, ready to be written.
+type Archive struct {
+	e          *Exporter
+	posts      []posts.Post
+	comments   map[string][]Comment
+	revisions  map[string][]posts.Revision
+	categories []posts.Category
+	tags       []posts.Tag
+	manifest   Manifest
+}
+
+// GeneratedAt is the time the archive was loaded.
+func (a *Archive) GeneratedAt() time.Time { return a.manifest.GeneratedAt }
+
+// Load reads everything the archive of the posts matching f contains. It is
+// separate from Write so that errors surface before any output is sent.
+func (e *Exporter) Load(ctx context.Context, f Filter) (*Archive, error) {
+	items, err := e.repo.FindPosts(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]string, len(items))
+	for i, p := range items {
+		ids[i] = p.ID
+	}
+
+	commentList, err := e.repo.FindComments(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	commentsByPost := make(map[string][]Comment)
+	for _, c := range commentList {
+		commentsByPost[c.PostID] = append(commentsByPost[c.PostID], c)
+	}
+	revisionList, err := e.repo.FindRevisions(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	revisionsByPost := make(map[string][]posts.Revision)
+	for _, rev := range revisionList {
+		revisionsByPost[rev.PostID] = append(revisionsByPost[rev.PostID], rev)
+	}
+	categories, err := e.repo.FindCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := e.repo.FindTags(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if categories == nil {
+		categories = make([]posts.Category, 0)
+	}
+	if tags == nil {
+		tags = make([]posts.Tag, 0)
+	}
+
+	return &Archive{
+		e:          e,
+		posts:      items,
+		comments:   commentsByPost,
+		revisions:  revisionsByPost,
+		categories: categories,
+		tags:       tags,
+		manifest: Manifest{
+			Version:     FormatVersion,
+			GeneratedAt: time.Now().UTC(),
+			Filter:      f,
+			Posts:       len(items),
+			Comments:    len(commentList),
+			Revisions:   len(revisionList),
+		},
+	}, nil
+}
+
+// Write streams the archive as a zip file to w.
+func (a *Archive) Write(w io.Writer) (*Manifest, error) {
+	e, m := a.e, a.manifest
+
+	zw := zip.NewWriter(w)
+	uploads := make(map[string]bool)
+	for i := range a.posts {
+		p := a.posts[i]
+		p.Body = e.localizeUploads(p.Body, uploads)
+		p.CoverURL = e.localizeUploads(p.CoverURL, uploads)
+
+		if err := writeFile(zw, "posts/"+p.Slug+".md", []byte(postMarkdown(&p))); err != nil {
+			return nil, err
+		}
+		if list := a.comments[p.ID]; len(list) > 0 {
+			if err := writeJSON(zw, "comments/"+p.Slug+".json", list); err != nil {
+				return nil, err
+			}
+		}
+		if list := a.revisions[p.ID]; len(list) > 0 {
+			if err := writeJSON(zw, "revisions/"+p.Slug+".json", list); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := writeJSON(zw, "taxonomy/categories.json", a.categories); err != nil {
+		return nil, err
+	}
+	if err := writeJSON(zw, "taxonomy/tags.json", a.tags); err != nil {
+		return nil, err
+	}
+
+	for _, name := range sortedKeys(uploads) {
+		ok, err := e.copyUpload(zw, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			m.Files++
+		} else {
+			m.MissingFiles = append(m.MissingFiles, name)
+		}
+	}
+
+	if err := writeJSON(zw, "manifest.json", m); err != nil {
+		return nil, err
+	}
+	return &m, zw.Close()
+}
+
+// localizeUploads rewrites links to local uploads as archive-relative paths
+// and records the referenced files.
+func (e *Exporter) localizeUploads(s string, seen map[string]bool) string {
+	return e.uploadRe.ReplaceAllStringFunc(s, func(m string) string {
+		name := path.Clean(e.uploadRe.FindStringSubmatch(m)[1])
+		if strings.HasPrefix(name, "..") {
+			return m
+		}
+		seen[name] = true
+		return "../uploads/" + name
+	})
+}
+
+// copyUpload adds uploads/<name> to the archive. It reports false if the
+// file no longer exists on disk.
+func (e *Exporter) copyUpload(zw *zip.Writer, name string) (bool, error) {
+	f, err := os.Open(filepath.Join(e.uploadDir, filepath.FromSlash(name)))
+	if err != nil {
+		return false, nil
+	}
+	defer f.Close()
+
+	dst, err := zw.Create("uploads/" + name)
+	if err != nil {
+		return false, err
+	}
+	_, err = io.Copy(dst, f)
+	return err == nil, err
+}
+
+// postMarkdown renders a post as Markdown with front-matter in the format
+// the Markdown importer reads.
+func postMarkdown(p *posts.Post) string {
+	var b strings.Builder
+	b.WriteString("---\n")
+	field := func(key, val string) {
+		if val != "" {
+			b.WriteString(key + ": " + yamlString(val) + "\n")
+		}
+	}
+	field("id", p.ID)
+	field("title", p.Title)
+	field("slug", p.Slug)
+	field("status", string(p.Status))
+	field("language", string(p.Language))
+	if p.Status != posts.StatusPublished {
+		b.WriteString("draft: true\n")
+	}
+	if p.PublishedAt != nil {
+		field("date", p.PublishedAt.UTC().Format(time.RFC3339))
+	}
+	field("created_at", p.CreatedAt.UTC().Format(time.RFC3339))
+	field("updated_at", p.UpdatedAt.UTC().Format(time.RFC3339))
+	if p.Author != nil {
+		field("author", p.Author.Name)
+	}
+	field("author_id", p.AuthorID)
+	if len(p.Authors) > 1 {
+		b.WriteString("authors:\n")
+		for _, c := range p.Authors {
+			b.WriteString("  - " + yamlString(c.Name+" ("+string(c.Role)+")") + "\n")
+		}
+	}
+	if p.Category != nil {
+		field("category", p.Category.Name)
+	}
+	if len(p.Tags) > 0 {
+		b.WriteString("tags:\n")
+		for _, t := range p.Tags {
+			b.WriteString("  - " + yamlString(t.Name) + "\n")
+		}
+	}
+	field("excerpt", p.Excerpt)
+	field("cover", p.CoverURL)
+	field("meta_title", p.MetaTitle)
+	field("meta_description", p.MetaDesc)
+	b.WriteString("---\n\n")
+	b.WriteString(p.Body)
+	if !strings.HasSuffix(p.Body, "\n") {
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// yamlString quotes s as a YAML double-quoted scalar (a JSON string is one).
+func yamlString(s string) string {
+	var b strings.Builder
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func writeFile(zw *zip.Writer, name string, data []byte) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func writeJSON(zw *zip.Writer, name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(zw, name, data)
+}
+
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
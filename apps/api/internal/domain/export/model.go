@@ -0,0 +1,56 @@
+package export
+
+import (
+	"time"
+
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+// FormatVersion is bumped when the archive layout changes.
+const FormatVersion = 1
+
+// Filter scopes an export. Empty fields match everything; the date range
+// applies to published_at, or created_at for unpublished posts.
+type Filter struct {
+	AuthorID     string     `json:"author_id,omitempty"`
+	CategorySlug string     `json:"category,omitempty"`
+	From         *time.Time `json:"from,omitempty"`
+	To           *time.Time `json:"to,omitempty"`
+}
+
+// SetRange parses from/to as RFC 3339 timestamps or YYYY-MM-DD dates. A
+// date-only "to" includes that whole day.
+func (f *Filter) SetRange(from, to string) error {
+	var err error
+	f.From, f.To, err = utils.ParseDateRange(from, to)
+	return err
+}
+
+// Comment is a comment as stored in an archive. Commenter emails and IP
+// addresses are never exported.
+type Comment struct {
+	ID         string    `json:"id"`
+	PostID     string    `json:"post_id"`
+	ParentID   *string   `json:"parent_id,omitempty"`
+	AuthorName string    `json:"author_name"`
+	Content    string    `json:"content"`
+	Status     string    `json:"status"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Manifest describes an archive; it is written as manifest.json.
+type Manifest struct {
+	Version      int       `json:"version"`
+	GeneratedAt  time.Time `json:"generated_at"`
+	Filter       Filter    `json:"filter"`
+	Posts        int       `json:"posts"`
+	Comments     int       `json:"comments"`
+	Revisions    int       `json:"revisions"`
+	Files        int       `json:"files"`
+	MissingFiles []string  `json:"missing_files,omitempty"`
+}
+
+// Filename is the download name of an archive generated at t.
+func Filename(t time.Time) string {
+	return "netpulse-export-" + t.UTC().Format("20060102-150405") + ".zip"
+}
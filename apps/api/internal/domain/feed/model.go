@@ -0,0 +1,62 @@
+package feed
+
+import "time"
+
+// MaxSitemapURLs is the sitemap protocol limit of URLs per sitemap file.
+const MaxSitemapURLs = 50000
+
+// EntryKind identifies what a sitemap entry points at.
+type EntryKind string
+
+const (
+	KindPost     EntryKind = "post"
+	KindCategory EntryKind = "category"
+	KindTag      EntryKind = "tag"
+	KindAuthor   EntryKind = "author"
+	KindListing  EntryKind = "listing"
+)
+
+// Entry is a raw sitemap row; the handler maps Key to a public URL.
+type Entry struct {
+	Kind    EntryKind
+	Key     string // slug, or user ID for authors
+	LastMod time.Time
+}
+
+// URL is a single <url> element in a sitemap.
+type URL struct {
+	Loc        string
+	LastMod    time.Time
+	ChangeFreq string
+	Priority   string
+}
+
+// Channel describes a syndication feed.
+type Channel struct {
+	Title       string
+	Description string
+	Link        string // HTML page the feed represents
+	FeedURL     string // self link
+	Language    string
+	UpdatedAt   time.Time
+}
+
+// Item is a single feed entry.
+type Item struct {
+	ID          string
+	Title       string
+	Link        string
+	Summary     string
+	Content     string
+	AuthorName  string
+	Category    string
+	PublishedAt time.Time
+	UpdatedAt   time.Time
+}
+
+// Filter narrows a feed to one category or tag (by slug).
+type Filter struct {
+	CategorySlug string
+	TagSlug      string
+	Limit        int
+}
@@ -0,0 +1,290 @@
+package feed
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"time"
+)
+
+const (
+	sitemapNS = "http://www.sitemaps.org/schemas/sitemap/0.9"
+	atomNS    = "http://www.w3.org/2005/Atom"
+	jsonFeed  = "https://jsonfeed.org/version/1.1"
+)
+
+// ── Sitemap ─────────────────────────────────────────
+
+// WriteURLSetStart writes the XML header and opening <urlset>.
+func WriteURLSetStart(w io.Writer) error {
+	_, err := io.WriteString(w, xml.Header+`<urlset xmlns="`+sitemapNS+`">`+"\n")
+	return err
+}
+
+// WriteURL writes one <url> element.
+func WriteURL(w io.Writer, u URL) error {
+	type xmlURL struct {
+		XMLName    xml.Name `xml:"url"`
+		Loc        string   `xml:"loc"`
+		LastMod    string   `xml:"lastmod,omitempty"`
+		ChangeFreq string   `xml:"changefreq,omitempty"`
+		Priority   string   `xml:"priority,omitempty"`
+	}
+	x := xmlURL{Loc: u.Loc, ChangeFreq: u.ChangeFreq, Priority: u.Priority}
+	if !u.LastMod.IsZero() {
+		x.LastMod = u.LastMod.UTC().Format(time.RFC3339)
+	}
+	b, err := xml.Marshal(x)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+// WriteURLSetEnd closes the <urlset>.
+func WriteURLSetEnd(w io.Writer) error {
+	_, err := io.WriteString(w, "</urlset>\n")
+	return err
+}
+
+// WriteSitemapIndex writes a <sitemapindex> pointing at child sitemaps.
+func WriteSitemapIndex(w io.Writer, locs []string, lastMod time.Time) error {
+	type xmlSitemap struct {
+		Loc     string `xml:"loc"`
+		LastMod string `xml:"lastmod,omitempty"`
+	}
+	type xmlIndex struct {
+		XMLName  xml.Name     `xml:"sitemapindex"`
+		XMLNS    string       `xml:"xmlns,attr"`
+		Sitemaps []xmlSitemap `xml:"sitemap"`
+	}
+	idx := xmlIndex{XMLNS: sitemapNS}
+	for _, loc := range locs {
+		s := xmlSitemap{Loc: loc}
+		if !lastMod.IsZero() {
+			s.LastMod = lastMod.UTC().Format(time.RFC3339)
+		}
+		idx.Sitemaps = append(idx.Sitemaps, s)
+	}
+	return writeXML(w, idx)
+}
+
+// ── RSS 2.0 ─────────────────────────────────────────
+
+// WriteRSS renders an RSS 2.0 feed.
+func WriteRSS(w io.Writer, ch Channel, items []Item) error {
+	type rssGUID struct {
+		IsPermaLink bool   `xml:"isPermaLink,attr"`
+		Value       string `xml:",chardata"`
+	}
+	type rssItem struct {
+		Title       string  `xml:"title"`
+		Link        string  `xml:"link"`
+		GUID        rssGUID `xml:"guid"`
+		Description string  `xml:"description,omitempty"`
+		Author      string  `xml:"dc:creator,omitempty"`
+		Category    string  `xml:"category,omitempty"`
+		PubDate     string  `xml:"pubDate"`
+	}
+	type atomLink struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+		Type string `xml:"type,attr"`
+	}
+	type rssChannel struct {
+		Title         string    `xml:"title"`
+		Link          string    `xml:"link"`
+		Description   string    `xml:"description"`
+		Language      string    `xml:"language,omitempty"`
+		LastBuildDate string    `xml:"lastBuildDate"`
+		AtomLink      atomLink  `xml:"atom:link"`
+		Items         []rssItem `xml:"item"`
+	}
+	type rss struct {
+		XMLName xml.Name   `xml:"rss"`
+		Version string     `xml:"version,attr"`
+		Atom    string     `xml:"xmlns:atom,attr"`
+		DC      string     `xml:"xmlns:dc,attr"`
+		Channel rssChannel `xml:"channel"`
+	}
+
+	doc := rss{
+		Version: "2.0",
+		Atom:    atomNS,
+		DC:      "http://purl.org/dc/elements/1.1/",
+		Channel: rssChannel{
+			Title:         ch.Title,
+			Link:          ch.Link,
+			Description:   ch.Description,
+			Language:      ch.Language,
+			LastBuildDate: ch.UpdatedAt.UTC().Format(time.RFC1123Z),
+			AtomLink:      atomLink{Href: ch.FeedURL, Rel: "self", Type: "application/rss+xml"},
+		},
+	}
+	for _, it := range items {
+		doc.Channel.Items = append(doc.Channel.Items, rssItem{
+			Title:       it.Title,
+			Link:        it.Link,
+			GUID:        rssGUID{IsPermaLink: true, Value: it.Link},
+			Description: it.Summary,
+			Author:      it.AuthorName,
+			Category:    it.Category,
+			PubDate:     it.PublishedAt.UTC().Format(time.RFC1123Z),
+		})
+	}
+	return writeXML(w, doc)
+}
+
+// ── Atom 1.0 ────────────────────────────────────────
+
+// WriteAtom renders an Atom 1.0 feed.
+func WriteAtom(w io.Writer, ch Channel, items []Item) error {
+	type link struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr,omitempty"`
+		Type string `xml:"type,attr,omitempty"`
+	}
+	type text struct {
+		Type  string `xml:"type,attr"`
+		Value string `xml:",chardata"`
+	}
+	type person struct {
+		Name string `xml:"name"`
+	}
+	type category struct {
+		Term string `xml:"term,attr"`
+	}
+	type entry struct {
+		ID        string    `xml:"id"`
+		Title     string    `xml:"title"`
+		Link      link      `xml:"link"`
+		Published string    `xml:"published"`
+		Updated   string    `xml:"updated"`
+		Author    *person   `xml:"author,omitempty"`
+		Category  *category `xml:"category,omitempty"`
+		Summary   *text     `xml:"summary,omitempty"`
+		Content   *text     `xml:"content,omitempty"`
+	}
+	type atomFeed struct {
+		XMLName  xml.Name `xml:"feed"`
+		XMLNS    string   `xml:"xmlns,attr"`
+		Lang     string   `xml:"xml:lang,attr,omitempty"`
+		ID       string   `xml:"id"`
+		Title    string   `xml:"title"`
+		Subtitle string   `xml:"subtitle,omitempty"`
+		Updated  string   `xml:"updated"`
+		Links    []link   `xml:"link"`
+		Entries  []entry  `xml:"entry"`
+	}
+
+	doc := atomFeed{
+		XMLNS:    atomNS,
+		Lang:     ch.Language,
+		ID:       ch.FeedURL,
+		Title:    ch.Title,
+		Subtitle: ch.Description,
+		Updated:  ch.UpdatedAt.UTC().Format(time.RFC3339),
+		Links: []link{
+			{Href: ch.Link, Rel: "alternate", Type: "text/html"},
+			{Href: ch.FeedURL, Rel: "self", Type: "application/atom+xml"},
+		},
+	}
+	for _, it := range items {
+		e := entry{
+			ID:        it.Link,
+			Title:     it.Title,
+			Link:      link{Href: it.Link, Rel: "alternate", Type: "text/html"},
+			Published: it.PublishedAt.UTC().Format(time.RFC3339),
+			Updated:   it.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+		if it.AuthorName != "" {
+			e.Author = &person{Name: it.AuthorName}
+		}
+		if it.Category != "" {
+			e.Category = &category{Term: it.Category}
+		}
+		if it.Summary != "" {
+			e.Summary = &text{Type: "text", Value: it.Summary}
+		}
+		if it.Content != "" {
+			e.Content = &text{Type: "text", Value: it.Content}
+		}
+		doc.Entries = append(doc.Entries, e)
+	}
+	return writeXML(w, doc)
+}
+
+// ── JSON Feed 1.1 ───────────────────────────────────
+
+// WriteJSONFeed renders a JSON Feed 1.1 document.
+func WriteJSONFeed(w io.Writer, ch Channel, items []Item) error {
+	type author struct {
+		Name string `json:"name"`
+	}
+	type item struct {
+		ID            string   `json:"id"`
+		URL           string   `json:"url"`
+		Title         string   `json:"title"`
+		Summary       string   `json:"summary,omitempty"`
+		ContentText   string   `json:"content_text"`
+		DatePublished string   `json:"date_published"`
+		DateModified  string   `json:"date_modified"`
+		Authors       []author `json:"authors,omitempty"`
+		Tags          []string `json:"tags,omitempty"`
+	}
+	type doc struct {
+		Version     string `json:"version"`
+		Title       string `json:"title"`
+		HomePageURL string `json:"home_page_url"`
+		FeedURL     string `json:"feed_url"`
+		Description string `json:"description,omitempty"`
+		Language    string `json:"language,omitempty"`
+		Items       []item `json:"items"`
+	}
+
+	d := doc{
+		Version:     jsonFeed,
+		Title:       ch.Title,
+		HomePageURL: ch.Link,
+		FeedURL:     ch.FeedURL,
+		Description: ch.Description,
+		Language:    ch.Language,
+		Items:       make([]item, 0, len(items)),
+	}
+	for _, it := range items {
+		ji := item{
+			ID:            it.Link,
+			URL:           it.Link,
+			Title:         it.Title,
+			Summary:       it.Summary,
+			ContentText:   it.Content,
+			DatePublished: it.PublishedAt.UTC().Format(time.RFC3339),
+			DateModified:  it.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+		if ji.ContentText == "" {
+			ji.ContentText = it.Summary
+		}
+		if it.AuthorName != "" {
+			ji.Authors = []author{{Name: it.AuthorName}}
+		}
+		if it.Category != "" {
+			ji.Tags = []string{it.Category}
+		}
+		d.Items = append(d.Items, ji)
+	}
+	return json.NewEncoder(w).Encode(d)
+}
+
+func writeXML(w io.Writer, v interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("encode xml: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,98 @@
+package imports
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"path"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// maxRedirects caps the redirects followed when downloading an image.
+const maxRedirects = 3
+
+// ErrAssetTooLarge is returned for files over MaxAssetSize.
+var ErrAssetTooLarge = fmt.Errorf("file exceeds %d MB", MaxAssetSize>>20)
+
+// errSVG is returned for SVG images. SVG can carry script, so imports do
+// not copy it into the media library.
+var errSVG = errors.New("SVG images are not imported")
+
+// isSVG reports whether an image is SVG by its file name or content type
+// (empty for archive files). Both count: the media library picks the type
+// from the extension, the browser may go by the content type.
+func isSVG(name, contentType string) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	return strings.EqualFold(path.Ext(name), ".svg") || mediaType == "image/svg+xml"
+}
+
+// errForbiddenAddress is returned when an image URL resolves to an address
+// that is not on the public internet.
+var errForbiddenAddress = errors.New("address not allowed")
+
+// cgnat is the shared address space (RFC 6598), not covered by IsPrivate.
+var cgnat = &net.IPNet{IP: net.IP{100, 64, 0, 0}, Mask: net.CIDRMask(10, 32)}
+
+// publicIP reports whether ip is a public unicast address.
+func publicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !ip.IsLoopback() &&
+		!ip.IsLinkLocalUnicast() && !cgnat.Contains(ip)
+}
+
+// newImageClient returns a client for downloading images named in an
+// archive. Archives are untrusted, so it only connects to public addresses
+// (checked after DNS resolution, which also covers redirects) and ignores
+// proxy settings that would hide the real address.
+func newImageClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+				return fmt.Errorf("%s: %w", host, errForbiddenAddress)
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Timeout: 30 * time.Second,
+		Transport: &http.Transport{
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ResponseHeaderTimeout: 15 * time.Second,
+			MaxIdleConns:          10,
+			IdleConnTimeout:       30 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxRedirects)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("redirect to unsupported scheme %q", req.URL.Scheme)
+			}
+			return nil
+		},
+	}
+}
+
+// readAsset reads a whole file, failing instead of truncating when it is
+// larger than MaxAssetSize.
+func readAsset(r io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxAssetSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxAssetSize {
+		return nil, ErrAssetTooLarge
+	}
+	return data, nil
+}
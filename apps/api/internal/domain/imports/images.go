@@ -0,0 +1,44 @@
+package imports
+
+import (
+	"regexp"
+	"strings"
+)
+
+// zipScheme marks an asset path inside a Markdown bundle.
+const zipScheme = "zip:"
+
+var (
+	mdImageRe   = regexp.MustCompile(`(!\[[^\]]*\]\(\s*<?)([^)\s>]+)`)
+	htmlImageRe = regexp.MustCompile(`(?i)(<img\b[^>]*?\ssrc\s*=\s*["'])([^"']+)`)
+)
+
+// rewriteImages replaces the URL of every Markdown and HTML image in body
+// with fn(url).
+func rewriteImages(body string, fn func(string) string) string {
+	replace := func(re *regexp.Regexp) {
+		body = re.ReplaceAllStringFunc(body, func(m string) string {
+			sub := re.FindStringSubmatch(m)
+			return sub[1] + fn(sub[2])
+		})
+	}
+	replace(mdImageRe)
+	replace(htmlImageRe)
+	return body
+}
+
+// imageURLs lists the distinct image URLs of body and cover, in order.
+func imageURLs(body, cover string) []string {
+	var urls []string
+	seen := make(map[string]bool)
+	add := func(u string) string {
+		if u != "" && !seen[u] && !strings.HasPrefix(u, "data:") {
+			seen[u] = true
+			urls = append(urls, u)
+		}
+		return u
+	}
+	add(cover)
+	rewriteImages(body, add)
+	return urls
+}
@@ -0,0 +1,250 @@
+package imports
+
+import (
+	"archive/zip"
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rapidtest/netpulse-api/internal/domain/posts"
+)
+
+// MaxAssetSize caps a single file read from an archive or downloaded image.
+const MaxAssetSize = 10 << 20
+
+// Bundle is a parsed Markdown zip: the articles plus every other file in it,
+// so relative image paths can be resolved.
+type Bundle struct {
+	Articles []Article
+	files    map[string]*zip.File
+}
+
+// Open returns a non-Markdown file of the bundle by its cleaned path.
+func (b *Bundle) Open(name string) (io.ReadCloser, bool) {
+	if b == nil {
+		return nil, false
+	}
+	f, ok := b.files[path.Clean(strings.TrimPrefix(name, "/"))]
+	if !ok {
+		return nil, false
+	}
+	rc, err := f.Open()
+	if err != nil {
+		return nil, false
+	}
+	return rc, true
+}
+
+// ParseMarkdownZip reads every .md/.markdown file of a zip archive. Each file
+// may start with YAML front-matter between --- lines; supported keys are
+// title, slug, date (or published_at), draft, status, excerpt (or
+// description), cover (or image), category (or categories), tags and
+// language (or lang).
+func ParseMarkdownZip(data []byte) (*Bundle, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid zip file: %w", err)
+	}
+
+	b := &Bundle{files: make(map[string]*zip.File)}
+	var docs []*zip.File
+	for _, f := range zr.File {
+		if f.FileInfo().IsDir() || strings.HasPrefix(path.Base(f.Name), ".") || strings.HasPrefix(f.Name, "__MACOSX/") {
+			continue
+		}
+		switch strings.ToLower(path.Ext(f.Name)) {
+		case ".md", ".markdown":
+			docs = append(docs, f)
+		default:
+			b.files[path.Clean(f.Name)] = f
+		}
+	}
+	if len(docs) == 0 {
+		return nil, fmt.Errorf("zip contains no Markdown files")
+	}
+
+	for _, f := range docs {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		raw, err := readAsset(rc)
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		b.Articles = append(b.Articles, parseMarkdownFile(f.Name, string(raw)))
+	}
+	return b, nil
+}
+
+func parseMarkdownFile(name, src string) Article {
+	meta, body := splitFrontMatter(src)
+
+	a := Article{
+		Source:    name,
+		Title:     meta.str("title"),
+		Slug:      meta.str("slug"),
+		Body:      strings.TrimSpace(body),
+		Excerpt:   meta.str("excerpt", "description", "summary"),
+		CoverURL:  meta.str("cover", "cover_image", "image", "featured_image"),
+		Published: true,
+	}
+	if a.Slug == "" {
+		a.Slug = strings.TrimSuffix(path.Base(name), path.Ext(name))
+	}
+	if a.Title == "" {
+		a.Title = a.Slug
+	}
+	if meta.str("draft") == "true" || strings.EqualFold(meta.str("status"), "draft") {
+		a.Published = false
+	}
+	if t, ok := parseDate(meta.str("published_at", "date")); ok {
+		a.PublishedAt = &t
+	}
+	if cats := meta.list("categories", "category"); len(cats) > 0 {
+		a.Category = cats[0]
+	}
+	a.Tags = meta.list("tags")
+	if lang, ok := posts.ParseLanguage(meta.str("language", "lang")); ok {
+		a.Language = string(lang)
+	}
+
+	// Relative asset paths are resolved against the Markdown file's folder.
+	dir := path.Dir(name)
+	a.Body = rewriteImages(a.Body, func(u string) string { return resolveAsset(dir, u) })
+	if a.CoverURL != "" {
+		a.CoverURL = resolveAsset(dir, a.CoverURL)
+	}
+	return a
+}
+
+// resolveAsset turns a relative reference into a "zip:" path inside the
+// bundle; absolute URLs and root-relative paths are returned unchanged.
+func resolveAsset(dir, u string) string {
+	if strings.Contains(u, "://") || strings.HasPrefix(u, "/") || strings.HasPrefix(u, "data:") {
+		return u
+	}
+	return zipScheme + path.Join(dir, u)
+}
+
+// frontMatter is the subset of YAML used by static site generators:
+// scalars, inline [a, b] lists and "- item" block lists.
+type frontMatter map[string][]string
+
+func (m frontMatter) str(keys ...string) string {
+	for _, k := range keys {
+		if v := m[k]; len(v) > 0 {
+			return v[0]
+		}
+	}
+	return ""
+}
+
+func (m frontMatter) list(keys ...string) []string {
+	for _, k := range keys {
+		if v := m[k]; len(v) > 0 {
+			return v
+		}
+	}
+	return nil
+}
+
+// splitFrontMatter separates leading front-matter from the Markdown body.
+func splitFrontMatter(src string) (frontMatter, string) {
+	src = strings.TrimPrefix(strings.ReplaceAll(src, "\r\n", "\n"), "\ufeff")
+	meta := frontMatter{}
+	if !strings.HasPrefix(src, "---\n") {
+		return meta, src
+	}
+	end := strings.Index(src[4:], "\n---")
+	if end < 0 {
+		return meta, src
+	}
+	header := src[4 : 4+end]
+	body := src[4+end+4:]
+	if i := strings.IndexByte(body, '\n'); i >= 0 {
+		body = body[i+1:]
+	} else {
+		body = ""
+	}
+
+	var key string
+	lines := strings.Split(header, "\n")
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "- ") && key != "" {
+			meta[key] = append(meta[key], unquote(strings.TrimSpace(trimmed[2:])))
+			continue
+		}
+		colon := strings.IndexByte(line, ':')
+		if colon < 0 || line != strings.TrimLeft(line, " \t") {
+			continue // nested maps are not supported
+		}
+		key = strings.ToLower(strings.TrimSpace(line[:colon]))
+		val := strings.TrimSpace(line[colon+1:])
+		switch {
+		case val == "":
+			meta[key] = nil
+		case val == "|" || val == ">" || val == "|-" || val == ">-":
+			// Block scalar: take the following indented lines.
+			var block []string
+			for i+1 < len(lines) && (strings.HasPrefix(lines[i+1], " ") || strings.TrimSpace(lines[i+1]) == "") {
+				i++
+				block = append(block, strings.TrimSpace(lines[i]))
+			}
+			sep := " "
+			if val[0] == '|' {
+				sep = "\n"
+			}
+			meta[key] = []string{strings.TrimSpace(strings.Join(block, sep))}
+		case strings.HasPrefix(val, "[") && strings.HasSuffix(val, "]"):
+			var items []string
+			for _, part := range strings.Split(val[1:len(val)-1], ",") {
+				if part = unquote(strings.TrimSpace(part)); part != "" {
+					items = append(items, part)
+				}
+			}
+			meta[key] = items
+		default:
+			meta[key] = []string{unquote(val)}
+		}
+	}
+	return meta, body
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		if v, err := strconv.Unquote(s); err == nil {
+			return v
+		}
+		return s[1 : len(s)-1]
+	}
+	if len(s) >= 2 && s[0] == '\'' && s[len(s)-1] == '\'' {
+		return strings.ReplaceAll(s[1:len(s)-1], "''", "'")
+	}
+	if i := strings.Index(s, " #"); i >= 0 {
+		return strings.TrimSpace(s[:i])
+	}
+	return s
+}
+
+func parseDate(s string) (time.Time, bool) {
+	for _, layout := range []string{
+		time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05 -0700",
+		"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02",
+	} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
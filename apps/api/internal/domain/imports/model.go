@@ -0,0 +1,93 @@
+package imports
+
+import "time"
+
+// Format is the kind of archive being imported.
+type Format string
+
+const (
+	FormatWXR      Format = "wxr"      // WordPress eXtended RSS export
+	FormatMarkdown Format = "markdown" // zip of Markdown files with YAML front-matter
+)
+
+// JobStatus is the lifecycle state of an import job.
+type JobStatus string
+
+const (
+	JobPending JobStatus = "PENDING"
+	JobRunning JobStatus = "RUNNING"
+	JobDone    JobStatus = "DONE"
+	JobFailed  JobStatus = "FAILED"
+)
+
+// ItemStatus is the outcome for a single imported article.
+type ItemStatus string
+
+const (
+	ItemCreated     ItemStatus = "created"
+	ItemWouldCreate ItemStatus = "would_create" // dry run
+	ItemSkipped     ItemStatus = "skipped"
+	ItemFailed      ItemStatus = "failed"
+)
+
+// Job is an import run with its progress and per-item results.
+type Job struct {
+	ID         string       `json:"id"`
+	Format     Format       `json:"format"`
+	Filename   string       `json:"filename"`
+	DryRun     bool         `json:"dry_run"`
+	AuthorID   string       `json:"author_id"`
+	Status     JobStatus    `json:"status"`
+	Total      int          `json:"total"`
+	Processed  int          `json:"processed"`
+	Created    int          `json:"created"`
+	Skipped    int          `json:"skipped"`
+	Failed     int          `json:"failed"`
+	Error      string       `json:"error,omitempty"`
+	Items      []ItemResult `json:"items,omitempty"`
+	CreatedBy  string       `json:"created_by"`
+	CreatedAt  time.Time    `json:"created_at"`
+	StartedAt  *time.Time   `json:"started_at,omitempty"`
+	FinishedAt *time.Time   `json:"finished_at,omitempty"`
+}
+
+// ItemResult reports what happened (or, in a dry run, would happen) to one article.
+type ItemResult struct {
+	Index         int        `json:"index"`
+	Source        string     `json:"source,omitempty"` // file name in a Markdown zip
+	Title         string     `json:"title"`
+	Slug          string     `json:"slug"`
+	Status        ItemStatus `json:"status"`
+	PostID        string     `json:"post_id,omitempty"`
+	Published     bool       `json:"published"`
+	PublishedAt   *time.Time `json:"published_at,omitempty"`
+	Category      string     `json:"category,omitempty"`
+	Tags          []string   `json:"tags,omitempty"`
+	NewCategories []string   `json:"new_categories,omitempty"`
+	NewTags       []string   `json:"new_tags,omitempty"`
+	Images        int        `json:"images"`
+	Warnings      []string   `json:"warnings,omitempty"`
+	Error         string     `json:"error,omitempty"`
+}
+
+// Options control how an import runs.
+type Options struct {
+	DryRun    bool
+	AuthorID  string // owner of the imported posts
+	CreatedBy string // admin who started the import
+}
+
+// Article is one post parsed from an archive.
+type Article struct {
+	Source      string
+	Title       string
+	Slug        string
+	Body        string
+	Excerpt     string
+	CoverURL    string
+	Published   bool
+	PublishedAt *time.Time
+	Category    string
+	Tags        []string
+	Language    string // "id" or "en"; detected on import when empty
+}
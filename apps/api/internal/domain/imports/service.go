@@ -0,0 +1,385 @@
+package imports
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/rapidtest/netpulse-api/internal/domain/posts"
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+// Repository persists import jobs and their progress.
+type Repository interface {
+	CreateJob(ctx context.Context, job *Job) error
+	UpdateJob(ctx context.Context, job *Job) error
+	FindJob(ctx context.Context, id string) (*Job, error)
+	FindJobs(ctx context.Context, limit int) ([]Job, error)
+	FailUnfinished(ctx context.Context, reason string) (int64, error)
+}
+
+// TermRepository looks up and creates categories or tags by name.
+type TermRepository interface {
+	FindIDByName(ctx context.Context, name string) (string, error)
+	EnsureByName(ctx context.Context, name string) (string, error)
+}
+
+// MediaStore saves a file into the media library and returns its public URL.
+type MediaStore func(ctx context.Context, name string, src io.Reader, uploadedBy string) (string, error)
+
+// Service runs imports in the background and records their progress.
+// Running jobs stop with the context given to NewService; Wait blocks
+// until they have.
+type Service struct {
+	repo       Repository
+	posts      *posts.Service
+	categories TermRepository
+	tags       TermRepository
+	media      MediaStore
+	client     *http.Client
+	ctx        context.Context
+	wg         sync.WaitGroup
+}
+
+func NewService(ctx context.Context, repo Repository, postsSvc *posts.Service, categories, tags TermRepository, media MediaStore) *Service {
+	return &Service{
+		repo:       repo,
+		posts:      postsSvc,
+		categories: categories,
+		tags:       tags,
+		media:      media,
+		client:     newImageClient(),
+		ctx:        ctx,
+	}
+}
+
+// FailInterrupted marks jobs left pending or running by a previous process
+// (for example after a crash) as failed. Call it once at startup.
+func (s *Service) FailInterrupted(ctx context.Context) (int64, error) {
+	return s.repo.FailUnfinished(ctx, "interrupted by server restart")
+}
+
+// Wait blocks until all running jobs have returned.
+func (s *Service) Wait() {
+	s.wg.Wait()
+}
+
+// Start parses the archive, records a job and processes it in the background.
+// Parse errors are returned directly; per-article errors end up in the job.
+func (s *Service) Start(ctx context.Context, format Format, filename string, data []byte, opts Options) (*Job, error) {
+	var articles []Article
+	var bundle *Bundle
+	switch format {
+	case FormatWXR:
+		parsed, err := ParseWXR(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		articles = parsed
+	case FormatMarkdown:
+		parsed, err := ParseMarkdownZip(data)
+		if err != nil {
+			return nil, err
+		}
+		bundle, articles = parsed, parsed.Articles
+	default:
+		return nil, fmt.Errorf("unsupported import format: %s", format)
+	}
+	if len(articles) == 0 {
+		return nil, fmt.Errorf("no posts found in %s", filename)
+	}
+
+	job := &Job{
+		ID:        utils.NewID(),
+		Format:    format,
+		Filename:  filename,
+		DryRun:    opts.DryRun,
+		AuthorID:  opts.AuthorID,
+		Status:    JobPending,
+		Total:     len(articles),
+		CreatedBy: opts.CreatedBy,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.CreateJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	// The request context ends with the response; the job outlives it
+	// and runs until the service's context is cancelled.
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.run(s.ctx, *job, articles, bundle)
+	}()
+	return job, nil
+}
+
+// GetJob returns a job with its per-item results.
+func (s *Service) GetJob(ctx context.Context, id string) (*Job, error) {
+	return s.repo.FindJob(ctx, id)
+}
+
+// ListJobs returns recent jobs, newest first, without item results.
+func (s *Service) ListJobs(ctx context.Context) ([]Job, error) {
+	return s.repo.FindJobs(ctx, 50)
+}
+
+func (s *Service) run(ctx context.Context, job Job, articles []Article, bundle *Bundle) {
+	// Progress is saved even after ctx is cancelled, so an interrupted
+	// job ends up FAILED instead of staying RUNNING.
+	saveCtx := context.WithoutCancel(ctx)
+	defer func() {
+		if r := recover(); r != nil {
+			job.Status = JobFailed
+			job.Error = fmt.Sprint(r)
+			s.finish(saveCtx, &job)
+		}
+	}()
+
+	now := time.Now()
+	job.Status = JobRunning
+	job.StartedAt = &now
+	_ = s.repo.UpdateJob(saveCtx, &job)
+
+	st := &runState{images: make(map[string]string), slugs: make(map[string]bool)}
+	for i, a := range articles {
+		if ctx.Err() != nil {
+			job.Status = JobFailed
+			job.Error = fmt.Sprintf("interrupted by server shutdown after %d of %d posts", job.Processed, job.Total)
+			s.finish(saveCtx, &job)
+			return
+		}
+		res := s.importArticle(ctx, &job, i, a, bundle, st)
+		job.Items = append(job.Items, res)
+		job.Processed++
+		switch res.Status {
+		case ItemCreated, ItemWouldCreate:
+			job.Created++
+		case ItemSkipped:
+			job.Skipped++
+		case ItemFailed:
+			job.Failed++
+		}
+		if err := s.repo.UpdateJob(saveCtx, &job); err != nil {
+			log.Error().Err(err).Str("job_id", job.ID).Msg("failed to save import progress")
+		}
+	}
+
+	job.Status = JobDone
+	s.finish(saveCtx, &job)
+}
+
+// runState is shared by the articles of one job.
+type runState struct {
+	images map[string]string // source URL -> media library URL, so shared images are stored once
+	slugs  map[string]bool   // slugs claimed earlier in this job
+}
+
+func (s *Service) finish(ctx context.Context, job *Job) {
+	now := time.Now()
+	job.FinishedAt = &now
+	if err := s.repo.UpdateJob(ctx, job); err != nil {
+		log.Error().Err(err).Str("job_id", job.ID).Msg("failed to save import result")
+	}
+	log.Info().Str("job_id", job.ID).Int("created", job.Created).Int("skipped", job.Skipped).
+		Int("failed", job.Failed).Bool("dry_run", job.DryRun).Msg("import finished")
+}
+
+func (s *Service) importArticle(ctx context.Context, job *Job, index int, a Article, bundle *Bundle, st *runState) ItemResult {
+	res := ItemResult{
+		Index:       index,
+		Source:      a.Source,
+		Title:       a.Title,
+		Slug:        utils.Slugify(a.Slug),
+		Published:   a.Published,
+		PublishedAt: a.PublishedAt,
+		Category:    a.Category,
+		Tags:        a.Tags,
+	}
+	if res.Slug == "" {
+		res.Slug = utils.Slugify(a.Title)
+	}
+	fail := func(status ItemStatus, err error) ItemResult {
+		res.Status = status
+		res.Error = err.Error()
+		return res
+	}
+
+	if strings.TrimSpace(a.Title) == "" || strings.TrimSpace(a.Body) == "" {
+		return fail(ItemFailed, fmt.Errorf("title and body are required"))
+	}
+	if ok, err := s.posts.SlugAvailable(ctx, res.Slug); err != nil {
+		return fail(ItemFailed, err)
+	} else if !ok || st.slugs[res.Slug] {
+		return fail(ItemSkipped, posts.ErrSlugConflict)
+	}
+	st.slugs[res.Slug] = true
+
+	input := posts.CreatePostInput{
+		Title:    a.Title,
+		Slug:     res.Slug,
+		Body:     a.Body,
+		Excerpt:  a.Excerpt,
+		CoverURL: a.CoverURL,
+		Language: a.Language,
+	}
+
+	// Categories and tags: looked up in a dry run, created as needed otherwise.
+	if a.Category != "" {
+		id, err := s.term(ctx, s.categories, a.Category, job.DryRun)
+		if err != nil {
+			return fail(ItemFailed, fmt.Errorf("category %q: %w", a.Category, err))
+		}
+		if id == "" {
+			res.NewCategories = append(res.NewCategories, a.Category)
+		}
+		input.CategoryID = id
+	}
+	for _, name := range a.Tags {
+		id, err := s.term(ctx, s.tags, name, job.DryRun)
+		if err != nil {
+			return fail(ItemFailed, fmt.Errorf("tag %q: %w", name, err))
+		}
+		if id == "" {
+			res.NewTags = append(res.NewTags, name)
+			continue
+		}
+		input.TagIDs = append(input.TagIDs, id)
+	}
+
+	images := imageURLs(a.Body, a.CoverURL)
+	res.Images = len(images)
+	if job.DryRun {
+		for _, u := range images {
+			if isSVG(strings.SplitN(u, "?", 2)[0], "") {
+				res.Warnings = append(res.Warnings, fmt.Sprintf("image %s: %v", strings.TrimPrefix(u, zipScheme), errSVG))
+				continue
+			}
+			if strings.HasPrefix(u, zipScheme) {
+				if rc, ok := bundle.Open(strings.TrimPrefix(u, zipScheme)); ok {
+					rc.Close()
+				} else {
+					res.Warnings = append(res.Warnings, "image not found in archive: "+strings.TrimPrefix(u, zipScheme))
+				}
+			}
+		}
+		res.Status = ItemWouldCreate
+		return res
+	}
+
+	// Copy images into the media library and point the post at the copies.
+	for _, u := range images {
+		if _, ok := st.images[u]; ok {
+			continue
+		}
+		newURL, err := s.storeImage(ctx, u, bundle, job.CreatedBy)
+		if err != nil {
+			res.Warnings = append(res.Warnings, err.Error())
+			continue
+		}
+		st.images[u] = newURL
+	}
+	mapURL := func(u string) string {
+		if v, ok := st.images[u]; ok {
+			return v
+		}
+		return strings.TrimPrefix(u, zipScheme)
+	}
+	input.Body = rewriteImages(input.Body, mapURL)
+	if input.CoverURL != "" {
+		input.CoverURL = mapURL(input.CoverURL)
+	}
+
+	post, err := s.posts.Create(ctx, input, job.AuthorID)
+	if err != nil {
+		return fail(ItemFailed, err)
+	}
+	res.PostID = post.ID
+
+	if a.Published {
+		at := time.Now()
+		if a.PublishedAt != nil {
+			at = *a.PublishedAt
+		}
+		if err := s.posts.PublishAt(ctx, post.ID, at); err != nil {
+			res.Warnings = append(res.Warnings, "imported as draft: "+err.Error())
+		}
+	}
+
+	res.Status = ItemCreated
+	return res
+}
+
+// term resolves a category or tag name to an id. In a dry run nothing is
+// created and "" means the term would be new.
+func (s *Service) term(ctx context.Context, repo TermRepository, name string, dryRun bool) (string, error) {
+	if dryRun {
+		return repo.FindIDByName(ctx, name)
+	}
+	return repo.EnsureByName(ctx, name)
+}
+
+// storeImage copies an image from the archive or the web into the media
+// library. Files over MaxAssetSize and SVG images are rejected.
+func (s *Service) storeImage(ctx context.Context, u string, bundle *Bundle, uploadedBy string) (string, error) {
+	if strings.HasPrefix(u, zipScheme) {
+		name := strings.TrimPrefix(u, zipScheme)
+		if isSVG(name, "") {
+			return "", fmt.Errorf("image %s: %w", name, errSVG)
+		}
+		rc, ok := bundle.Open(name)
+		if !ok {
+			return "", fmt.Errorf("image not found in archive: %s", name)
+		}
+		defer rc.Close()
+		data, err := readAsset(rc)
+		if err != nil {
+			return "", fmt.Errorf("image %s: %w", name, err)
+		}
+		return s.media(ctx, path.Base(name), bytes.NewReader(data), uploadedBy)
+	}
+
+	if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+		return "", fmt.Errorf("image skipped (not an absolute URL): %s", u)
+	}
+	name := path.Base(strings.SplitN(u, "?", 2)[0])
+	if isSVG(name, "") {
+		return "", fmt.Errorf("image %s: %w", u, errSVG)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return "", fmt.Errorf("image %s: %w", u, err)
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("image %s: %w", u, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("image %s: HTTP %d", u, resp.StatusCode)
+	}
+	ct := resp.Header.Get("Content-Type")
+	if !strings.HasPrefix(ct, "image/") {
+		return "", fmt.Errorf("image %s: unexpected content type %q", u, ct)
+	}
+	if isSVG(name, ct) {
+		return "", fmt.Errorf("image %s: %w", u, errSVG)
+	}
+	if resp.ContentLength > MaxAssetSize {
+		return "", fmt.Errorf("image %s: %w", u, ErrAssetTooLarge)
+	}
+	data, err := readAsset(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("image %s: %w", u, err)
+	}
+
+	return s.media(ctx, name, bytes.NewReader(data), uploadedBy)
+}
@@ -0,0 +1,99 @@
+package imports
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// wxrItem is an <item> of a WordPress export. Elements are matched by local
+// name so every WXR version (1.0–1.2) parses the same way.
+type wxrItem struct {
+	Title     string `xml:"title"`
+	PubDate   string `xml:"pubDate"`
+	PostID    string `xml:"post_id"`
+	PostName  string `xml:"post_name"`
+	PostDate  string `xml:"post_date_gmt"`
+	Status    string `xml:"status"`
+	PostType  string `xml:"post_type"`
+	AttachURL string `xml:"attachment_url"`
+	Encoded   []struct {
+		XMLName xml.Name
+		Value   string `xml:",chardata"`
+	} `xml:"encoded"`
+	Terms []struct {
+		Domain   string `xml:"domain,attr"`
+		Nicename string `xml:"nicename,attr"`
+		Name     string `xml:",chardata"`
+	} `xml:"category"`
+	Meta []struct {
+		Key   string `xml:"meta_key"`
+		Value string `xml:"meta_value"`
+	} `xml:"postmeta"`
+}
+
+// ParseWXR reads the posts of a WordPress WXR export. Pages, attachments,
+// trashed posts and auto-drafts are skipped; featured images are resolved
+// through their attachment items.
+func ParseWXR(r io.Reader) ([]Article, error) {
+	var doc struct {
+		Items []wxrItem `xml:"channel>item"`
+	}
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid WXR file: %w", err)
+	}
+
+	attachments := make(map[string]string)
+	for _, it := range doc.Items {
+		if it.PostType == "attachment" && it.AttachURL != "" {
+			attachments[it.PostID] = strings.TrimSpace(it.AttachURL)
+		}
+	}
+
+	var articles []Article
+	for _, it := range doc.Items {
+		if it.PostType != "post" || it.Status == "trash" || it.Status == "auto-draft" {
+			continue
+		}
+		a := Article{
+			Source:    "post " + it.PostID,
+			Title:     strings.TrimSpace(it.Title),
+			Slug:      strings.TrimSpace(it.PostName),
+			Published: it.Status == "publish",
+		}
+		for _, enc := range it.Encoded {
+			switch {
+			case strings.Contains(enc.XMLName.Space, "excerpt"):
+				a.Excerpt = strings.TrimSpace(enc.Value)
+			case strings.Contains(enc.XMLName.Space, "content"):
+				a.Body = strings.TrimSpace(enc.Value)
+			}
+		}
+		for _, t := range it.Terms {
+			name := strings.TrimSpace(t.Name)
+			switch {
+			case name == "":
+			case t.Domain == "category" && a.Category == "" && t.Nicename != "uncategorized":
+				a.Category = name
+			case t.Domain == "post_tag":
+				a.Tags = append(a.Tags, name)
+			}
+		}
+		for _, m := range it.Meta {
+			if m.Key == "_thumbnail_id" {
+				a.CoverURL = attachments[strings.TrimSpace(m.Value)]
+			}
+		}
+		if t, err := time.Parse("2006-01-02 15:04:05", it.PostDate); err == nil && t.Year() > 1 {
+			a.PublishedAt = &t
+		} else if t, err := time.Parse(time.RFC1123Z, strings.TrimSpace(it.PubDate)); err == nil {
+			a.PublishedAt = &t
+		}
+		articles = append(articles, a)
+	}
+	return articles, nil
+}
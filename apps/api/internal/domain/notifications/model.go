@@ -0,0 +1,146 @@
+package notifications
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Notification types, in the order they win when one comment concerns a
+// user in several ways: a reply to someone who is also mentioned is a reply.
+const (
+	TypeCommentReply   = "comment_reply"
+	TypeCommentMention = "comment_mention"
+	TypePostComment    = "post_comment"
+)
+
+// Types lists all notification types in priority order.
+var Types = []string{TypeCommentReply, TypeCommentMention, TypePostComment}
+
+// ValidType reports whether t is a notification type.
+func ValidType(t string) bool {
+	for _, v := range Types {
+		if v == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Notification tells a user about a comment.
+type Notification struct {
+	ID        string     `json:"id"`
+	UserID    string     `json:"-"`
+	Type      string     `json:"type"`
+	ActorID   *string    `json:"actor_id,omitempty"`
+	PostID    string     `json:"post_id"`
+	CommentID string     `json:"comment_id"`
+	ReadAt    *time.Time `json:"read_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+
+	// Joined fields
+	ActorName   string `json:"actor_name,omitempty"`
+	ActorAvatar string `json:"actor_avatar,omitempty"`
+	PostTitle   string `json:"post_title,omitempty"`
+	PostSlug    string `json:"post_slug,omitempty"`
+	Excerpt     string `json:"excerpt,omitempty"` // start of the comment
+}
+
+// Filter selects a user's notifications.
+type Filter struct {
+	UserID     string
+	UnreadOnly bool
+	Page       int
+	Limit      int
+}
+
+// List is one page of notifications.
+type List struct {
+	Items      []Notification `json:"items"`
+	Total      int            `json:"total"`
+	Unread     int            `json:"unread"`
+	Page       int            `json:"page"`
+	Limit      int            `json:"limit"`
+	TotalPages int            `json:"total_pages"`
+}
+
+// Preferences maps each type to whether the user gets it.
+type Preferences map[string]bool
+
+// DefaultPreferences enables every type.
+func DefaultPreferences() Preferences {
+	p := make(Preferences, len(Types))
+	for _, t := range Types {
+		p[t] = true
+	}
+	return p
+}
+
+// MaxMentions caps the users one comment can notify by mention.
+const MaxMentions = 10
+
+// mentionRe matches @username not preceded by a word character, '@' or
+// '.', so email addresses are not mentions.
+var mentionRe = regexp.MustCompile(`(?:^|[^\w@.])@(\w{3,30})\b`)
+
+// ParseMentions returns the distinct lowercase usernames mentioned in
+// content, in order of appearance and at most MaxMentions.
+func ParseMentions(content string) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, m := range mentionRe.FindAllStringSubmatch(content, -1) {
+		name := strings.ToLower(m[1])
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		names = append(names, name)
+		if len(names) == MaxMentions {
+			break
+		}
+	}
+	return names
+}
+
+// Recipient is a user a comment concerns, with the types that apply in
+// priority order.
+type Recipient struct {
+	UserID string
+	Types  []string
+}
+
+// Pick returns the first of r's types that prefs enable, or "".
+func (r Recipient) Pick(prefs Preferences) string {
+	for _, t := range r.Types {
+		if enabled, ok := prefs[t]; !ok || enabled {
+			return t
+		}
+	}
+	return ""
+}
+
+// Recipients collects who a comment by actorID concerns: the author of
+// the comment replied to, mentioned users and the post author. Empty IDs
+// and the actor are skipped.
+func Recipients(actorID, parentAuthorID, postAuthorID string, mentionedIDs []string) []Recipient {
+	var out []Recipient
+	index := make(map[string]int)
+	add := func(userID, typ string) {
+		if userID == "" || userID == actorID {
+			return
+		}
+		if i, ok := index[userID]; ok {
+			out[i].Types = append(out[i].Types, typ)
+			return
+		}
+		index[userID] = len(out)
+		out = append(out, Recipient{UserID: userID, Types: []string{typ}})
+	}
+
+	add(parentAuthorID, TypeCommentReply)
+	for _, id := range mentionedIDs {
+		add(id, TypeCommentMention)
+	}
+	add(postAuthorID, TypePostComment)
+	return out
+}
@@ -0,0 +1,42 @@
+package notifications
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		content string
+		want    []string
+	}{
+		{"no mentions", nil},
+		{"@alice thanks", []string{"alice"}},
+		{"thanks @Alice and @bob_99!", []string{"alice", "bob_99"}},
+		{"@alice @ALICE @alice", []string{"alice"}},
+		{"(@alice) @bob, @carol.", []string{"alice", "bob", "carol"}},
+		{"mail me at dave@example.com", nil},
+		{"foo.@alice a@@bob", nil},
+		{"@ab too short", nil},
+		{"@" + strings.Repeat("a", 31) + " too long", nil},
+		{"@" + strings.Repeat("a", 30), []string{strings.Repeat("a", 30)}},
+	}
+	for _, tt := range tests {
+		if got := ParseMentions(tt.content); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseMentions(%q) = %v, want %v", tt.content, got, tt.want)
+		}
+	}
+}
+
+func TestParseMentionsLimit(t *testing.T) {
+	var b strings.Builder
+	for i := 0; i < MaxMentions+5; i++ {
+		fmt.Fprintf(&b, "@user%d ", i)
+	}
+	got := ParseMentions(b.String())
+	if len(got) != MaxMentions || got[0] != "user0" || got[MaxMentions-1] != fmt.Sprintf("user%d", MaxMentions-1) {
+		t.Errorf("ParseMentions kept %v, want the first %d", got, MaxMentions)
+	}
+}
@@ -0,0 +1,77 @@
+package notifications
+
+import (
+	"context"
+
+	"github.com/rapidtest/netpulse-api/internal/domain/comments"
+)
+
+// Store resolves who a comment concerns and saves notifications.
+type Store interface {
+	// Authors returns the user IDs of the post's author and of the
+	// parent comment's author; "" when unknown, a guest or deleted.
+	Authors(ctx context.Context, postID string, parentID *string) (postAuthorID, parentAuthorID string, err error)
+	// ResolveUsernames returns the IDs of active users with the given
+	// lowercase usernames.
+	ResolveUsernames(ctx context.Context, usernames []string) ([]string, error)
+	// PreferencesFor returns the stored preferences of each user.
+	PreferencesFor(ctx context.Context, userIDs []string) (map[string]Preferences, error)
+	// Insert saves notifications, skipping users already notified of the
+	// same comment.
+	Insert(ctx context.Context, items []Notification) error
+}
+
+// NotifyComment notifies everyone a visible comment concerns, by the
+// first type each recipient has not opted out of. It is safe to call
+// again, e.g. after approval or an edit adding mentions.
+func NotifyComment(ctx context.Context, s Store, c *comments.Comment) error {
+	if c.Status != comments.StatusApproved || c.DeletedAt != nil {
+		return nil
+	}
+
+	postAuthor, parentAuthor, err := s.Authors(ctx, c.PostID, c.ParentID)
+	if err != nil {
+		return err
+	}
+	var mentioned []string
+	if names := ParseMentions(c.Content); len(names) > 0 {
+		if mentioned, err = s.ResolveUsernames(ctx, names); err != nil {
+			return err
+		}
+	}
+
+	actorID := ""
+	if c.UserID != nil {
+		actorID = *c.UserID
+	}
+	recipients := Recipients(actorID, parentAuthor, postAuthor, mentioned)
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	ids := make([]string, len(recipients))
+	for i, r := range recipients {
+		ids[i] = r.UserID
+	}
+	prefs, err := s.PreferencesFor(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	var items []Notification
+	for _, r := range recipients {
+		if t := r.Pick(prefs[r.UserID]); t != "" {
+			items = append(items, Notification{
+				UserID:    r.UserID,
+				Type:      t,
+				ActorID:   c.UserID,
+				PostID:    c.PostID,
+				CommentID: c.ID,
+			})
+		}
+	}
+	if len(items) == 0 {
+		return nil
+	}
+	return s.Insert(ctx, items)
+}
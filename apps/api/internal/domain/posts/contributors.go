@@ -0,0 +1,60 @@
+package posts
+
+// ContributorRole is a credit shown in a post's byline.
+type ContributorRole string
+
+const (
+	RoleAuthor            ContributorRole = "author"
+	RoleEditor            ContributorRole = "editor"
+	RoleIllustrator       ContributorRole = "illustrator"
+	RoleTechnicalReviewer ContributorRole = "technical_reviewer"
+)
+
+// MaxContributors caps the length of a byline.
+const MaxContributors = 10
+
+// Valid reports whether r is a known contributor role.
+func (r ContributorRole) Valid() bool {
+	switch r {
+	case RoleAuthor, RoleEditor, RoleIllustrator, RoleTechnicalReviewer:
+		return true
+	}
+	return false
+}
+
+// Contributor is a credited user of a post, in byline order. The post's
+// AuthorID is its owner and is always one of the contributors.
+type Contributor struct {
+	UserID   string          `json:"user_id"`
+	Name     string          `json:"name"`
+	Avatar   string          `json:"avatar,omitempty"`
+	Role     ContributorRole `json:"role"`
+	Position int             `json:"position"`
+}
+
+// ContributorInput is one entry of SetContributorsInput.
+type ContributorInput struct {
+	UserID string          `json:"user_id"`
+	Role   ContributorRole `json:"role"` // defaults to "author"
+}
+
+// SetContributorsInput replaces a post's byline, in display order.
+type SetContributorsInput struct {
+	Authors []ContributorInput `json:"authors"`
+}
+
+// HasContributor reports whether userID owns or is credited on the post.
+func (p *Post) HasContributor(userID string) bool {
+	if userID == "" {
+		return false
+	}
+	if p.AuthorID == userID {
+		return true
+	}
+	for _, c := range p.Authors {
+		if c.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
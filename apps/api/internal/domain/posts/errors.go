@@ -0,0 +1,60 @@
+package posts
+
+import "errors"
+
+// ErrSlugConflict is returned when a slug is already used by another post,
+// either as its current slug or as one of its former slugs.
+var ErrSlugConflict = errors.New("slug already in use by another post")
+
+// RedirectError is returned by GetBySlug when the requested slug is a
+// former slug of a published post. Slug holds the post's current slug.
+type RedirectError struct {
+	Slug string
+}
+
+func (e *RedirectError) Error() string {
+	return "post moved to " + e.Slug
+}
+
+// ErrRevisionNotFound is returned when a post has no revision with the
+// given ID.
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// ErrTagNotFound is returned by a tag merge when a source tag does not
+// exist.
+var ErrTagNotFound = errors.New("tag not found")
+
+// ErrPreviewUnavailable is returned for preview tokens that are unknown,
+// revoked or expired.
+var ErrPreviewUnavailable = errors.New("preview link is invalid or has expired")
+
+// ErrUnresolvedNotes is returned by SubmitReview while review notes are open.
+var ErrUnresolvedNotes = errors.New("resolve all review notes before submitting for review")
+
+// ErrVersionConflict is returned by the repository when a post update loses
+// a race with another writer.
+var ErrVersionConflict = errors.New("post was modified by someone else")
+
+// VersionConflictError is returned by Update when the caller's version is
+// stale. Current is the post as it is now stored.
+type VersionConflictError struct {
+	Current *Post
+}
+
+func (e *VersionConflictError) Error() string {
+	return ErrVersionConflict.Error()
+}
+
+func (e *VersionConflictError) Unwrap() error {
+	return ErrVersionConflict
+}
+
+// ErrUnknownContributor is returned when a byline names a user that does not exist.
+var ErrUnknownContributor = errors.New("contributor not found")
+
+// ErrGone is returned by GetBySlug for archived posts, so they can be
+// reported as permanently removed rather than missing.
+var ErrGone = errors.New("post is no longer available")
+
+// ErrUnsupportedLanguage is returned for a post language other than "id" or "en".
+var ErrUnsupportedLanguage = errors.New("language must be id or en")
@@ -0,0 +1,49 @@
+package posts
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Highlight holds search snippets with matches wrapped in <mark> tags. All
+// other text is HTML-escaped, so the snippets are safe to render as HTML.
+type Highlight struct {
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+}
+
+// Match delimiters ts_headline is asked to emit. Control characters cannot
+// occur in the HTML-escaped output, so they are replaced after escaping.
+const (
+	HighlightStart = "\x02"
+	HighlightStop  = "\x03"
+)
+
+// HeadlineOptions are the ts_headline options for body snippets.
+const HeadlineOptions = `StartSel="` + HighlightStart + `", StopSel="` + HighlightStop + `", ` +
+	`MaxFragments=2, MaxWords=25, MinWords=12, FragmentDelimiter=" … "`
+
+// TitleHeadlineOptions highlight the whole title.
+const TitleHeadlineOptions = `StartSel="` + HighlightStart + `", StopSel="` + HighlightStop + `", HighlightAll=true`
+
+var (
+	mdImage    = regexp.MustCompile(`!\[[^\]]*\]\([^)]*\)`)
+	mdLink     = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	mdLineMark = regexp.MustCompile(`(?m)^\s{0,3}(?:#{1,6}|>|[-*+]|\d+\.)\s+`)
+	mdInline   = strings.NewReplacer("**", "", "__", "", "`", "", "~~", "")
+)
+
+// FormatHighlight turns a ts_headline result into a plain-text snippet:
+// Markdown syntax is dropped, the text is escaped and matches are wrapped
+// in <mark>.
+func FormatHighlight(s string) string {
+	s = mdImage.ReplaceAllString(s, "")
+	s = mdLink.ReplaceAllString(s, "$1")
+	s = mdLineMark.ReplaceAllString(s, "")
+	s = mdInline.Replace(s)
+	s = strings.Join(strings.Fields(s), " ")
+	s = html.EscapeString(s)
+	s = strings.ReplaceAll(s, HighlightStart, "<mark>")
+	return strings.ReplaceAll(s, HighlightStop, "</mark>")
+}
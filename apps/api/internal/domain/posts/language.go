@@ -0,0 +1,76 @@
+package posts
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Language selects the text search configuration a post is indexed with.
+type Language string
+
+const (
+	LanguageIndonesian Language = "id"
+	LanguageEnglish    Language = "en"
+)
+
+// DefaultLanguage is used when a post or query gives no usable hint.
+const DefaultLanguage = LanguageIndonesian
+
+// SearchConfig is the Postgres text search configuration for the language.
+// It must agree with netpulse_ts_config() in the migrations.
+func (l Language) SearchConfig() string {
+	if l == LanguageEnglish {
+		return "english"
+	}
+	return "netpulse_id"
+}
+
+// ParseLanguage accepts "id"/"en" and common variants such as "id-ID" or
+// "english". The empty string yields ok == false.
+func ParseLanguage(s string) (Language, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if i := strings.IndexAny(s, "-_"); i > 0 {
+		s = s[:i]
+	}
+	switch s {
+	case "id", "in", "ind", "indonesian", "indonesia", "bahasa":
+		return LanguageIndonesian, true
+	case "en", "eng", "english":
+		return LanguageEnglish, true
+	}
+	return "", false
+}
+
+// Function words that are frequent in one language and rare in the other.
+var (
+	indonesianMarkers = wordSet("yang dan di ke dari untuk dengan ini itu tidak ada akan pada juga dalam adalah atau karena bisa sudah saat oleh agar kami kita mereka saya anda cara apa bagaimana lebih seperti telah harus baru jika")
+	englishMarkers    = wordSet("the and of to in is for with this that not are will on also from be or because can has have was were by how what which more like new if you your we they it")
+)
+
+func wordSet(s string) map[string]bool {
+	m := make(map[string]bool)
+	for _, w := range strings.Fields(s) {
+		m[w] = true
+	}
+	return m
+}
+
+// DetectLanguage guesses the language of text by counting function words.
+// Short or ambiguous text yields DefaultLanguage.
+func DetectLanguage(text string) Language {
+	var id, en int
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	}) {
+		if indonesianMarkers[w] {
+			id++
+		}
+		if englishMarkers[w] {
+			en++
+		}
+	}
+	if en > id {
+		return LanguageEnglish
+	}
+	return DefaultLanguage
+}
@@ -2,6 +2,8 @@ package posts
 
 import (
 	"time"
+
+	"github.com/rapidtest/netpulse-api/internal/markdown"
 )
 
 // PostStatus represents the editorial workflow state.
@@ -29,22 +31,57 @@ type Post struct {
 	CategoryID    *string    `json:"category_id,omitempty"`
 	PublishedAt   *time.Time `json:"published_at,omitempty"`
 	ScheduledAt   *time.Time `json:"scheduled_at,omitempty"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"` // archived automatically once passed
 	MetaTitle     string     `json:"meta_title,omitempty"`
 	MetaDesc      string     `json:"meta_description,omitempty"`
 	Canonical     string     `json:"canonical,omitempty"`
+	Language      Language   `json:"language,omitempty"` // drives the full-text search configuration
 	CreatedAt     time.Time  `json:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at"`
+	Version       int        `json:"version"`
 	Tags          []Tag      `json:"tags,omitempty"`
 	Category      *Category  `json:"category,omitempty"`
 	Author        *Author    `json:"author,omitempty"`
+	Authors       []Contributor `json:"authors,omitempty"`
+	Series        []SeriesNav `json:"series,omitempty"`
+
+	// Rendered output of Body, filled on public reads.
+	BodyHTML    string              `json:"body_html,omitempty"`
+	TOC         []markdown.TOCEntry `json:"toc,omitempty"`
+	ReadingTime int                 `json:"reading_time,omitempty"`
+
+	// Search snippets, filled on search results.
+	Highlight *Highlight `json:"highlight,omitempty"`
 }
 
-// Category organizes posts.
+// SeriesNav places a post within a series for previous/next navigation.
+type SeriesNav struct {
+	ID       string       `json:"id"`
+	Title    string       `json:"title"`
+	Slug     string       `json:"slug"`
+	Position int          `json:"position"`
+	Total    int          `json:"total"`
+	Previous *SeriesEntry `json:"previous,omitempty"`
+	Next     *SeriesEntry `json:"next,omitempty"`
+}
+
+// SeriesEntry is a minimal reference to a neighbouring post in a series.
+type SeriesEntry struct {
+	Title string `json:"title"`
+	Slug  string `json:"slug"`
+}
+
+// Category organizes posts. Categories nest through ParentID.
 type Category struct {
-	ID          string `json:"id"`
-	Name        string `json:"name"`
-	Slug        string `json:"slug"`
-	Description string `json:"description,omitempty"`
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	Slug        string  `json:"slug"`
+	Description string  `json:"description,omitempty"`
+	ParentID    *string `json:"parent_id,omitempty"`
+
+	// Listing fields
+	PostCount int        `json:"post_count"`
+	Children  []Category `json:"children,omitempty"`
 }
 
 // Tag is a label for posts.
@@ -52,6 +89,9 @@ type Tag struct {
 	ID   string `json:"id"`
 	Name string `json:"name"`
 	Slug string `json:"slug"`
+
+	// Listing fields
+	PostCount int `json:"post_count"`
 }
 
 // Author is a minimal user representation for public display.
@@ -64,6 +104,7 @@ type Author struct {
 // CreatePostInput is the DTO for creating a post.
 type CreatePostInput struct {
 	Title      string   `json:"title" validate:"required,max=200"`
+	Slug       string   `json:"slug,omitempty"` // optional; defaults to the slugified title
 	Body       string   `json:"body" validate:"required"`
 	Excerpt    string   `json:"excerpt" validate:"max=500"`
 	CoverURL   string   `json:"cover_url"`
@@ -71,11 +112,13 @@ type CreatePostInput struct {
 	TagIDs     []string `json:"tag_ids"`
 	MetaTitle  string   `json:"meta_title" validate:"max=70"`
 	MetaDesc   string   `json:"meta_description" validate:"max=160"`
+	Language   string   `json:"language,omitempty"` // "id" or "en"; detected from the content when empty
 }
 
 // UpdatePostInput is the DTO for updating a post.
 type UpdatePostInput struct {
 	Title      *string  `json:"title,omitempty"`
+	Slug       *string  `json:"slug,omitempty"` // explicit slug; otherwise derived from a new title
 	Body       *string  `json:"body,omitempty"`
 	Excerpt    *string  `json:"excerpt,omitempty"`
 	CoverURL   *string  `json:"cover_url,omitempty"`
@@ -83,6 +126,11 @@ type UpdatePostInput struct {
 	TagIDs     []string `json:"tag_ids,omitempty"`
 	MetaTitle  *string  `json:"meta_title,omitempty"`
 	MetaDesc   *string  `json:"meta_description,omitempty"`
+	Language   *string  `json:"language,omitempty"`
+
+	// Version is the post version the edit is based on. When set, the update
+	// fails with a VersionConflictError if the post has changed since.
+	Version *int `json:"version,omitempty"`
 }
 
 // PostListFilter holds query parameters for listing posts.
@@ -104,6 +152,12 @@ type PostListResult struct {
 	Limit      int    `json:"limit"`
 	Total      int    `json:"total"`
 	TotalPages int    `json:"total_pages"`
+
+	// Language is the query language of a search result; Fuzzy reports
+	// that trigram matches were added to too few full-text hits.
+	Language Language      `json:"language,omitempty"`
+	Fuzzy    bool          `json:"fuzzy,omitempty"`
+	Facets   *SearchFacets `json:"facets,omitempty"`
 }
 
 // SearchResult is a trimmed post result for search.
@@ -4,12 +4,12 @@ package posts
 // These are checked in handlers before calling service methods.
 
 // CanEdit checks if a user can edit a post.
-func CanEdit(userID, authorID, role string) bool {
+func CanEdit(userID string, post *Post, role string) bool {
 	if role == "OWNER" || role == "ADMIN" || role == "EDITOR" {
 		return true
 	}
-	// Authors can only edit their own posts
-	return userID == authorID
+	// Authors can edit posts they own or are credited on
+	return post.HasContributor(userID)
 }
 
 // CanPublish checks if a user can publish a post.
@@ -17,7 +17,8 @@ func CanPublish(role string) bool {
 	return role == "OWNER" || role == "ADMIN" || role == "EDITOR"
 }
 
-// CanDelete checks if a user can delete a post.
+// CanDelete checks if a user can delete a post. Co-authors cannot; only the
+// post's owner can.
 func CanDelete(userID, authorID, role string) bool {
 	if role == "OWNER" || role == "ADMIN" {
 		return true
@@ -26,9 +27,9 @@ func CanDelete(userID, authorID, role string) bool {
 }
 
 // CanSubmitReview checks if a user can submit a post for review.
-func CanSubmitReview(userID, authorID, role string) bool {
+func CanSubmitReview(userID string, post *Post, role string) bool {
 	if role == "OWNER" || role == "ADMIN" || role == "EDITOR" {
 		return true
 	}
-	return userID == authorID
+	return post.HasContributor(userID)
 }
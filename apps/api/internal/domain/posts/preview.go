@@ -0,0 +1,32 @@
+package posts
+
+import "time"
+
+// PreviewToken grants read access to a single post regardless of its status.
+type PreviewToken struct {
+	ID           string     `json:"id"`
+	PostID       string     `json:"post_id"`
+	CreatedBy    string     `json:"created_by"`
+	CreatorName  string     `json:"creator_name,omitempty"`
+	Note         string     `json:"note,omitempty"`
+	ExpiresAt    time.Time  `json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at,omitempty"`
+	ViewCount    int        `json:"view_count"`
+	LastViewedAt *time.Time `json:"last_viewed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+
+	// Token is the signed value shared with the reviewer. It is only
+	// returned when the preview link is created.
+	Token string `json:"token,omitempty"`
+}
+
+// CreatePreviewInput is the body of a create-preview-link request.
+type CreatePreviewInput struct {
+	ExpiresInHours int    `json:"expires_in_hours"`
+	Note           string `json:"note"`
+}
+
+const (
+	DefaultPreviewTTL = 7 * 24 * time.Hour
+	MaxPreviewTTL     = 30 * 24 * time.Hour
+)
@@ -0,0 +1,11 @@
+package posts
+
+import "github.com/rapidtest/netpulse-api/internal/markdown"
+
+// Render fills the post's BodyHTML, TOC and ReadingTime from its Markdown body.
+func Render(post *Post) {
+	out := markdown.Render(post.Body)
+	post.BodyHTML = out.HTML
+	post.TOC = out.TOC
+	post.ReadingTime = out.ReadingTime
+}
@@ -0,0 +1,56 @@
+package posts
+
+import "time"
+
+// NoteKind distinguishes review thread entries.
+type NoteKind string
+
+const (
+	NoteSummary NoteKind = "SUMMARY" // overall feedback of a change request
+	NoteInline  NoteKind = "INLINE"  // feedback anchored to a body range
+	NoteReply   NoteKind = "REPLY"   // answer within a note's thread
+)
+
+// NoteAnchor locates an inline note in the post body. Start and End are
+// character (rune) offsets; Quote is the text they covered when the note
+// was written, so clients can re-anchor after edits.
+type NoteAnchor struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Quote string `json:"quote"`
+}
+
+// ReviewNote is one entry of a post's review thread. Top-level notes
+// (summary and inline) can be resolved; replies hang off them.
+type ReviewNote struct {
+	ID         int64        `json:"id"`
+	PostID     string       `json:"post_id"`
+	ParentID   *int64       `json:"parent_id,omitempty"`
+	AuthorID   string       `json:"author_id"`
+	AuthorName string       `json:"author_name,omitempty"`
+	Kind       NoteKind     `json:"kind"`
+	Body       string       `json:"body"`
+	Anchor     *NoteAnchor  `json:"anchor,omitempty"`
+	ResolvedAt *time.Time   `json:"resolved_at,omitempty"`
+	ResolvedBy *string      `json:"resolved_by,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+	Replies    []ReviewNote `json:"replies,omitempty"`
+}
+
+// InlineNoteInput is an inline note within a change request.
+type InlineNoteInput struct {
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+	Body  string `json:"body"`
+}
+
+// RequestChangesInput sends a post in review back to its author.
+type RequestChangesInput struct {
+	Summary string            `json:"summary"`
+	Notes   []InlineNoteInput `json:"notes"`
+}
+
+// ReplyNoteInput is the body of a reply to a review note.
+type ReplyNoteInput struct {
+	Body string `json:"body"`
+}
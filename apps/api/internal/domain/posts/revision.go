@@ -0,0 +1,146 @@
+package posts
+
+import (
+	"strings"
+	"time"
+)
+
+// Revision is a snapshot of a post's editable content at a point in time.
+type Revision struct {
+	ID         int64     `json:"id"`
+	PostID     string    `json:"post_id"`
+	Title      string    `json:"title"`
+	Body       string    `json:"body,omitempty"`
+	Excerpt    string    `json:"excerpt"`
+	EditorID   string    `json:"editor_id"`
+	EditorName string    `json:"editor_name,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// DiffOp is the kind of change for a single diff line.
+type DiffOp string
+
+const (
+	DiffEqual  DiffOp = "equal"
+	DiffInsert DiffOp = "insert"
+	DiffDelete DiffOp = "delete"
+)
+
+// DiffLine is one line of a line-based diff.
+type DiffLine struct {
+	Op   DiffOp `json:"op"`
+	Text string `json:"text"`
+}
+
+// RevisionDiff compares two revisions field by field.
+type RevisionDiff struct {
+	From    int64      `json:"from"`
+	To      int64      `json:"to"`
+	Title   []DiffLine `json:"title"`
+	Excerpt []DiffLine `json:"excerpt"`
+	Body    []DiffLine `json:"body"`
+}
+
+// maxDiffCells caps the LCS table size so huge bodies don't exhaust memory.
+const maxDiffCells = 4_000_000
+
+// DiffRevisions builds a line-based diff between two revisions.
+func DiffRevisions(from, to *Revision) *RevisionDiff {
+	return &RevisionDiff{
+		From:    from.ID,
+		To:      to.ID,
+		Title:   DiffText(from.Title, to.Title),
+		Excerpt: DiffText(from.Excerpt, to.Excerpt),
+		Body:    DiffText(from.Body, to.Body),
+	}
+}
+
+// DiffText returns a line-based diff of a and b using longest common subsequence.
+func DiffText(a, b string) []DiffLine {
+	al := splitLines(a)
+	bl := splitLines(b)
+
+	// Trim common prefix and suffix to keep the LCS table small.
+	prefix := 0
+	for prefix < len(al) && prefix < len(bl) && al[prefix] == bl[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(al)-prefix && suffix < len(bl)-prefix &&
+		al[len(al)-1-suffix] == bl[len(bl)-1-suffix] {
+		suffix++
+	}
+
+	out := make([]DiffLine, 0, len(al)+len(bl))
+	for _, l := range al[:prefix] {
+		out = append(out, DiffLine{Op: DiffEqual, Text: l})
+	}
+	out = append(out, diffMiddle(al[prefix:len(al)-suffix], bl[prefix:len(bl)-suffix])...)
+	for _, l := range al[len(al)-suffix:] {
+		out = append(out, DiffLine{Op: DiffEqual, Text: l})
+	}
+	return out
+}
+
+func diffMiddle(a, b []string) []DiffLine {
+	n, m := len(a), len(b)
+	var out []DiffLine
+
+	// Fall back to delete-all/insert-all when the table would be too large.
+	if n*m > maxDiffCells {
+		for _, l := range a {
+			out = append(out, DiffLine{Op: DiffDelete, Text: l})
+		}
+		for _, l := range b {
+			out = append(out, DiffLine{Op: DiffInsert, Text: l})
+		}
+		return out
+	}
+
+	// lcs[i][j] = length of LCS of a[i:] and b[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n && j < m {
+		switch {
+		case a[i] == b[j]:
+			out = append(out, DiffLine{Op: DiffEqual, Text: a[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			out = append(out, DiffLine{Op: DiffDelete, Text: a[i]})
+			i++
+		default:
+			out = append(out, DiffLine{Op: DiffInsert, Text: b[j]})
+			j++
+		}
+	}
+	for ; i < n; i++ {
+		out = append(out, DiffLine{Op: DiffDelete, Text: a[i]})
+	}
+	for ; j < m; j++ {
+		out = append(out, DiffLine{Op: DiffInsert, Text: b[j]})
+	}
+	return out
+}
+
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+}
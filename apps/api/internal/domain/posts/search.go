@@ -0,0 +1,52 @@
+package posts
+
+import "time"
+
+// Facet dimensions of a search. The counts of a dimension leave out its own
+// filter (except tags, which narrow down), so a reader still sees the
+// alternatives to the value they selected.
+const (
+	FacetCategory = "category"
+	FacetTag      = "tag"
+	FacetAuthor   = "author"
+	FacetYear     = "year"
+)
+
+// MaxFacetValues caps the values returned per facet.
+const MaxFacetValues = 20
+
+// SearchFilter holds the query and filters of a full-text search.
+type SearchFilter struct {
+	Query    string
+	Language Language   // query language; posts in it rank first
+	Category string     // category slug; includes its subcategories
+	Tags     []string   // tag slugs; posts must have all of them
+	AuthorID string     // includes co-authored posts
+	From     *time.Time // published_at range
+	To       *time.Time
+	Page     int
+	Limit    int
+	Sort     string // "relevance" or "newest"
+}
+
+// FacetValue is one filter option with the number of matching results.
+type FacetValue struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int    `json:"count"`
+}
+
+// SearchFacets are the result counts per filter dimension. Type counts
+// posts and store listings matching the query.
+type SearchFacets struct {
+	Type     []FacetValue `json:"type"`
+	Category []FacetValue `json:"category"`
+	Tag      []FacetValue `json:"tag"`
+	Author   []FacetValue `json:"author"`
+	Year     []FacetValue `json:"year"`
+}
+
+// HasFilters reports whether any post-only filter is set.
+func (f SearchFilter) HasFilters() bool {
+	return f.Category != "" || len(f.Tags) > 0 || f.AuthorID != "" || f.From != nil || f.To != nil
+}
@@ -2,8 +2,10 @@ package posts
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/rapidtest/netpulse-api/internal/utils"
@@ -14,12 +16,38 @@ type Repository interface {
 	FindAll(ctx context.Context, filter PostListFilter) (*PostListResult, error)
 	FindBySlug(ctx context.Context, slug string) (*Post, error)
 	FindByID(ctx context.Context, id string) (*Post, error)
-	Create(ctx context.Context, post *Post) error
+	Create(ctx context.Context, post *Post, rev *Revision) error
 	Update(ctx context.Context, post *Post) error
+	UpdateWithRevision(ctx context.Context, post *Post, revs ...*Revision) error
 	Delete(ctx context.Context, id string) error
 	UpdateStatus(ctx context.Context, id string, status PostStatus) error
-	Search(ctx context.Context, query string, page, limit int, sort string) (*PostListResult, error)
-	Suggest(ctx context.Context, query string, limit int) ([]SuggestResult, error)
+	PublishDue(ctx context.Context, limit int) ([]Post, error)
+	ArchiveExpired(ctx context.Context, limit int) ([]Post, error)
+	SlugArchived(ctx context.Context, slug string) (bool, error)
+	FindSeriesNav(ctx context.Context, postID string) ([]SeriesNav, error)
+	SlugTaken(ctx context.Context, slug, excludePostID string) (bool, error)
+	RecordSlugChange(ctx context.Context, postID, oldSlug, newSlug string) error
+	FindSlugRedirect(ctx context.Context, oldSlug string) (string, error)
+	Search(ctx context.Context, f SearchFilter) (*PostListResult, error)
+	Suggest(ctx context.Context, query string, lang Language, limit int) ([]SuggestResult, error)
+	FindRevisions(ctx context.Context, postID string) ([]Revision, error)
+	FindRevision(ctx context.Context, postID string, revisionID int64) (*Revision, error)
+	CreatePreviewToken(ctx context.Context, p *PreviewToken) error
+	FindPreviewTokens(ctx context.Context, postID string) ([]PreviewToken, error)
+	RevokePreviewToken(ctx context.Context, postID, id string) error
+	RecordPreviewView(ctx context.Context, id string) (string, error)
+	RequestChanges(ctx context.Context, postID string, notes []ReviewNote) error
+	FindReviewNotes(ctx context.Context, postID string) ([]ReviewNote, error)
+	FindReviewNote(ctx context.Context, postID string, id int64) (*ReviewNote, error)
+	CreateReviewNote(ctx context.Context, note *ReviewNote) error
+	SetReviewNoteResolved(ctx context.Context, postID string, id int64, userID string, resolved bool) error
+	CountOpenReviewNotes(ctx context.Context, postID string) (int, error)
+	CountRevisions(ctx context.Context, postID string) (int, error)
+	SetTags(ctx context.Context, postID string, tagIDs []string) error
+	MarkPublished(ctx context.Context, id string, at time.Time) error
+	SetContributors(ctx context.Context, postID string, list []Contributor) error
+	FindForReindex(ctx context.Context, afterID string, limit int) ([]Post, error)
+	Reindex(ctx context.Context, id string, lang Language) error
 }
 
 // CacheRepository defines a cache interface.
@@ -30,6 +58,10 @@ type CacheRepository interface {
 	DeleteByPrefix(ctx context.Context, prefix string) error
 }
 
+// publishBatchSize caps how many due posts a single PublishDue or
+// ArchiveExpired query claims.
+const publishBatchSize = 100
+
 // Service contains business logic for posts.
 type Service struct {
 	repo  Repository
@@ -58,9 +90,26 @@ func (s *Service) List(ctx context.Context, filter PostListFilter) (*PostListRes
 	return result, nil
 }
 
-// GetBySlug returns a single published post by slug.
+// GetBySlug returns a single published post by slug, including
+// previous/next navigation for any series it belongs to. Archived posts
+// yield ErrGone.
 func (s *Service) GetBySlug(ctx context.Context, slug string) (*Post, error) {
-	return s.repo.FindBySlug(ctx, slug)
+	post, err := s.repo.FindBySlug(ctx, slug)
+	if err != nil {
+		if archived, aerr := s.repo.SlugArchived(ctx, slug); aerr == nil && archived {
+			return nil, ErrGone
+		}
+		// A renamed post keeps its old slugs; point the caller at the new one.
+		if current, rerr := s.repo.FindSlugRedirect(ctx, slug); rerr == nil && current != "" {
+			return nil, &RedirectError{Slug: current}
+		}
+		return nil, err
+	}
+	if nav, err := s.repo.FindSeriesNav(ctx, post.ID); err == nil {
+		post.Series = nav
+	}
+	Render(post)
+	return post, nil
 }
 
 // GetByID returns a post by ID (admin use).
@@ -70,10 +119,25 @@ func (s *Service) GetByID(ctx context.Context, id string) (*Post, error) {
 
 // Create creates a new draft post.
 func (s *Service) Create(ctx context.Context, input CreatePostInput, authorID string) (*Post, error) {
+	slug := utils.Slugify(input.Slug)
+	if slug == "" {
+		slug = utils.Slugify(input.Title)
+	}
+	if err := s.checkSlug(ctx, slug, ""); err != nil {
+		return nil, err
+	}
+	lang, ok := ParseLanguage(input.Language)
+	if !ok {
+		if input.Language != "" {
+			return nil, ErrUnsupportedLanguage
+		}
+		lang = DetectLanguage(input.Title + "\n" + input.Excerpt + "\n" + input.Body)
+	}
+
 	post := &Post{
 		ID:         utils.NewID(),
 		Title:      input.Title,
-		Slug:       utils.Slugify(input.Title),
+		Slug:       slug,
 		Excerpt:    input.Excerpt,
 		Body:       input.Body,
 		CoverURL:   input.CoverURL,
@@ -81,6 +145,7 @@ func (s *Service) Create(ctx context.Context, input CreatePostInput, authorID st
 		AuthorID:   authorID,
 		MetaTitle:  input.MetaTitle,
 		MetaDesc:   input.MetaDesc,
+		Language:   lang,
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
 	}
@@ -88,24 +153,55 @@ func (s *Service) Create(ctx context.Context, input CreatePostInput, authorID st
 		post.CategoryID = &input.CategoryID
 	}
 
-	if err := s.repo.Create(ctx, post); err != nil {
+	// The first revision is written with the post, so every post has one.
+	if err := s.repo.Create(ctx, post, newRevision(post, authorID)); err != nil {
 		return nil, err
 	}
+	if len(input.TagIDs) > 0 {
+		if err := s.repo.SetTags(ctx, post.ID, input.TagIDs); err != nil {
+			return nil, fmt.Errorf("failed to set tags: %w", err)
+		}
+	}
 
 	return post, nil
 }
 
-// Update modifies an existing post.
-func (s *Service) Update(ctx context.Context, id string, input UpdatePostInput) (*Post, error) {
+// Update modifies an existing post and, when its content changed, records
+// a revision for editorID.
+func (s *Service) Update(ctx context.Context, id string, input UpdatePostInput, editorID string) (*Post, error) {
 	post, err := s.repo.FindByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if input.Version != nil && *input.Version != post.Version {
+		return nil, &VersionConflictError{Current: post}
+	}
+
+	// Posts created before revisions were tracked have no history yet;
+	// snapshot the current content first so it can still be restored.
+	var revs []*Revision
+	n, err := s.repo.CountRevisions(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		revs = append(revs, newRevision(post, post.AuthorID))
+	}
 
+	oldSlug := post.Slug
+	oldTitle, oldBody, oldExcerpt := post.Title, post.Body, post.Excerpt
 	if input.Title != nil {
 		post.Title = *input.Title
 		post.Slug = utils.Slugify(*input.Title)
 	}
+	if input.Slug != nil && utils.Slugify(*input.Slug) != "" {
+		post.Slug = utils.Slugify(*input.Slug)
+	}
+	if post.Slug != oldSlug {
+		if err := s.checkSlug(ctx, post.Slug, post.ID); err != nil {
+			return nil, err
+		}
+	}
 	if input.Body != nil {
 		post.Body = *input.Body
 	}
@@ -124,11 +220,44 @@ func (s *Service) Update(ctx context.Context, id string, input UpdatePostInput)
 	if input.MetaDesc != nil {
 		post.MetaDesc = *input.MetaDesc
 	}
+	if input.Language != nil {
+		lang, ok := ParseLanguage(*input.Language)
+		if !ok {
+			return nil, ErrUnsupportedLanguage
+		}
+		post.Language = lang
+	}
 	post.UpdatedAt = time.Now()
 
-	if err := s.repo.Update(ctx, post); err != nil {
+	// Revisions snapshot the title, body and excerpt; they are written in
+	// the same transaction as the post, and only when one of those changed.
+	if post.Title != oldTitle || post.Body != oldBody || post.Excerpt != oldExcerpt {
+		revs = append(revs, newRevision(post, editorID))
+	}
+	if len(revs) > 0 {
+		err = s.repo.UpdateWithRevision(ctx, post, revs...)
+	} else {
+		err = s.repo.Update(ctx, post)
+	}
+	if err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			if current, ferr := s.repo.FindByID(ctx, id); ferr == nil {
+				return nil, &VersionConflictError{Current: current}
+			}
+		}
 		return nil, err
 	}
+	if input.TagIDs != nil {
+		if err := s.repo.SetTags(ctx, post.ID, input.TagIDs); err != nil {
+			return nil, fmt.Errorf("failed to set tags: %w", err)
+		}
+	}
+
+	if post.Slug != oldSlug {
+		if err := s.repo.RecordSlugChange(ctx, post.ID, oldSlug, post.Slug); err != nil {
+			return nil, fmt.Errorf("failed to record slug history: %w", err)
+		}
+	}
 
 	// Invalidate cache
 	_ = s.cache.DeleteByPrefix(ctx, "posts:")
@@ -136,6 +265,165 @@ func (s *Service) Update(ctx context.Context, id string, input UpdatePostInput)
 	return post, nil
 }
 
+// ListRevisions returns the revision history of a post, newest first.
+func (s *Service) ListRevisions(ctx context.Context, postID string) ([]Revision, error) {
+	return s.repo.FindRevisions(ctx, postID)
+}
+
+// DiffRevisions compares two revisions of the same post.
+func (s *Service) DiffRevisions(ctx context.Context, postID string, fromID, toID int64) (*RevisionDiff, error) {
+	from, err := s.repo.FindRevision(ctx, postID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.repo.FindRevision(ctx, postID, toID)
+	if err != nil {
+		return nil, err
+	}
+	return DiffRevisions(from, to), nil
+}
+
+// RestoreRevision copies an older revision back onto the post.
+// The restore itself is recorded as a new revision unless the post
+// already matches it.
+func (s *Service) RestoreRevision(ctx context.Context, postID string, revisionID int64, editorID string) (*Post, error) {
+	rev, err := s.repo.FindRevision(ctx, postID, revisionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.Update(ctx, postID, UpdatePostInput{
+		Title:   &rev.Title,
+		Body:    &rev.Body,
+		Excerpt: &rev.Excerpt,
+	}, editorID)
+}
+
+// SetContributors replaces the byline of a post. The owner is always
+// credited: if the input leaves them out they are added first as an author.
+func (s *Service) SetContributors(ctx context.Context, postID string, input SetContributorsInput) (*Post, error) {
+	post, err := s.repo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	var list []Contributor
+	seen := make(map[string]bool)
+	for _, in := range input.Authors {
+		if in.UserID == "" {
+			return nil, fmt.Errorf("user_id is required")
+		}
+		if seen[in.UserID] {
+			return nil, fmt.Errorf("user %s is listed twice", in.UserID)
+		}
+		seen[in.UserID] = true
+		if in.Role == "" {
+			in.Role = RoleAuthor
+		}
+		if !in.Role.Valid() {
+			return nil, fmt.Errorf("invalid role %q", in.Role)
+		}
+		list = append(list, Contributor{UserID: in.UserID, Role: in.Role})
+	}
+	if !seen[post.AuthorID] {
+		list = append([]Contributor{{UserID: post.AuthorID, Role: RoleAuthor}}, list...)
+	}
+	if len(list) > MaxContributors {
+		return nil, fmt.Errorf("a post can credit at most %d contributors", MaxContributors)
+	}
+	for i := range list {
+		list[i].Position = i
+	}
+
+	if err := s.repo.SetContributors(ctx, postID, list); err != nil {
+		return nil, err
+	}
+	_ = s.cache.DeleteByPrefix(ctx, "posts:")
+	return s.repo.FindByID(ctx, postID)
+}
+
+// checkSlug rejects empty slugs and slugs owned by another post.
+func (s *Service) checkSlug(ctx context.Context, slug, postID string) error {
+	if slug == "" {
+		return fmt.Errorf("title must contain at least one letter or digit")
+	}
+	taken, err := s.repo.SlugTaken(ctx, slug, postID)
+	if err != nil {
+		return err
+	}
+	if taken {
+		return ErrSlugConflict
+	}
+	return nil
+}
+
+func newRevision(post *Post, editorID string) *Revision {
+	return &Revision{
+		PostID:   post.ID,
+		Title:    post.Title,
+		Body:     post.Body,
+		Excerpt:  post.Excerpt,
+		EditorID: editorID,
+	}
+}
+
+// CreatePreview records a new preview link for a post. The caller signs the
+// returned record's ID and ExpiresAt into the shareable token.
+func (s *Service) CreatePreview(ctx context.Context, postID, createdBy string, input CreatePreviewInput) (*PreviewToken, error) {
+	if _, err := s.repo.FindByID(ctx, postID); err != nil {
+		return nil, err
+	}
+
+	// Clamp the hours before converting: a large count would overflow
+	// time.Duration and wrap to a negative or tiny TTL.
+	ttl := DefaultPreviewTTL
+	if input.ExpiresInHours > 0 {
+		ttl = MaxPreviewTTL
+		if input.ExpiresInHours < int(MaxPreviewTTL/time.Hour) {
+			ttl = time.Duration(input.ExpiresInHours) * time.Hour
+		}
+	}
+
+	now := time.Now()
+	p := &PreviewToken{
+		ID:        utils.NewID(),
+		PostID:    postID,
+		CreatedBy: createdBy,
+		Note:      input.Note,
+		// Whole seconds, so the signed expiry matches the stored one.
+		ExpiresAt: now.Add(ttl).Truncate(time.Second),
+		CreatedAt: now,
+	}
+	if err := s.repo.CreatePreviewToken(ctx, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// ListPreviews returns all preview links of a post, newest first.
+func (s *Service) ListPreviews(ctx context.Context, postID string) ([]PreviewToken, error) {
+	return s.repo.FindPreviewTokens(ctx, postID)
+}
+
+// RevokePreview disables a preview link immediately.
+func (s *Service) RevokePreview(ctx context.Context, postID, previewID string) error {
+	return s.repo.RevokePreviewToken(ctx, postID, previewID)
+}
+
+// GetPreview counts a view on an active preview link and returns its post,
+// whatever the post's status.
+func (s *Service) GetPreview(ctx context.Context, previewID string) (*Post, error) {
+	postID, err := s.repo.RecordPreviewView(ctx, previewID)
+	if err != nil {
+		return nil, ErrPreviewUnavailable
+	}
+	post, err := s.GetByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	Render(post)
+	return post, nil
+}
+
 // Delete removes a post.
 func (s *Service) Delete(ctx context.Context, id string) error {
 	if err := s.repo.Delete(ctx, id); err != nil {
@@ -154,9 +442,136 @@ func (s *Service) SubmitReview(ctx context.Context, id string) error {
 	if post.Status != StatusDraft && post.Status != StatusChangesRequested {
 		return fmt.Errorf("post must be in DRAFT or CHANGES_REQUESTED to submit for review")
 	}
+	open, err := s.repo.CountOpenReviewNotes(ctx, id)
+	if err != nil {
+		return err
+	}
+	if open > 0 {
+		return fmt.Errorf("%w (%d open)", ErrUnresolvedNotes, open)
+	}
 	return s.repo.UpdateStatus(ctx, id, StatusInReview)
 }
 
+// RequestChanges sends a post in review back to its author with a summary
+// and optional inline notes anchored to ranges of the body.
+func (s *Service) RequestChanges(ctx context.Context, postID, editorID string, input RequestChangesInput) ([]ReviewNote, error) {
+	post, err := s.repo.FindByID(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.Status != StatusInReview {
+		return nil, fmt.Errorf("changes can only be requested for posts IN_REVIEW")
+	}
+
+	summary := strings.TrimSpace(input.Summary)
+	if summary == "" {
+		return nil, fmt.Errorf("summary is required")
+	}
+
+	now := time.Now()
+	notes := []ReviewNote{{
+		PostID:    postID,
+		AuthorID:  editorID,
+		Kind:      NoteSummary,
+		Body:      summary,
+		CreatedAt: now,
+	}}
+
+	body := []rune(post.Body)
+	for i, n := range input.Notes {
+		text := strings.TrimSpace(n.Body)
+		if text == "" {
+			return nil, fmt.Errorf("note %d: body is required", i+1)
+		}
+		if n.Start < 0 || n.End <= n.Start || n.End > len(body) {
+			return nil, fmt.Errorf("note %d: range %d-%d is outside the post body", i+1, n.Start, n.End)
+		}
+		notes = append(notes, ReviewNote{
+			PostID:    postID,
+			AuthorID:  editorID,
+			Kind:      NoteInline,
+			Body:      text,
+			Anchor:    &NoteAnchor{Start: n.Start, End: n.End, Quote: string(body[n.Start:n.End])},
+			CreatedAt: now,
+		})
+	}
+
+	if err := s.repo.RequestChanges(ctx, postID, notes); err != nil {
+		return nil, err
+	}
+	return s.ListReviewNotes(ctx, postID)
+}
+
+// ListReviewNotes returns a post's review thread: top-level notes in order,
+// each with its replies.
+func (s *Service) ListReviewNotes(ctx context.Context, postID string) ([]ReviewNote, error) {
+	flat, err := s.repo.FindReviewNotes(ctx, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	index := make(map[int64]int)
+	var threads []ReviewNote
+	for _, n := range flat {
+		if n.ParentID == nil {
+			index[n.ID] = len(threads)
+			threads = append(threads, n)
+		}
+	}
+	for _, n := range flat {
+		if n.ParentID == nil {
+			continue
+		}
+		if i, ok := index[*n.ParentID]; ok {
+			threads[i].Replies = append(threads[i].Replies, n)
+		}
+	}
+	return threads, nil
+}
+
+// ReplyToNote adds a reply to a review note. Replies to replies are attached
+// to the top-level note so threads stay one level deep.
+func (s *Service) ReplyToNote(ctx context.Context, postID string, noteID int64, authorID string, input ReplyNoteInput) (*ReviewNote, error) {
+	body := strings.TrimSpace(input.Body)
+	if body == "" {
+		return nil, fmt.Errorf("body is required")
+	}
+
+	parent, err := s.repo.FindReviewNote(ctx, postID, noteID)
+	if err != nil {
+		return nil, err
+	}
+	parentID := parent.ID
+	if parent.ParentID != nil {
+		parentID = *parent.ParentID
+	}
+
+	note := &ReviewNote{
+		PostID:    postID,
+		ParentID:  &parentID,
+		AuthorID:  authorID,
+		Kind:      NoteReply,
+		Body:      body,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.CreateReviewNote(ctx, note); err != nil {
+		return nil, err
+	}
+	return note, nil
+}
+
+// SetNoteResolved marks a top-level review note as resolved or reopens it.
+func (s *Service) SetNoteResolved(ctx context.Context, postID string, noteID int64, userID string, resolved bool) error {
+	note, err := s.repo.FindReviewNote(ctx, postID, noteID)
+	if err != nil {
+		return err
+	}
+	if note.ParentID != nil {
+		return fmt.Errorf("only top-level notes can be resolved")
+	}
+	return s.repo.SetReviewNoteResolved(ctx, postID, noteID, userID, resolved)
+}
+
 // Publish transitions a post to PUBLISHED.
 func (s *Service) Publish(ctx context.Context, id string) error {
 	post, err := s.repo.FindByID(ctx, id)
@@ -173,6 +588,22 @@ func (s *Service) Publish(ctx context.Context, id string) error {
 	return nil
 }
 
+// PublishAt publishes a post with an explicit published_at, used by imports
+// to keep the original publication date.
+func (s *Service) PublishAt(ctx context.Context, id string, at time.Time) error {
+	if err := s.repo.MarkPublished(ctx, id, at); err != nil {
+		return err
+	}
+	_ = s.cache.DeleteByPrefix(ctx, "posts:")
+	return nil
+}
+
+// SlugAvailable reports whether a new post could use slug.
+func (s *Service) SlugAvailable(ctx context.Context, slug string) (bool, error) {
+	taken, err := s.repo.SlugTaken(ctx, slug, "")
+	return !taken, err
+}
+
 // Schedule sets a post to be published at a future time.
 func (s *Service) Schedule(ctx context.Context, id string, scheduledAt time.Time) error {
 	if scheduledAt.Before(time.Now()) {
@@ -191,21 +622,117 @@ func (s *Service) Schedule(ctx context.Context, id string, scheduledAt time.Time
 	return s.repo.Update(ctx, post)
 }
 
-// Search performs full-text search.
-func (s *Service) Search(ctx context.Context, query string, page, limit int, sort string) (*PostListResult, error) {
-	if page < 1 {
-		page = 1
+// PublishDue publishes every SCHEDULED post whose scheduled_at has passed
+// and returns the posts that were published.
+func (s *Service) PublishDue(ctx context.Context) ([]Post, error) {
+	var published []Post
+	for {
+		batch, err := s.repo.PublishDue(ctx, publishBatchSize)
+		if err != nil {
+			return published, err
+		}
+		published = append(published, batch...)
+		if len(batch) < publishBatchSize {
+			break
+		}
 	}
-	if limit < 1 || limit > 50 {
-		limit = 10
+	if len(published) > 0 {
+		_ = s.cache.DeleteByPrefix(ctx, "posts:")
 	}
-	return s.repo.Search(ctx, query, page, limit, sort)
+	return published, nil
+}
+
+// SetExpiry sets or, with nil, clears the time at which a post is archived
+// automatically.
+func (s *Service) SetExpiry(ctx context.Context, id string, expiresAt *time.Time) (*Post, error) {
+	post, err := s.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if expiresAt != nil {
+		if expiresAt.Before(time.Now()) {
+			return nil, fmt.Errorf("expiry time must be in the future")
+		}
+		if post.Status == StatusScheduled && post.ScheduledAt != nil && !expiresAt.After(*post.ScheduledAt) {
+			return nil, fmt.Errorf("expiry time must be after the scheduled publish time")
+		}
+	}
+	post.ExpiresAt = expiresAt
+	post.UpdatedAt = time.Now()
+	if err := s.repo.Update(ctx, post); err != nil {
+		return nil, err
+	}
+	_ = s.cache.DeleteByPrefix(ctx, "posts:")
+	return post, nil
+}
+
+// ArchiveExpired archives every published post whose expiry has passed and
+// returns them.
+func (s *Service) ArchiveExpired(ctx context.Context) ([]Post, error) {
+	var archived []Post
+	for {
+		batch, err := s.repo.ArchiveExpired(ctx, publishBatchSize)
+		if err != nil {
+			return archived, err
+		}
+		archived = append(archived, batch...)
+		if len(batch) < publishBatchSize {
+			break
+		}
+	}
+	if len(archived) > 0 {
+		_ = s.cache.DeleteByPrefix(ctx, "posts:")
+	}
+	return archived, nil
+}
+
+// Search performs full-text search with filters and facet counts.
+func (s *Service) Search(ctx context.Context, f SearchFilter) (*PostListResult, error) {
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	if f.Limit < 1 || f.Limit > 50 {
+		f.Limit = 10
+	}
+	return s.repo.Search(ctx, f)
 }
 
 // Suggest returns autocomplete suggestions.
-func (s *Service) Suggest(ctx context.Context, query string, limit int) ([]SuggestResult, error) {
+func (s *Service) Suggest(ctx context.Context, query string, lang Language, limit int) ([]SuggestResult, error) {
 	if limit < 1 || limit > 10 {
 		limit = 5
 	}
-	return s.repo.Suggest(ctx, query, limit)
+	return s.repo.Suggest(ctx, query, lang, limit)
+}
+
+// ReindexSearch rebuilds the search vector of every post, in batches, with
+// its language's configuration. With detect, each post's language is first
+// re-detected from its content. It returns the number of posts reindexed.
+func (s *Service) ReindexSearch(ctx context.Context, detect bool) (int, error) {
+	n := 0
+	after := ""
+	for {
+		batch, err := s.repo.FindForReindex(ctx, after, publishBatchSize)
+		if err != nil {
+			return n, err
+		}
+		for _, p := range batch {
+			lang := p.Language
+			if detect {
+				lang = DetectLanguage(p.Title + "\n" + p.Excerpt + "\n" + p.Body)
+			}
+			if err := s.repo.Reindex(ctx, p.ID, lang); err != nil {
+				return n, fmt.Errorf("post %s: %w", p.ID, err)
+			}
+			n++
+			after = p.ID
+		}
+		if len(batch) < publishBatchSize {
+			break
+		}
+	}
+	if n > 0 {
+		_ = s.cache.DeleteByPrefix(ctx, "posts:")
+	}
+	return n, nil
 }
@@ -0,0 +1,63 @@
+package posts
+
+// CreateCategoryInput from admin API.
+type CreateCategoryInput struct {
+	Name        string  `json:"name"`
+	Slug        string  `json:"slug,omitempty"`
+	Description string  `json:"description"`
+	ParentID    *string `json:"parent_id,omitempty"`
+}
+
+// UpdateCategoryInput from admin API. An empty ParentID moves the category
+// to the top level.
+type UpdateCategoryInput struct {
+	Name        *string `json:"name,omitempty"`
+	Slug        *string `json:"slug,omitempty"`
+	Description *string `json:"description,omitempty"`
+	ParentID    *string `json:"parent_id,omitempty"`
+}
+
+// CreateTagInput from admin API.
+type CreateTagInput struct {
+	Name string `json:"name"`
+	Slug string `json:"slug,omitempty"`
+}
+
+// UpdateTagInput from admin API.
+type UpdateTagInput struct {
+	Name *string `json:"name,omitempty"`
+	Slug *string `json:"slug,omitempty"`
+}
+
+// MergeTagsInput lists the tags folded into the target tag. Their posts are
+// retagged and their slugs redirect to the target.
+type MergeTagsInput struct {
+	SourceIDs []string `json:"source_ids"`
+}
+
+// CategoryTree nests a flat category list under its parents, keeping the
+// input order among siblings. Categories whose parent is missing become roots.
+func CategoryTree(flat []Category) []Category {
+	byParent := make(map[string][]Category)
+	known := make(map[string]bool, len(flat))
+	for _, c := range flat {
+		known[c.ID] = true
+	}
+	var roots []Category
+	for _, c := range flat {
+		if c.ParentID != nil && known[*c.ParentID] {
+			byParent[*c.ParentID] = append(byParent[*c.ParentID], c)
+			continue
+		}
+		roots = append(roots, c)
+	}
+
+	var attach func(list []Category) []Category
+	attach = func(list []Category) []Category {
+		for i := range list {
+			list[i].Children = attach(byParent[list[i].ID])
+		}
+		return list
+	}
+	return attach(roots)
+}
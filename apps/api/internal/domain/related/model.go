@@ -0,0 +1,52 @@
+package related
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/rapidtest/netpulse-api/internal/domain/posts"
+	"github.com/rapidtest/netpulse-api/internal/domain/settings"
+)
+
+// Weights scale the ranking signals. Each signal is normalized to 0..1
+// before weighting, so the weights compare directly.
+type Weights struct {
+	Tags     float64 `json:"tags"`     // share of the post's tags the candidate has
+	Category float64 `json:"category"` // same category
+	Text     float64 `json:"text"`     // full-text similarity of the search vectors
+	CoViews  float64 `json:"coviews"`  // readers who viewed both posts recently
+	Recency  float64 `json:"recency"`  // newer candidates first
+}
+
+// DefaultWeights apply when a weight is not set in site settings.
+var DefaultWeights = Weights{Tags: 3, Category: 1, Text: 2, CoViews: 2, Recency: 1}
+
+// WeightsFromSettings reads the related_weight_* site settings. Missing,
+// invalid or negative values fall back to DefaultWeights.
+func WeightsFromSettings(s map[string]string) Weights {
+	w := DefaultWeights
+	for key, dst := range map[string]*float64{
+		settings.KeyRelatedWeightTags:     &w.Tags,
+		settings.KeyRelatedWeightCategory: &w.Category,
+		settings.KeyRelatedWeightText:     &w.Text,
+		settings.KeyRelatedWeightCoViews:  &w.CoViews,
+		settings.KeyRelatedWeightRecency:  &w.Recency,
+	} {
+		if v, err := strconv.ParseFloat(s[key], 64); err == nil && v >= 0 {
+			*dst = v
+		}
+	}
+	return w
+}
+
+// Post is a ranked "read next" suggestion.
+type Post struct {
+	ID          string          `json:"id"`
+	Title       string          `json:"title"`
+	Slug        string          `json:"slug"`
+	Excerpt     string          `json:"excerpt"`
+	CoverURL    string          `json:"cover_url,omitempty"`
+	PublishedAt *time.Time      `json:"published_at,omitempty"`
+	Category    *posts.Category `json:"category,omitempty"`
+	Score       float64         `json:"score"`
+}
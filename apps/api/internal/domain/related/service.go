@@ -0,0 +1,77 @@
+package related
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DefaultLimit and MaxLimit bound the number of suggestions per request.
+const (
+	DefaultLimit = 5
+	MaxLimit     = 20
+)
+
+// cacheTTL bounds staleness of co-view signals; post changes clear the
+// cache immediately through the "posts:" prefix.
+const cacheTTL = time.Hour
+
+// CachePrefix is the key prefix of cached suggestions.
+const CachePrefix = "posts:related:"
+
+// Repository ranks published posts against a published post.
+type Repository interface {
+	FindRelated(ctx context.Context, idOrSlug string, w Weights, limit int) ([]Post, error)
+}
+
+// SettingsSource provides the site settings holding the weights.
+type SettingsSource interface {
+	GetAll(ctx context.Context) (map[string]string, error)
+}
+
+// Cache stores ranked results per post.
+type Cache interface {
+	Get(ctx context.Context, key string) (string, error)
+	Set(ctx context.Context, key string, value string, ttl time.Duration) error
+}
+
+type Service struct {
+	repo     Repository
+	settings SettingsSource
+	cache    Cache
+}
+
+func NewService(repo Repository, settings SettingsSource, cache Cache) *Service {
+	return &Service{repo: repo, settings: settings, cache: cache}
+}
+
+// Related returns up to limit published posts related to the published post
+// with the given ID or slug, best first.
+func (s *Service) Related(ctx context.Context, idOrSlug string, limit int) ([]Post, error) {
+	if limit < 1 || limit > MaxLimit {
+		limit = DefaultLimit
+	}
+
+	key := fmt.Sprintf("%s%s:%d", CachePrefix, idOrSlug, limit)
+	if cached, err := s.cache.Get(ctx, key); err == nil && cached != "" {
+		var items []Post
+		if json.Unmarshal([]byte(cached), &items) == nil {
+			return items, nil
+		}
+	}
+
+	all, _ := s.settings.GetAll(ctx)
+	items, err := s.repo.FindRelated(ctx, idOrSlug, WeightsFromSettings(all), limit)
+	if err != nil {
+		return nil, err
+	}
+	if items == nil {
+		items = make([]Post, 0)
+	}
+
+	if data, err := json.Marshal(items); err == nil {
+		_ = s.cache.Set(ctx, key, string(data), cacheTTL)
+	}
+	return items, nil
+}
@@ -0,0 +1,144 @@
+package reports
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/rapidtest/netpulse-api/internal/domain/settings"
+)
+
+// Reported content types.
+const (
+	TargetPost    = "post"
+	TargetComment = "comment"
+)
+
+// Reason categories a reader picks from.
+const (
+	ReasonSpam           = "spam"
+	ReasonHarassment     = "harassment"
+	ReasonHateSpeech     = "hate_speech"
+	ReasonMisinformation = "misinformation"
+	ReasonInappropriate  = "inappropriate"
+	ReasonCopyright      = "copyright"
+	ReasonOther          = "other"
+)
+
+// Reasons lists the valid reasons in display order.
+var Reasons = []string{
+	ReasonSpam, ReasonHarassment, ReasonHateSpeech, ReasonMisinformation,
+	ReasonInappropriate, ReasonCopyright, ReasonOther,
+}
+
+// ValidReason reports whether r is a known reason.
+func ValidReason(r string) bool {
+	for _, v := range Reasons {
+		if v == r {
+			return true
+		}
+	}
+	return false
+}
+
+// MaxDetails bounds the free-text details of a report.
+const MaxDetails = 1000
+
+// Item statuses.
+const (
+	StatusOpen      = "open"
+	StatusDismissed = "dismissed" // reports rejected, content restored
+	StatusRemoved   = "removed"   // content rejected or archived
+)
+
+// Resolution actions.
+const (
+	ActionDismiss = "dismiss"
+	ActionRemove  = "remove"
+)
+
+// CreateReportInput from the public API.
+type CreateReportInput struct {
+	Reason  string `json:"reason"`
+	Details string `json:"details,omitempty"`
+}
+
+// Report is one reader's report.
+type Report struct {
+	ID          string    `json:"id"`
+	ItemID      string    `json:"item_id"`
+	Reason      string    `json:"reason"`
+	Details     string    `json:"details,omitempty"`
+	ReporterID  *string   `json:"reporter_id,omitempty"`
+	ReporterKey string    `json:"-"`
+	IPHash      string    `json:"-"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// Joined fields
+	ReporterName string `json:"reporter_name,omitempty"`
+}
+
+// Item groups the reports on one post or comment.
+type Item struct {
+	ID             string     `json:"id"`
+	TargetType     string     `json:"target_type"`
+	TargetID       string     `json:"target_id"`
+	PostID         string     `json:"post_id"`
+	Status         string     `json:"status"`
+	OpenReports    int        `json:"open_reports"`
+	HiddenAt       *time.Time `json:"hidden_at,omitempty"`
+	PriorStatus    string     `json:"-"`
+	ResolvedBy     *string    `json:"resolved_by,omitempty"`
+	ResolvedAt     *time.Time `json:"resolved_at,omitempty"`
+	ResolutionNote string     `json:"resolution_note,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+
+	// Joined fields
+	PostTitle string         `json:"post_title,omitempty"`
+	Excerpt   string         `json:"excerpt,omitempty"` // comment content or post excerpt
+	Author    string         `json:"author,omitempty"`
+	Reasons   map[string]int `json:"reasons,omitempty"` // open reports per reason
+	Reports   []Report       `json:"reports,omitempty"`
+}
+
+// QueueFilter selects report items for moderators.
+type QueueFilter struct {
+	Status     string
+	TargetType string
+	Page       int
+	Limit      int
+}
+
+// ItemList is a page of report items.
+type ItemList struct {
+	Items      []Item `json:"items"`
+	Total      int    `json:"total"`
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	TotalPages int    `json:"total_pages"`
+}
+
+// ResolveInput from moderators.
+type ResolveInput struct {
+	Action string `json:"action"`
+	Note   string `json:"note,omitempty"`
+}
+
+// Default auto-hide thresholds.
+const (
+	DefaultHideThresholdComments = 3
+	DefaultHideThresholdPosts    = 5
+)
+
+// HideThreshold returns the open reports that hide a target of the given
+// type; 0 means never.
+func HideThreshold(s map[string]string, targetType string) int {
+	key, def := settings.KeyReportHideThresholdComments, DefaultHideThresholdComments
+	if targetType == TargetPost {
+		key, def = settings.KeyReportHideThresholdPosts, DefaultHideThresholdPosts
+	}
+	if n, err := strconv.Atoi(s[key]); err == nil && n >= 0 {
+		return n
+	}
+	return def
+}
@@ -0,0 +1,114 @@
+package search
+
+import (
+	"time"
+
+	"github.com/rapidtest/netpulse-api/internal/domain/posts"
+)
+
+// Result types of a unified search.
+const (
+	TypePost      = "post"
+	TypeListing   = "listing"
+	TypePortfolio = "portfolio"
+)
+
+// Types lists every result type in facet order.
+var Types = []string{TypePost, TypeListing, TypePortfolio}
+
+// TypeLabels are the facet labels of the result types.
+var TypeLabels = map[string]string{
+	TypePost:      "Artikel",
+	TypeListing:   "Toko",
+	TypePortfolio: "Portfolio",
+}
+
+// Filter holds a unified search. Posts takes the post query and filters;
+// only Query, Language, Page, Limit and Sort apply to the other types.
+type Filter struct {
+	Posts posts.SearchFilter
+	Types []string // result types to return; counts cover all of them
+}
+
+// Searchable returns the types a search can find: post-only filters rule
+// out listings and portfolio items.
+func (f Filter) Searchable() []string {
+	if f.Posts.HasFilters() {
+		return []string{TypePost}
+	}
+	return Types
+}
+
+// Selected returns the searchable types among f.Types.
+func (f Filter) Selected() []string {
+	var types []string
+	for _, t := range f.Searchable() {
+		for _, want := range f.Types {
+			if t == want {
+				types = append(types, t)
+			}
+		}
+	}
+	return types
+}
+
+// TypeFacet lists the result count of every type.
+func TypeFacet(counts map[string]int) []posts.FacetValue {
+	values := make([]posts.FacetValue, 0, len(Types))
+	for _, t := range Types {
+		values = append(values, posts.FacetValue{Value: t, Label: TypeLabels[t], Count: counts[t]})
+	}
+	return values
+}
+
+// Result is one typed search hit. Rank is comparable across types.
+type Result struct {
+	Type      string           `json:"type"`
+	ID        string           `json:"id"`
+	Title     string           `json:"title"`
+	Slug      string           `json:"slug,omitempty"`
+	Excerpt   string           `json:"excerpt,omitempty"`
+	CoverURL  string           `json:"cover_url,omitempty"`
+	Category  string           `json:"category,omitempty"` // post or listing category, portfolio listing
+	Date      *time.Time       `json:"date,omitempty"`     // published_at of posts, created_at otherwise
+	Price     int64            `json:"price,omitempty"`    // listing base price
+	URL       string           `json:"url"`
+	Rank      float64          `json:"rank"`
+	Highlight *posts.Highlight `json:"highlight,omitempty"`
+
+	// ListingSlug is the listing a portfolio item belongs to.
+	ListingSlug string `json:"-"`
+	PreviewURL  string `json:"-"`
+}
+
+// SetURL fills URL: posts live on the site, listings and portfolio items on
+// the store. Portfolio items link to their listing, their preview or the
+// store portfolio section.
+func (r *Result) SetURL(siteURL, storeURL string) {
+	switch r.Type {
+	case TypePost:
+		r.URL = siteURL + "/posts/" + r.Slug
+	case TypeListing:
+		r.URL = storeURL + "/" + r.Slug
+	case TypePortfolio:
+		switch {
+		case r.ListingSlug != "":
+			r.URL = storeURL + "/" + r.ListingSlug
+		case r.PreviewURL != "":
+			r.URL = r.PreviewURL
+		default:
+			r.URL = storeURL + "/#portfolio"
+		}
+	}
+}
+
+// ResultList is a page of unified search results.
+type ResultList struct {
+	Items      []Result            `json:"items"`
+	Page       int                 `json:"page"`
+	Limit      int                 `json:"limit"`
+	Total      int                 `json:"total"`
+	TotalPages int                 `json:"total_pages"`
+	Language   posts.Language      `json:"language,omitempty"`
+	Facets     *posts.SearchFacets `json:"facets"`
+}
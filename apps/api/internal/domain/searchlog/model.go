@@ -0,0 +1,93 @@
+package searchlog
+
+import (
+	"strings"
+	"time"
+)
+
+// Sources of a logged search.
+const (
+	SourceSearch  = "search"
+	SourceSuggest = "suggest"
+)
+
+// maxQueryLen caps the stored query length in runes.
+const maxQueryLen = 120
+
+// Search is one logged search request.
+type Search struct {
+	ID            string
+	Query         string // normalized; see Normalize
+	Source        string
+	ContentType   string // "post" or "listing"
+	Language      string
+	Results       int // total matches
+	FirstPosition int // position of the first result on the returned page
+	Shown         int // results on the returned page
+	IPHash        string
+}
+
+// Click is a reader opening a search result.
+type Click struct {
+	SearchID   string `json:"search_id"`
+	Position   int    `json:"position"` // 1-based rank across pages
+	ResultType string `json:"type"`
+	ResultID   string `json:"id"`
+}
+
+// Normalize lowercases a query and collapses its whitespace, so spelling
+// variants of the same query are counted together.
+func Normalize(q string) string {
+	q = strings.Join(strings.Fields(strings.ToLower(q)), " ")
+	if r := []rune(q); len(r) > maxQueryLen {
+		q = string(r[:maxQueryLen])
+	}
+	return q
+}
+
+// ReportFilter selects the searches of a report.
+type ReportFilter struct {
+	Source    string
+	Since     time.Time
+	Limit     int // queries per list
+	Positions int // result positions in CTRByPosition
+}
+
+// QueryStat aggregates the searches for one normalized query.
+type QueryStat struct {
+	Query        string    `json:"query"`
+	Searches     int       `json:"searches"`
+	ZeroResults  int       `json:"zero_results"`
+	Clicked      int       `json:"clicked"` // searches with at least one click
+	CTR          float64   `json:"ctr"`
+	LastSearched time.Time `json:"last_searched"`
+}
+
+// PositionStat is the click-through rate of one result position.
+type PositionStat struct {
+	Position    int     `json:"position"`
+	Impressions int     `json:"impressions"`
+	Clicks      int     `json:"clicks"`
+	CTR         float64 `json:"ctr"`
+}
+
+// Report is the admin search analytics overview.
+type Report struct {
+	Source            string         `json:"source"`
+	Days              int            `json:"days"`
+	TotalSearches     int            `json:"total_searches"`
+	UniqueQueries     int            `json:"unique_queries"`
+	ZeroResultRate    float64        `json:"zero_result_rate"`
+	CTR               float64        `json:"ctr"`
+	TopQueries        []QueryStat    `json:"top_queries"`
+	ZeroResultQueries []QueryStat    `json:"zero_result_queries"`
+	CTRByPosition     []PositionStat `json:"ctr_by_position"`
+}
+
+// Rate returns part/total, or 0 when total is 0.
+func Rate(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
@@ -0,0 +1,97 @@
+package searchlog
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Repository persists searches and clicks.
+type Repository interface {
+	InsertSearch(ctx context.Context, s *Search) error
+	InsertClick(ctx context.Context, c *Click) error
+}
+
+// queueSize bounds the entries waiting to be written. Entries beyond it are
+// dropped rather than slowing down search requests.
+const queueSize = 1024
+
+// writeTimeout bounds a single insert.
+const writeTimeout = 5 * time.Second
+
+type entry struct {
+	search *Search
+	click  *Click
+}
+
+// Recorder writes searches and clicks in the background. Searches and
+// clicks share one queue, so a click is written after its search.
+type Recorder struct {
+	repo  Repository
+	queue chan entry
+}
+
+func NewRecorder(repo Repository) *Recorder {
+	return &Recorder{repo: repo, queue: make(chan entry, queueSize)}
+}
+
+// RecordSearch queues a search for logging.
+func (r *Recorder) RecordSearch(s Search) {
+	r.enqueue(entry{search: &s})
+}
+
+// RecordClick queues a result click for logging.
+func (r *Recorder) RecordClick(c Click) {
+	r.enqueue(entry{click: &c})
+}
+
+func (r *Recorder) enqueue(e entry) {
+	select {
+	case r.queue <- e:
+	default:
+		log.Warn().Msg("search log queue full, dropping entry")
+	}
+}
+
+// Run writes queued entries until ctx is cancelled, then writes the
+// entries still queued and returns. Stop the HTTP server first so nothing
+// is queued after that.
+func (r *Recorder) Run(ctx context.Context) {
+	ctx, stop := context.WithoutCancel(ctx), ctx.Done()
+	for {
+		select {
+		case <-stop:
+			r.drain(ctx)
+			return
+		case e := <-r.queue:
+			r.write(ctx, e)
+		}
+	}
+}
+
+func (r *Recorder) drain(ctx context.Context) {
+	for {
+		select {
+		case e := <-r.queue:
+			r.write(ctx, e)
+		default:
+			return
+		}
+	}
+}
+
+func (r *Recorder) write(ctx context.Context, e entry) {
+	ctx, cancel := context.WithTimeout(ctx, writeTimeout)
+	defer cancel()
+
+	var err error
+	if e.search != nil {
+		err = r.repo.InsertSearch(ctx, e.search)
+	} else {
+		err = r.repo.InsertClick(ctx, e.click)
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("failed to write search log")
+	}
+}
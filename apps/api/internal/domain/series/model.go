@@ -0,0 +1,53 @@
+package series
+
+import "time"
+
+// Series groups posts into an ordered, multi-part collection.
+type Series struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Slug        string    `json:"slug"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+
+	// Joined fields
+	PostCount int          `json:"post_count"`
+	Posts     []SeriesPost `json:"posts,omitempty"`
+}
+
+// SeriesPost is a post's entry in a series.
+type SeriesPost struct {
+	PostID      string     `json:"post_id"`
+	Title       string     `json:"title"`
+	Slug        string     `json:"slug"`
+	Excerpt     string     `json:"excerpt,omitempty"`
+	CoverURL    string     `json:"cover_url,omitempty"`
+	Status      string     `json:"status,omitempty"`
+	Position    int        `json:"position"`
+	PublishedAt *time.Time `json:"published_at,omitempty"`
+}
+
+// CreateSeriesInput from admin API.
+type CreateSeriesInput struct {
+	Title       string `json:"title"`
+	Slug        string `json:"slug,omitempty"`
+	Description string `json:"description"`
+}
+
+// UpdateSeriesInput from admin API.
+type UpdateSeriesInput struct {
+	Title       *string `json:"title,omitempty"`
+	Slug        *string `json:"slug,omitempty"`
+	Description *string `json:"description,omitempty"`
+}
+
+// SetPostsInput replaces a series' posts; order in PostIDs becomes position.
+type SetPostsInput struct {
+	PostIDs []string `json:"post_ids"`
+}
+
+// AddPostInput attaches one post. A nil Position appends it at the end.
+type AddPostInput struct {
+	PostID   string `json:"post_id"`
+	Position *int   `json:"position,omitempty"`
+}
@@ -29,4 +29,30 @@ const (
 	KeyFooterText             = "footer_text"
 	KeyAboutPage              = "about_page"
 	KeyContactEmail           = "contact_email"
+
+	// Related posts ranking weights (non-negative numbers).
+	KeyRelatedWeightTags     = "related_weight_tags"
+	KeyRelatedWeightCategory = "related_weight_category"
+	KeyRelatedWeightText     = "related_weight_text"
+	KeyRelatedWeightCoViews  = "related_weight_coviews"
+	KeyRelatedWeightRecency  = "related_weight_recency"
+
+	// Reply levels shown nested before deeper replies are flattened.
+	KeyCommentMaxDepth = "comment_max_depth"
+	// Minutes after posting during which owners may edit or delete.
+	KeyCommentEditWindow = "comment_edit_window_minutes"
+
+	// Comment spam scoring; see the spam package.
+	KeySpamThreshold          = "spam_threshold"
+	KeySpamCleanThreshold     = "spam_clean_threshold"
+	KeySpamTrustedMinApproved = "spam_trusted_min_approved"
+	KeySpamMaxLinks           = "spam_max_links"
+	KeySpamVelocityIP         = "spam_velocity_ip"
+	KeySpamVelocityEmail      = "spam_velocity_email"
+	KeySpamBlocklistWords     = "spam_blocklist_words"
+	KeySpamBlocklistDomains   = "spam_blocklist_domains"
+
+	// Open reader reports that auto-hide a comment or post (0 = never).
+	KeyReportHideThresholdComments = "report_hide_threshold_comments"
+	KeyReportHideThresholdPosts    = "report_hide_threshold_posts"
 )
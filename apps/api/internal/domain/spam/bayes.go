@@ -0,0 +1,109 @@
+package spam
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/rapidtest/netpulse-api/internal/domain/comments"
+)
+
+// MinTrainingDocs is the number of comments per class the model needs
+// before it scores anything.
+const MinTrainingDocs = 10
+
+// interestingTokens is the number of tokens furthest from neutral that
+// decide the Bayes score.
+const interestingTokens = 15
+
+// TokenCount counts documents of each class.
+type TokenCount struct {
+	Spam int
+	Ham  int
+}
+
+// Model stores token counts learned from moderation decisions.
+type Model interface {
+	// Counts returns the counts of the given tokens and the number of
+	// trained documents per class.
+	Counts(ctx context.Context, tokens []string) (map[string]TokenCount, TokenCount, error)
+	// Learn counts tokens for a comment under label, replacing what was
+	// learned from it before.
+	Learn(ctx context.Context, commentID string, label comments.CommentStatus, tokens []string) error
+}
+
+// Learn trains m with a moderation decision. Only SPAM and APPROVED teach
+// the model; other statuses are ignored.
+func Learn(ctx context.Context, m Model, commentID, content string, status comments.CommentStatus) error {
+	if status != comments.StatusSpam && status != comments.StatusApproved {
+		return nil
+	}
+	return m.Learn(ctx, commentID, status, Tokenize(content))
+}
+
+// BayesCheck scores content with a naive Bayes model: token probabilities
+// are smoothed towards neutral (Robinson) and the most telling ones
+// combined.
+type BayesCheck struct {
+	model Model
+}
+
+func NewBayesCheck(model Model) *BayesCheck {
+	return &BayesCheck{model: model}
+}
+
+func (c *BayesCheck) Score(ctx context.Context, in Input, _ Config) (Signal, error) {
+	sig := Signal{Name: "bayes"}
+	tokens := Tokenize(in.Content)
+	if len(tokens) == 0 {
+		return sig, nil
+	}
+	counts, docs, err := c.model.Counts(ctx, tokens)
+	if err != nil {
+		return sig, err
+	}
+	if docs.Spam < MinTrainingDocs || docs.Ham < MinTrainingDocs {
+		return sig, nil
+	}
+
+	var probs []float64
+	for _, t := range tokens {
+		tc, ok := counts[t]
+		if !ok {
+			continue
+		}
+		s := math.Min(1, float64(tc.Spam)/float64(docs.Spam))
+		h := math.Min(1, float64(tc.Ham)/float64(docs.Ham))
+		if s+h == 0 {
+			continue
+		}
+		n := float64(tc.Spam + tc.Ham)
+		p := (0.5 + n*s/(s+h)) / (1 + n)
+		probs = append(probs, math.Max(0.01, math.Min(0.99, p)))
+	}
+	if len(probs) == 0 {
+		return sig, nil
+	}
+
+	sort.Slice(probs, func(i, j int) bool {
+		return math.Abs(probs[i]-0.5) > math.Abs(probs[j]-0.5)
+	})
+	if len(probs) > interestingTokens {
+		probs = probs[:interestingTokens]
+	}
+	// P = Πp / (Πp + Π(1-p)), in log space.
+	var eta float64
+	for _, p := range probs {
+		eta += math.Log(1-p) - math.Log(p)
+	}
+	score := 1 / (1 + math.Exp(eta))
+
+	// Below neutral the model says ham, which is not evidence of spam;
+	// above it, 0.5..1 is stretched to 0..1.
+	if score > 0.5 {
+		sig.Score = 2 * (score - 0.5)
+		sig.Detail = fmt.Sprintf("spam probability %.2f from %d known tokens", score, len(probs))
+	}
+	return sig, nil
+}
@@ -0,0 +1,83 @@
+package spam
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rapidtest/netpulse-api/internal/domain/comments"
+)
+
+// fakeModel serves fixed token counts.
+type fakeModel struct {
+	counts map[string]TokenCount
+	docs   TokenCount
+}
+
+func (m *fakeModel) Counts(_ context.Context, tokens []string) (map[string]TokenCount, TokenCount, error) {
+	found := make(map[string]TokenCount)
+	for _, t := range tokens {
+		if c, ok := m.counts[t]; ok {
+			found[t] = c
+		}
+	}
+	return found, m.docs, nil
+}
+
+func (m *fakeModel) Learn(context.Context, string, comments.CommentStatus, []string) error {
+	return nil
+}
+
+func bayesScore(t *testing.T, m Model, content string) float64 {
+	t.Helper()
+	sig, err := NewBayesCheck(m).Score(context.Background(), Input{Content: content}, DefaultConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sig.Score
+}
+
+func TestBayesCheck(t *testing.T) {
+	m := &fakeModel{
+		counts: map[string]TokenCount{
+			"casino":  {Spam: 40, Ham: 0},
+			"bonus":   {Spam: 30, Ham: 1},
+			"article": {Spam: 1, Ham: 40},
+			"thanks":  {Spam: 0, Ham: 30},
+			"the":     {Spam: 40, Ham: 40},
+		},
+		docs: TokenCount{Spam: 50, Ham: 50},
+	}
+
+	spam := bayesScore(t, m, "casino bonus")
+	if spam < 0.9 {
+		t.Errorf("spam tokens scored %v, want at least 0.9", spam)
+	}
+	if got := bayesScore(t, m, "thanks for the article"); got != 0 {
+		t.Errorf("ham tokens scored %v, want 0", got)
+	}
+	if got := bayesScore(t, m, "unknown words only"); got != 0 {
+		t.Errorf("unknown tokens scored %v, want 0", got)
+	}
+	if got := bayesScore(t, m, "the casino bonus and the article"); got <= 0 || got >= spam {
+		t.Errorf("mixed tokens scored %v, want between 0 and %v", got, spam)
+	}
+}
+
+func TestBayesCheckMinTrainingDocs(t *testing.T) {
+	counts := map[string]TokenCount{"casino": {Spam: 9, Ham: 0}}
+	tests := []struct {
+		docs  TokenCount
+		score bool
+	}{
+		{TokenCount{Spam: 0, Ham: 0}, false},
+		{TokenCount{Spam: MinTrainingDocs - 1, Ham: 100}, false},
+		{TokenCount{Spam: 100, Ham: MinTrainingDocs - 1}, false},
+		{TokenCount{Spam: MinTrainingDocs, Ham: MinTrainingDocs}, true},
+	}
+	for _, tt := range tests {
+		got := bayesScore(t, &fakeModel{counts: counts, docs: tt.docs}, "casino")
+		if (got > 0) != tt.score {
+			t.Errorf("docs %+v: score %v, want scoring %v", tt.docs, got, tt.score)
+		}
+	}
+}
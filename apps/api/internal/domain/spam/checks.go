@@ -0,0 +1,165 @@
+package spam
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// LinkCheck scores the number of links: a little per link up to
+// Config.MaxLinks, steeply above.
+type LinkCheck struct{}
+
+func (LinkCheck) Score(_ context.Context, in Input, cfg Config) (Signal, error) {
+	sig := Signal{Name: "links"}
+	n := len(Links(in.Content))
+	if n == 0 {
+		return sig, nil
+	}
+	sig.Detail = fmt.Sprintf("%d links", n)
+	if n <= cfg.MaxLinks {
+		sig.Score = 0.15 * float64(n)
+	} else {
+		sig.Score = math.Min(1, 0.5+0.2*float64(n-cfg.MaxLinks))
+	}
+	return sig, nil
+}
+
+// BlocklistCheck scores blocklisted words or phrases and linked domains.
+// A blocked domain is near-certain spam; subdomains are blocked with it.
+type BlocklistCheck struct{}
+
+func (BlocklistCheck) Score(_ context.Context, in Input, cfg Config) (Signal, error) {
+	sig := Signal{Name: "blocklist"}
+
+	for _, link := range Links(in.Content) {
+		d := Domain(link)
+		for _, blocked := range cfg.BlockedDomains {
+			if d == blocked || strings.HasSuffix(d, "."+blocked) {
+				sig.Score, sig.Detail = 0.95, "domain "+blocked
+				return sig, nil
+			}
+		}
+	}
+
+	// Match whole words, so phrases are compared on word boundaries.
+	text := " " + strings.Join(words(in.Content), " ") + " "
+	var hits []string
+	for _, w := range cfg.BlockedWords {
+		if strings.Contains(text, " "+strings.Join(words(w), " ")+" ") {
+			hits = append(hits, w)
+		}
+	}
+	switch {
+	case len(hits) == 1:
+		sig.Score = 0.6
+	case len(hits) > 1:
+		sig.Score = 0.9
+	}
+	sig.Detail = strings.Join(hits, ", ")
+	return sig, nil
+}
+
+// DuplicateWindow is how far back identical comments count.
+const DuplicateWindow = 7 * 24 * time.Hour
+
+// duplicateMinLength skips short content, where repeats like "terima
+// kasih" are normal.
+const duplicateMinLength = 20
+
+// DuplicateSource counts earlier comments with the same content hash.
+type DuplicateSource interface {
+	CountDuplicates(ctx context.Context, hash string, since time.Time) (total, spam int, err error)
+}
+
+// DuplicateCheck scores content posted before, most of all content already
+// marked as spam.
+type DuplicateCheck struct {
+	source DuplicateSource
+}
+
+func NewDuplicateCheck(source DuplicateSource) *DuplicateCheck {
+	return &DuplicateCheck{source: source}
+}
+
+func (c *DuplicateCheck) Score(ctx context.Context, in Input, _ Config) (Signal, error) {
+	sig := Signal{Name: "duplicate"}
+	if len(strings.TrimSpace(in.Content)) < duplicateMinLength {
+		return sig, nil
+	}
+	total, spam, err := c.source.CountDuplicates(ctx, ContentHash(in.Content), time.Now().Add(-DuplicateWindow))
+	if err != nil {
+		return sig, err
+	}
+	switch {
+	case spam > 0:
+		sig.Score = 0.95
+	case total == 1:
+		sig.Score = 0.5
+	case total > 1:
+		sig.Score = 0.8
+	}
+	if total > 0 {
+		sig.Detail = fmt.Sprintf("%d identical comments", total)
+	}
+	return sig, nil
+}
+
+// VelocityWindow is the period comment rates are counted over.
+const VelocityWindow = 10 * time.Minute
+
+// Counter counts events per key over a window.
+type Counter interface {
+	Incr(ctx context.Context, key string, window time.Duration) (int64, error)
+}
+
+// VelocityCheck scores many comments in a short time from one IP or one
+// guest email. Every new classified comment counts, spam included; edits
+// are not scored.
+type VelocityCheck struct {
+	counter Counter
+}
+
+func NewVelocityCheck(counter Counter) *VelocityCheck {
+	return &VelocityCheck{counter: counter}
+}
+
+func (c *VelocityCheck) Score(ctx context.Context, in Input, cfg Config) (Signal, error) {
+	sig := Signal{Name: "velocity"}
+	if in.Edit {
+		return sig, nil
+	}
+	rate := func(key string, limit int, label string) error {
+		if limit == 0 {
+			return nil
+		}
+		n, err := c.counter.Incr(ctx, key, VelocityWindow)
+		if err != nil {
+			return err
+		}
+		if over := int(n) - limit; over > 0 {
+			if s := math.Min(1, 0.5+0.15*float64(over)); s > sig.Score {
+				sig.Score = s
+				sig.Detail = fmt.Sprintf("%d comments per %s from this %s", n, VelocityWindow, label)
+			}
+		}
+		return nil
+	}
+
+	if in.IPHash != "" {
+		if err := rate("spam:ip:"+in.IPHash, cfg.VelocityIP, "IP"); err != nil {
+			return sig, err
+		}
+	}
+	if email := strings.ToLower(strings.TrimSpace(in.GuestEmail)); email != "" && in.UserID == "" {
+		h := sha256.Sum256([]byte(email))
+		if err := rate("spam:email:"+hex.EncodeToString(h[:16]), cfg.VelocityEmail, "email"); err != nil {
+			return sig, err
+		}
+	}
+	return sig, nil
+}
@@ -0,0 +1,38 @@
+package spam
+
+import (
+	"context"
+	"testing"
+)
+
+func TestBlocklistCheck(t *testing.T) {
+	cfg := DefaultConfig
+	cfg.BlockedWords = []string{"casino", "cheap pills", "viagra"}
+	cfg.BlockedDomains = []string{"spam.example"}
+
+	tests := []struct {
+		name    string
+		content string
+		score   float64
+		detail  string
+	}{
+		{"clean", "Nice article, thanks!", 0, ""},
+		{"word", "Best CASINO bonus", 0.6, "casino"},
+		{"phrase", "buy cheap, pills here", 0.6, "cheap pills"},
+		{"two words", "casino and viagra", 0.9, "casino, viagra"},
+		{"inside a word", "casinos and cheap pillsbury", 0, ""},
+		{"domain", "see https://spam.example/offer", 0.95, "domain spam.example"},
+		{"subdomain", "see www.shop.spam.example", 0.95, "domain spam.example"},
+		{"lookalike domain", "see https://notspam.example", 0, ""},
+		{"domain in text only", "spam.example is bad", 0, ""},
+	}
+	for _, tt := range tests {
+		sig, err := BlocklistCheck{}.Score(context.Background(), Input{Content: tt.content}, cfg)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		if sig.Score != tt.score || sig.Detail != tt.detail {
+			t.Errorf("%s: got (%v, %q), want (%v, %q)", tt.name, sig.Score, sig.Detail, tt.score, tt.detail)
+		}
+	}
+}
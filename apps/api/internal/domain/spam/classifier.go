@@ -0,0 +1,68 @@
+package spam
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Check scores one aspect of a comment. Checks return a zero score when
+// they find nothing; errors skip the check.
+type Check interface {
+	Score(ctx context.Context, in Input, cfg Config) (Signal, error)
+}
+
+// SettingsSource provides the site settings holding the Config.
+type SettingsSource interface {
+	GetAll(ctx context.Context) (map[string]string, error)
+}
+
+// TrustSource tells whether a user's clean comments need no moderation.
+type TrustSource interface {
+	IsTrusted(ctx context.Context, userID string, minApproved int) (bool, error)
+}
+
+// Classifier scores new comments with a set of checks and picks their
+// status.
+type Classifier struct {
+	settings SettingsSource
+	trust    TrustSource
+	checks   []Check
+}
+
+func NewClassifier(settings SettingsSource, trust TrustSource, checks ...Check) *Classifier {
+	return &Classifier{settings: settings, trust: trust, checks: checks}
+}
+
+// Classify scores in and decides its status: spam at a high score,
+// approved when clean and written by a trusted user, pending otherwise.
+func (c *Classifier) Classify(ctx context.Context, in Input) Verdict {
+	cfg := DefaultConfig
+	if all, err := c.settings.GetAll(ctx); err == nil {
+		cfg = ConfigFromSettings(all)
+	}
+
+	v := Verdict{Signals: []Signal{}}
+	for _, check := range c.checks {
+		sig, err := check.Score(ctx, in, cfg)
+		if err != nil {
+			log.Warn().Err(err).Str("check", fmt.Sprintf("%T", check)).Msg("spam check failed")
+			continue
+		}
+		if sig.Score > 0 {
+			v.Signals = append(v.Signals, sig)
+		}
+	}
+	v.Score = Combine(v.Signals)
+
+	if in.UserID != "" {
+		trusted, err := c.trust.IsTrusted(ctx, in.UserID, cfg.TrustedMinApproved)
+		if err != nil {
+			log.Warn().Err(err).Msg("spam trust lookup failed")
+		}
+		v.Trusted = trusted
+	}
+	v.Status = cfg.Status(v.Score, v.Trusted)
+	return v
+}
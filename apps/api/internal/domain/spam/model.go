@@ -0,0 +1,194 @@
+package spam
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/rapidtest/netpulse-api/internal/domain/comments"
+	"github.com/rapidtest/netpulse-api/internal/domain/settings"
+)
+
+// Config holds the scoring settings. Scores are in 0..1.
+type Config struct {
+	SpamThreshold      float64 // at or above: StatusSpam
+	CleanThreshold     float64 // below: clean, auto-approved for trusted users
+	TrustedMinApproved int     // approved comments that make a user trusted
+	MaxLinks           int     // links a normal comment may have
+	VelocityIP         int     // comments per VelocityWindow and IP, 0 = off
+	VelocityEmail      int     // comments per VelocityWindow and guest email, 0 = off
+	BlockedWords       []string
+	BlockedDomains     []string
+}
+
+// DefaultConfig is used for missing or invalid settings.
+var DefaultConfig = Config{
+	SpamThreshold:      0.9,
+	CleanThreshold:     0.3,
+	TrustedMinApproved: 3,
+	MaxLinks:           2,
+	VelocityIP:         5,
+	VelocityEmail:      3,
+}
+
+// ConfigFromSettings reads the spam_* settings. Blocklists are separated by
+// commas or newlines and matched case-insensitively.
+func ConfigFromSettings(s map[string]string) Config {
+	cfg := DefaultConfig
+	readFloat := func(key string, dst *float64) {
+		if v, err := strconv.ParseFloat(s[key], 64); err == nil && v >= 0 && v <= 1 {
+			*dst = v
+		}
+	}
+	readInt := func(key string, dst *int) {
+		if v, err := strconv.Atoi(s[key]); err == nil && v >= 0 {
+			*dst = v
+		}
+	}
+	readFloat(settings.KeySpamThreshold, &cfg.SpamThreshold)
+	readFloat(settings.KeySpamCleanThreshold, &cfg.CleanThreshold)
+	readInt(settings.KeySpamTrustedMinApproved, &cfg.TrustedMinApproved)
+	readInt(settings.KeySpamMaxLinks, &cfg.MaxLinks)
+	readInt(settings.KeySpamVelocityIP, &cfg.VelocityIP)
+	readInt(settings.KeySpamVelocityEmail, &cfg.VelocityEmail)
+	cfg.BlockedWords = splitList(s[settings.KeySpamBlocklistWords])
+	cfg.BlockedDomains = splitList(s[settings.KeySpamBlocklistDomains])
+	return cfg
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, f := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '\n' }) {
+		if f = strings.ToLower(strings.TrimSpace(f)); f != "" {
+			items = append(items, f)
+		}
+	}
+	return items
+}
+
+// Input is a comment about to be inserted.
+type Input struct {
+	PostID     string
+	UserID     string // empty for guests
+	Content    string
+	GuestEmail string
+	IPHash     string
+	Edit       bool // re-scoring an edited comment
+}
+
+// Signal is the score one check gave a comment.
+type Signal struct {
+	Name   string  `json:"name"`
+	Score  float64 `json:"score"`
+	Detail string  `json:"detail,omitempty"`
+}
+
+// Verdict is the combined result of all checks.
+type Verdict struct {
+	Score   float64                `json:"score"`
+	Signals []Signal               `json:"signals"`
+	Trusted bool                   `json:"trusted"`
+	Status  comments.CommentStatus `json:"status"`
+}
+
+// Combine merges signal scores as independent evidence (noisy-OR): one
+// strong signal is enough, several weak ones add up, and the result stays
+// below 1 unless a signal is certain.
+func Combine(signals []Signal) float64 {
+	clean := 1.0
+	for _, s := range signals {
+		clean *= 1 - math.Max(0, math.Min(1, s.Score))
+	}
+	return 1 - clean
+}
+
+// Status maps a score to the status a new comment gets.
+func (c Config) Status(score float64, trusted bool) comments.CommentStatus {
+	switch {
+	case score >= c.SpamThreshold:
+		return comments.StatusSpam
+	case trusted && score < c.CleanThreshold:
+		return comments.StatusApproved
+	default:
+		return comments.StatusPending
+	}
+}
+
+// EditStatus returns the status of an edited comment that had status
+// current. Edits never lift a comment out of moderation, but approved
+// content that now scores as spam, or no longer counts as clean, goes back
+// to SPAM or PENDING.
+func (v Verdict) EditStatus(current comments.CommentStatus) comments.CommentStatus {
+	switch {
+	case current == comments.StatusSpam || current == comments.StatusRejected:
+		return current
+	case v.Status == comments.StatusSpam:
+		return comments.StatusSpam
+	case current == comments.StatusApproved && v.Status == comments.StatusPending:
+		return comments.StatusPending
+	}
+	return current
+}
+
+// ContentHash identifies content regardless of case and whitespace.
+func ContentHash(content string) string {
+	h := sha256.Sum256([]byte(strings.Join(strings.Fields(strings.ToLower(content)), " ")))
+	return hex.EncodeToString(h[:])
+}
+
+var linkPattern = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"'()]+`)
+
+// Links returns the URLs in content.
+func Links(content string) []string {
+	return linkPattern.FindAllString(content, -1)
+}
+
+// Domain returns the lowercased host of a link, without "www.".
+func Domain(link string) string {
+	host := strings.ToLower(link)
+	if i := strings.Index(host, "://"); i >= 0 {
+		host = host[i+3:]
+	}
+	if i := strings.IndexAny(host, "/?#:"); i >= 0 {
+		host = host[:i]
+	}
+	return strings.TrimPrefix(host, "www.")
+}
+
+// maxTokens bounds the tokens taken from one comment.
+const maxTokens = 200
+
+// Tokenize returns the distinct words of content (3 to 30 letters or
+// digits, lowercased) and a "domain:<host>" token per linked domain.
+func Tokenize(content string) []string {
+	seen := make(map[string]bool)
+	var tokens []string
+	add := func(t string) {
+		if !seen[t] && len(tokens) < maxTokens {
+			seen[t] = true
+			tokens = append(tokens, t)
+		}
+	}
+	for _, link := range Links(content) {
+		if d := Domain(link); d != "" {
+			add("domain:" + d)
+		}
+	}
+	for _, w := range words(content) {
+		if n := len([]rune(w)); n >= 3 && n <= 30 {
+			add(w)
+		}
+	}
+	return tokens
+}
+
+// words splits content into lowercased runs of letters and digits.
+func words(content string) []string {
+	return strings.FieldsFunc(strings.ToLower(content), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
@@ -0,0 +1,125 @@
+package spam
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/rapidtest/netpulse-api/internal/domain/comments"
+	"github.com/rapidtest/netpulse-api/internal/domain/settings"
+)
+
+func TestCombine(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores []float64
+		want   float64
+	}{
+		{"none", nil, 0},
+		{"one", []float64{0.6}, 0.6},
+		{"two weak", []float64{0.5, 0.5}, 0.75},
+		{"three", []float64{0.2, 0.5, 0.5}, 0.8},
+		{"certain", []float64{0.1, 1}, 1},
+		{"clamped", []float64{-1, 2}, 1},
+		{"negative ignored", []float64{-0.5, 0.5}, 0.5},
+	}
+	for _, tt := range tests {
+		var signals []Signal
+		for _, s := range tt.scores {
+			signals = append(signals, Signal{Score: s})
+		}
+		if got := Combine(signals); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("%s: Combine(%v) = %v, want %v", tt.name, tt.scores, got, tt.want)
+		}
+	}
+}
+
+func TestConfigStatus(t *testing.T) {
+	cfg := DefaultConfig // spam at 0.9, clean below 0.3
+	tests := []struct {
+		score   float64
+		trusted bool
+		want    comments.CommentStatus
+	}{
+		{0, false, comments.StatusPending},
+		{0, true, comments.StatusApproved},
+		{0.29, true, comments.StatusApproved},
+		{0.3, true, comments.StatusPending},
+		{0.89, true, comments.StatusPending},
+		{0.9, false, comments.StatusSpam},
+		{0.9, true, comments.StatusSpam},
+		{1, true, comments.StatusSpam},
+	}
+	for _, tt := range tests {
+		if got := cfg.Status(tt.score, tt.trusted); got != tt.want {
+			t.Errorf("Status(%v, trusted=%v) = %s, want %s", tt.score, tt.trusted, got, tt.want)
+		}
+	}
+}
+
+func TestVerdictEditStatus(t *testing.T) {
+	tests := []struct {
+		current, verdict, want comments.CommentStatus
+	}{
+		// Clean edits keep the status; they never approve.
+		{comments.StatusApproved, comments.StatusApproved, comments.StatusApproved},
+		{comments.StatusPending, comments.StatusApproved, comments.StatusPending},
+		{comments.StatusSpam, comments.StatusApproved, comments.StatusSpam},
+		{comments.StatusRejected, comments.StatusApproved, comments.StatusRejected},
+		// Approved content that is no longer clean goes back to moderation.
+		{comments.StatusApproved, comments.StatusPending, comments.StatusPending},
+		{comments.StatusPending, comments.StatusPending, comments.StatusPending},
+		// Spam is filed away unless a moderator already rejected it.
+		{comments.StatusApproved, comments.StatusSpam, comments.StatusSpam},
+		{comments.StatusPending, comments.StatusSpam, comments.StatusSpam},
+		{comments.StatusRejected, comments.StatusSpam, comments.StatusRejected},
+	}
+	for _, tt := range tests {
+		v := Verdict{Status: tt.verdict}
+		if got := v.EditStatus(tt.current); got != tt.want {
+			t.Errorf("%s edited to %s content: got %s, want %s", tt.current, tt.verdict, got, tt.want)
+		}
+	}
+}
+
+func TestConfigFromSettings(t *testing.T) {
+	if got := ConfigFromSettings(nil); !reflect.DeepEqual(got, DefaultConfig) {
+		t.Errorf("ConfigFromSettings(nil) = %+v, want DefaultConfig", got)
+	}
+
+	got := ConfigFromSettings(map[string]string{
+		settings.KeySpamThreshold:          "0.8",
+		settings.KeySpamCleanThreshold:     "0.2",
+		settings.KeySpamTrustedMinApproved: "5",
+		settings.KeySpamMaxLinks:           "0",
+		settings.KeySpamVelocityIP:         "0",
+		settings.KeySpamVelocityEmail:      "7",
+		settings.KeySpamBlocklistWords:     " Cheap Pills ,casino\n\n,",
+		settings.KeySpamBlocklistDomains:   "Spam.example\nbad.test",
+	})
+	want := Config{
+		SpamThreshold:      0.8,
+		CleanThreshold:     0.2,
+		TrustedMinApproved: 5,
+		MaxLinks:           0,
+		VelocityIP:         0,
+		VelocityEmail:      7,
+		BlockedWords:       []string{"cheap pills", "casino"},
+		BlockedDomains:     []string{"spam.example", "bad.test"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ConfigFromSettings = %+v, want %+v", got, want)
+	}
+
+	// Invalid or out-of-range values fall back to the defaults.
+	got = ConfigFromSettings(map[string]string{
+		settings.KeySpamThreshold:          "1.5",
+		settings.KeySpamCleanThreshold:     "-0.1",
+		settings.KeySpamTrustedMinApproved: "three",
+		settings.KeySpamMaxLinks:           "-1",
+		settings.KeySpamVelocityIP:         "",
+	})
+	if !reflect.DeepEqual(got, DefaultConfig) {
+		t.Errorf("ConfigFromSettings(invalid) = %+v, want DefaultConfig", got)
+	}
+}
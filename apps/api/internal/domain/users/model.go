@@ -1,11 +1,17 @@
 package users
 
-import "time"
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
 
 type User struct {
 	ID               string     `json:"id"`
 	Email            string     `json:"email"`
 	Name             string     `json:"name"`
+	Username         string     `json:"username,omitempty"`
 	PasswordHash     string     `json:"-"`
 	Avatar           string     `json:"avatar,omitempty"`
 	Bio              string     `json:"bio,omitempty"`
@@ -52,6 +58,18 @@ func (u *User) PrimaryRole() string {
 	return best.Name
 }
 
+// ErrUsernameTaken is returned when another user already has the username.
+var ErrUsernameTaken = errors.New("username is already taken")
+
+var usernameRe = regexp.MustCompile(`^[a-z0-9_]{3,30}$`)
+
+// NormalizeUsername lowercases a username and trims a leading '@'. It
+// returns false when the result is not 3–30 letters, digits or '_'.
+func NormalizeUsername(s string) (string, bool) {
+	s = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(s), "@"))
+	return s, usernameRe.MatchString(s)
+}
+
 type Role struct {
 	ID          string       `json:"id"`
 	Name        string       `json:"name"`
@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -80,6 +81,20 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Username is optional; a neutral default is generated without one
+	if input.Username != "" {
+		username, ok := users.NormalizeUsername(input.Username)
+		if !ok {
+			utils.JSONError(w, http.StatusBadRequest, "username must be 3-30 letters, digits or underscores")
+			return
+		}
+		if taken, _ := h.usersRepo.UsernameTaken(r.Context(), username, ""); taken {
+			utils.JSONError(w, http.StatusConflict, "username is already taken")
+			return
+		}
+		input.Username = username
+	}
+
 	// Hash password
 	passwordHash, err := security.HashPassword(input.Password)
 	if err != nil {
@@ -111,13 +126,17 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	userID := utils.NewID()
 
 	userObj := &users.User{
-		ID: userID, Email: input.Email, Name: input.Name,
+		ID: userID, Email: input.Email, Name: input.Name, Username: input.Username,
 		PasswordHash: passwordHash, IsActive: true,
 		ReferralCode: referralCode, ReferredBy: referredBy,
 		CreatedAt: now, UpdatedAt: now,
 	}
 
 	err = h.usersRepo.Create(r.Context(), userObj)
+	if errors.Is(err, users.ErrUsernameTaken) {
+		utils.JSONError(w, http.StatusConflict, "username is already taken")
+		return
+	}
 	if err != nil {
 		log.Error().Err(err).Msg("failed to create user")
 		utils.JSONError(w, http.StatusInternalServerError, "failed to create account")
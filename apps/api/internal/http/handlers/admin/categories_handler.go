@@ -0,0 +1,176 @@
+package admin
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rapidtest/netpulse-api/internal/domain/posts"
+	"github.com/rapidtest/netpulse-api/internal/http/middleware"
+	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
+	redisRepo "github.com/rapidtest/netpulse-api/internal/repository/redis"
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+type CategoriesHandler struct {
+	repo      *postgres.CategoriesRepo
+	auditRepo *postgres.AuditRepo
+	cache     *redisRepo.Cache
+}
+
+func NewCategoriesHandler(repo *postgres.CategoriesRepo, auditRepo *postgres.AuditRepo, cache *redisRepo.Cache) *CategoriesHandler {
+	return &CategoriesHandler{repo: repo, auditRepo: auditRepo, cache: cache}
+}
+
+// List returns all categories with post counts (all statuses), flat or as a tree with ?tree=true.
+func (h *CategoriesHandler) List(w http.ResponseWriter, r *http.Request) {
+	items, err := h.repo.FindAll(r.Context(), false)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to list categories")
+		return
+	}
+	if utils.QueryString(r, "tree", "") == "true" {
+		items = posts.CategoryTree(items)
+	}
+	if items == nil {
+		items = make([]posts.Category, 0)
+	}
+	utils.JSONResponse(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// GetByID returns a single category.
+func (h *CategoriesHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	c, err := h.repo.FindByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "category not found")
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, c)
+}
+
+// Create adds a category, optionally under a parent.
+func (h *CategoriesHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input posts.CreateCategoryInput
+	if err := utils.DecodeJSON(r, &input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" || len(input.Name) > 100 {
+		utils.JSONError(w, http.StatusBadRequest, "name is required (max 100 chars)")
+		return
+	}
+
+	slug := utils.Slugify(input.Slug)
+	if slug == "" {
+		slug = utils.Slugify(input.Name)
+	}
+	if taken, _ := h.repo.Conflicts(r.Context(), input.Name, slug, ""); taken {
+		utils.JSONError(w, http.StatusConflict, "category name or slug already in use: "+slug)
+		return
+	}
+
+	c := &posts.Category{Name: input.Name, Slug: slug, Description: input.Description}
+	if input.ParentID != nil && *input.ParentID != "" {
+		if _, err := h.repo.FindByID(r.Context(), *input.ParentID); err != nil {
+			utils.JSONError(w, http.StatusBadRequest, "parent category not found")
+			return
+		}
+		c.ParentID = input.ParentID
+	}
+
+	if err := h.repo.Create(r.Context(), c); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to create category")
+		return
+	}
+
+	userID := middleware.GetUserID(r)
+	_ = h.auditRepo.Log(r.Context(), userID, "create", "category", c.ID, c.Name, r.RemoteAddr)
+
+	utils.JSONResponse(w, http.StatusCreated, c)
+}
+
+// Update renames, re-slugs or moves a category. A category cannot be moved
+// under itself or one of its descendants.
+func (h *CategoriesHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	c, err := h.repo.FindByID(r.Context(), id)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "category not found")
+		return
+	}
+
+	var input posts.UpdateCategoryInput
+	if err := utils.DecodeJSON(r, &input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" || len(name) > 100 {
+			utils.JSONError(w, http.StatusBadRequest, "name is required (max 100 chars)")
+			return
+		}
+		c.Name = name
+	}
+	if input.Description != nil {
+		c.Description = *input.Description
+	}
+	if input.Slug != nil {
+		slug := utils.Slugify(*input.Slug)
+		if slug == "" {
+			utils.JSONError(w, http.StatusBadRequest, "invalid slug")
+			return
+		}
+		c.Slug = slug
+	}
+	if taken, _ := h.repo.Conflicts(r.Context(), c.Name, c.Slug, id); taken {
+		utils.JSONError(w, http.StatusConflict, "category name or slug already in use: "+c.Slug)
+		return
+	}
+	if input.ParentID != nil {
+		if *input.ParentID == "" {
+			c.ParentID = nil
+		} else {
+			if _, err := h.repo.FindByID(r.Context(), *input.ParentID); err != nil {
+				utils.JSONError(w, http.StatusBadRequest, "parent category not found")
+				return
+			}
+			if cycle, err := h.repo.IsDescendant(r.Context(), id, *input.ParentID); err != nil || cycle {
+				utils.JSONError(w, http.StatusBadRequest, "a category cannot be nested under itself or its subcategories")
+				return
+			}
+			c.ParentID = input.ParentID
+		}
+	}
+
+	if err := h.repo.Update(r.Context(), c); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to update category")
+		return
+	}
+	_ = h.cache.DeleteByPrefix(r.Context(), "posts:")
+
+	userID := middleware.GetUserID(r)
+	_ = h.auditRepo.Log(r.Context(), userID, "update", "category", id, "", r.RemoteAddr)
+
+	utils.JSONResponse(w, http.StatusOK, c)
+}
+
+// Delete removes a category. Subcategories move up a level; posts become uncategorized.
+func (h *CategoriesHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.repo.Delete(r.Context(), id); err != nil {
+		utils.JSONError(w, http.StatusNotFound, "category not found")
+		return
+	}
+	_ = h.cache.DeleteByPrefix(r.Context(), "posts:")
+
+	userID := middleware.GetUserID(r)
+	_ = h.auditRepo.Log(r.Context(), userID, "delete", "category", id, "", r.RemoteAddr)
+
+	utils.JSONResponse(w, http.StatusOK, map[string]string{"message": "deleted"})
+}
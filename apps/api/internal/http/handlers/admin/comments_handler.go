@@ -5,19 +5,24 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/rapidtest/netpulse-api/internal/domain/comments"
+	"github.com/rapidtest/netpulse-api/internal/domain/notifications"
+	"github.com/rapidtest/netpulse-api/internal/domain/spam"
 	"github.com/rapidtest/netpulse-api/internal/http/middleware"
 	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
 	"github.com/rapidtest/netpulse-api/internal/utils"
+	"github.com/rs/zerolog/log"
 )
 
 type CommentsHandler struct {
 	commentsRepo   *postgres.CommentsRepo
 	engagementRepo *postgres.EngagementRepo
+	spamRepo       *postgres.SpamRepo
+	notifications  *postgres.NotificationsRepo
 	auditRepo      *postgres.AuditRepo
 }
 
-func NewCommentsHandler(commentsRepo *postgres.CommentsRepo, engagementRepo *postgres.EngagementRepo, auditRepo *postgres.AuditRepo) *CommentsHandler {
-	return &CommentsHandler{commentsRepo: commentsRepo, engagementRepo: engagementRepo, auditRepo: auditRepo}
+func NewCommentsHandler(commentsRepo *postgres.CommentsRepo, engagementRepo *postgres.EngagementRepo, spamRepo *postgres.SpamRepo, notificationsRepo *postgres.NotificationsRepo, auditRepo *postgres.AuditRepo) *CommentsHandler {
+	return &CommentsHandler{commentsRepo: commentsRepo, engagementRepo: engagementRepo, spamRepo: spamRepo, notifications: notificationsRepo, auditRepo: auditRepo}
 }
 
 // List handles GET /admin/comments
@@ -36,7 +41,17 @@ func (h *CommentsHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	utils.JSONResponse(w, http.StatusOK, result)
+	items := make([]comments.AdminComment, len(result.Items))
+	for i, c := range result.Items {
+		items[i] = comments.AdminComment{Comment: c, SpamScore: c.SpamScore}
+	}
+	utils.JSONResponse(w, http.StatusOK, map[string]interface{}{
+		"items":       items,
+		"total":       result.Total,
+		"page":        result.Page,
+		"limit":       result.Limit,
+		"total_pages": result.TotalPages,
+	})
 }
 
 // Moderate handles PATCH /admin/comments/{id}
@@ -74,6 +89,13 @@ func (h *CommentsHandler) Moderate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	h.learn(r, original, input.Status)
+	if input.Status == comments.StatusApproved {
+		approved := *original
+		approved.Status = input.Status
+		h.notify(r, &approved)
+	}
+
 	// Update comment count
 	if original.Status == comments.StatusApproved && input.Status != comments.StatusApproved {
 		h.engagementRepo.IncrementComments(r.Context(), original.PostID, -1)
@@ -89,6 +111,17 @@ func (h *CommentsHandler) Moderate(w http.ResponseWriter, r *http.Request) {
 	utils.JSONResponse(w, http.StatusOK, map[string]string{"message": "comment updated"})
 }
 
+// Edits handles GET /admin/comments/{id}/edits
+func (h *CommentsHandler) Edits(w http.ResponseWriter, r *http.Request) {
+	items, err := h.commentsRepo.ListEdits(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to load comment edits")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
 // BulkModerate handles POST /admin/comments/bulk
 func (h *CommentsHandler) BulkModerate(w http.ResponseWriter, r *http.Request) {
 	var input comments.BulkModerateInput
@@ -102,12 +135,26 @@ func (h *CommentsHandler) BulkModerate(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	switch input.Status {
+	case comments.StatusApproved, comments.StatusRejected, comments.StatusSpam, comments.StatusPending:
+	default:
+		utils.JSONError(w, http.StatusBadRequest, "invalid status")
+		return
+	}
+
 	err := h.commentsRepo.BulkUpdateStatus(r.Context(), input.IDs, input.Status)
 	if err != nil {
 		utils.JSONError(w, http.StatusInternalServerError, "failed to update comments")
 		return
 	}
 
+	for _, id := range input.IDs {
+		if c, err := h.commentsRepo.FindByID(r.Context(), id); err == nil {
+			h.learn(r, c, input.Status)
+			h.notify(r, c)
+		}
+	}
+
 	userID := middleware.GetUserID(r)
 	ip := middleware.ExtractIP(r)
 	h.auditRepo.Log(r.Context(), userID, "comment.bulk_moderate", "comment", "",
@@ -116,6 +163,22 @@ func (h *CommentsHandler) BulkModerate(w http.ResponseWriter, r *http.Request) {
 	utils.JSONResponse(w, http.StatusOK, map[string]string{"message": "comments updated"})
 }
 
+// learn trains the spam filter with a moderation decision. Training is
+// best effort and never fails the request.
+func (h *CommentsHandler) learn(r *http.Request, c *comments.Comment, status comments.CommentStatus) {
+	if err := spam.Learn(r.Context(), h.spamRepo, c.ID, c.Content, status); err != nil {
+		log.Warn().Err(err).Str("comment_id", c.ID).Msg("failed to train spam filter")
+	}
+}
+
+// notify sends the notifications of a comment once it is approved.
+// Approving again sends nothing new.
+func (h *CommentsHandler) notify(r *http.Request, c *comments.Comment) {
+	if err := notifications.NotifyComment(r.Context(), h.notifications, c); err != nil {
+		log.Warn().Err(err).Str("comment_id", c.ID).Msg("failed to send comment notifications")
+	}
+}
+
 // Delete handles DELETE /admin/comments/{id}
 func (h *CommentsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	commentID := chi.URLParam(r, "id")
@@ -0,0 +1,61 @@
+package admin
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/rapidtest/netpulse-api/internal/domain/export"
+	"github.com/rapidtest/netpulse-api/internal/http/middleware"
+	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+type ExportHandler struct {
+	exporter  *export.Exporter
+	auditRepo *postgres.AuditRepo
+}
+
+func NewExportHandler(exporter *export.Exporter, auditRepo *postgres.AuditRepo) *ExportHandler {
+	return &ExportHandler{exporter: exporter, auditRepo: auditRepo}
+}
+
+// Export streams a zip archive of posts, comments, revisions, taxonomy and
+// referenced uploads. Optional filters: author_id, category, from, to.
+func (h *ExportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	filter := export.Filter{
+		AuthorID:     utils.QueryString(r, "author_id", ""),
+		CategorySlug: utils.QueryString(r, "category", ""),
+	}
+	if err := filter.SetRange(utils.QueryString(r, "from", ""), utils.QueryString(r, "to", "")); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	archive, err := h.exporter.Load(r.Context(), filter)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to export content")
+		return
+	}
+
+	userID := middleware.GetUserID(r)
+	m, err := writeArchive(w, archive)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("export interrupted")
+		return
+	}
+	_ = h.auditRepo.Log(r.Context(), userID, "export", "post", "",
+		fmt.Sprintf("%d posts, %d comments, %d files", m.Posts, m.Comments, m.Files), r.RemoteAddr)
+}
+
+// writeArchive sends archive as a zip download. Archives can take longer than
+// the server's write timeout, so the deadline is lifted for this response.
+func writeArchive(w http.ResponseWriter, archive *export.Archive) (*export.Manifest, error) {
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+export.Filename(archive.GeneratedAt())+`"`)
+	w.WriteHeader(http.StatusOK)
+	return archive.Write(w)
+}
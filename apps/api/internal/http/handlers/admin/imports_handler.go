@@ -0,0 +1,114 @@
+package admin
+
+import (
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rapidtest/netpulse-api/internal/domain/imports"
+	"github.com/rapidtest/netpulse-api/internal/http/middleware"
+	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+// maxImportSize caps an uploaded WXR file or Markdown zip.
+const maxImportSize = 100 << 20
+
+type ImportsHandler struct {
+	svc       *imports.Service
+	usersRepo *postgres.UsersRepo
+	auditRepo *postgres.AuditRepo
+}
+
+func NewImportsHandler(svc *imports.Service, usersRepo *postgres.UsersRepo, auditRepo *postgres.AuditRepo) *ImportsHandler {
+	return &ImportsHandler{svc: svc, usersRepo: usersRepo, auditRepo: auditRepo}
+}
+
+// List returns recent import jobs.
+func (h *ImportsHandler) List(w http.ResponseWriter, r *http.Request) {
+	items, err := h.svc.ListJobs(r.Context())
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to list imports")
+		return
+	}
+	if items == nil {
+		items = make([]imports.Job, 0)
+	}
+	utils.JSONResponse(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// GetByID returns an import job with progress and per-item results.
+func (h *ImportsHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	job, err := h.svc.GetJob(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "import job not found")
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, job)
+}
+
+// Create starts an import from a multipart upload: "file" (a WXR .xml or a
+// .zip of Markdown files), optional "format", "dry_run" and "author_id".
+func (h *ImportsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxImportSize)
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "file too large (max 100MB)")
+		return
+	}
+
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "file required")
+		return
+	}
+	defer file.Close()
+
+	format := imports.Format(strings.ToLower(r.FormValue("format")))
+	if format == "" {
+		switch strings.ToLower(filepath.Ext(header.Filename)) {
+		case ".xml":
+			format = imports.FormatWXR
+		case ".zip":
+			format = imports.FormatMarkdown
+		}
+	}
+	if format != imports.FormatWXR && format != imports.FormatMarkdown {
+		utils.JSONError(w, http.StatusBadRequest, "format must be wxr (.xml) or markdown (.zip)")
+		return
+	}
+
+	userID := middleware.GetUserID(r)
+	authorID := r.FormValue("author_id")
+	if authorID == "" {
+		authorID = userID
+	} else if _, err := h.usersRepo.FindByID(r.Context(), authorID); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "author not found")
+		return
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "failed to read file")
+		return
+	}
+
+	job, err := h.svc.Start(r.Context(), format, header.Filename, data, imports.Options{
+		DryRun:    r.FormValue("dry_run") == "true",
+		AuthorID:  authorID,
+		CreatedBy: userID,
+	})
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	details := header.Filename
+	if job.DryRun {
+		details += " (dry run)"
+	}
+	_ = h.auditRepo.Log(r.Context(), userID, "import", "post", job.ID, details, r.RemoteAddr)
+
+	utils.JSONResponse(w, http.StatusAccepted, job)
+}
@@ -1,6 +1,8 @@
 package admin
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -73,56 +75,65 @@ func (h *MediaHandler) Upload(w http.ResponseWriter, r *http.Request) {
 	}
 	defer file.Close()
 
-	// Validate mime type
-	ext := strings.ToLower(filepath.Ext(header.Filename))
-	allowedExts := map[string]string{
-		".jpg": "image/jpeg", ".jpeg": "image/jpeg", ".png": "image/png",
-		".gif": "image/gif", ".svg": "image/svg+xml", ".webp": "image/webp",
-		".mp4": "video/mp4", ".webm": "video/webm",
-		".pdf": "application/pdf",
-	}
-	mimeType, ok := allowedExts[ext]
-	if !ok {
+	userID := middleware.GetUserID(r)
+
+	item, err := h.Store(r.Context(), header.Filename, file, userID)
+	if errors.Is(err, errMediaType) {
 		utils.JSONError(w, http.StatusBadRequest, "tipe file tidak diizinkan")
 		return
 	}
+	if err != nil {
+		fmt.Printf("[MEDIA] failed to store media: userID=%s, file=%s, err=%v\n", userID, header.Filename, err)
+		utils.JSONError(w, http.StatusInternalServerError, fmt.Sprintf("failed to store media: %v", err))
+		return
+	}
+
+	_ = h.auditRepo.Log(r.Context(), userID, "upload", "media", item.ID, header.Filename, r.RemoteAddr)
+
+	utils.JSONResponse(w, http.StatusCreated, item)
+}
+
+var errMediaType = errors.New("unsupported media type")
+
+// mediaTypes maps allowed upload extensions to their MIME types.
+var mediaTypes = map[string]string{
+	".jpg": "image/jpeg", ".jpeg": "image/jpeg", ".png": "image/png",
+	".gif": "image/gif", ".svg": "image/svg+xml", ".webp": "image/webp",
+	".mp4": "video/mp4", ".webm": "video/webm",
+	".pdf": "application/pdf",
+}
+
+// Store writes src to the uploads directory (by year/month) and records it
+// in the media library. originalName decides the file type.
+func (h *MediaHandler) Store(ctx context.Context, originalName string, src io.Reader, uploadedBy string) (*postgres.MediaItem, error) {
+	ext := strings.ToLower(filepath.Ext(originalName))
+	mimeType, ok := mediaTypes[ext]
+	if !ok {
+		return nil, errMediaType
+	}
 
 	// Generate unique filename
 	ts := time.Now().Format("20060102-150405")
-	safeName := strings.ReplaceAll(strings.TrimSuffix(header.Filename, ext), " ", "-")
-	filename := fmt.Sprintf("%s-%s%s", ts, safeName, ext)
+	safeName := strings.ReplaceAll(strings.TrimSuffix(filepath.Base(originalName), filepath.Ext(originalName)), " ", "-")
+	filename := fmt.Sprintf("%s-%s-%s%s", ts, utils.NewID()[:6], safeName, ext)
 
-	// Create uploads directory (by year/month)
 	subDir := time.Now().Format("2006/01")
 	fullDir := filepath.Join(h.uploadDir, subDir)
 	_ = os.MkdirAll(fullDir, 0o755)
 
 	dst, err := os.Create(filepath.Join(fullDir, filename))
 	if err != nil {
-		utils.JSONError(w, http.StatusInternalServerError, "failed to save file")
-		return
+		return nil, err
 	}
 	defer dst.Close()
 
-	written, err := io.Copy(dst, file)
+	written, err := io.Copy(dst, src)
 	if err != nil {
-		utils.JSONError(w, http.StatusInternalServerError, "failed to write file")
-		return
+		return nil, err
 	}
 
 	url := fmt.Sprintf("%s/uploads/%s/%s", h.baseURL, subDir, filename)
-	userID := middleware.GetUserID(r)
-
-	item, err := h.mediaRepo.Create(r.Context(), header.Filename, url, mimeType, written, userID)
-	if err != nil {
-		fmt.Printf("[MEDIA] failed to record media: userID=%s, file=%s, err=%v\n", userID, header.Filename, err)
-		utils.JSONError(w, http.StatusInternalServerError, fmt.Sprintf("failed to record media: %v", err))
-		return
-	}
-
-	_ = h.auditRepo.Log(r.Context(), userID, "upload", "media", item.ID, header.Filename, r.RemoteAddr)
-
-	utils.JSONResponse(w, http.StatusCreated, item)
+	return h.mediaRepo.Create(ctx, filepath.Base(originalName), url, mimeType, written, uploadedBy)
 }
 
 // Delete removes a media item and its file.
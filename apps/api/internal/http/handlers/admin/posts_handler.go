@@ -1,23 +1,31 @@
 package admin
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/rapidtest/netpulse-api/internal/domain/posts"
 	"github.com/rapidtest/netpulse-api/internal/http/middleware"
 	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
+	redisRepo "github.com/rapidtest/netpulse-api/internal/repository/redis"
+	"github.com/rapidtest/netpulse-api/internal/security"
 	"github.com/rapidtest/netpulse-api/internal/utils"
 )
 
 type PostsHandler struct {
 	svc       *posts.Service
 	auditRepo *postgres.AuditRepo
+	usersRepo *postgres.UsersRepo
+	previews  *security.PreviewSigner
+	locks     *redisRepo.EditLocks
 }
 
-func NewPostsHandler(svc *posts.Service, auditRepo *postgres.AuditRepo) *PostsHandler {
-	return &PostsHandler{svc: svc, auditRepo: auditRepo}
+func NewPostsHandler(svc *posts.Service, auditRepo *postgres.AuditRepo, usersRepo *postgres.UsersRepo,
+	previews *security.PreviewSigner, locks *redisRepo.EditLocks) *PostsHandler {
+	return &PostsHandler{svc: svc, auditRepo: auditRepo, usersRepo: usersRepo, previews: previews, locks: locks}
 }
 
 // List returns all posts (admin view, all statuses).
@@ -47,6 +55,7 @@ func (h *PostsHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		utils.JSONError(w, http.StatusNotFound, "post not found")
 		return
 	}
+	utils.SetVersionETag(w, post.Version)
 	utils.JSONResponse(w, http.StatusOK, post)
 }
 
@@ -61,6 +70,14 @@ func (h *PostsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	authorID, _ := r.Context().Value(middleware.CtxUserID).(string)
 
 	post, err := h.svc.Create(r.Context(), input, authorID)
+	if errors.Is(err, posts.ErrUnsupportedLanguage) {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, posts.ErrSlugConflict) {
+		utils.JSONError(w, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		utils.JSONError(w, http.StatusInternalServerError, "failed to create post")
 		return
@@ -80,16 +97,43 @@ func (h *PostsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
+	if input.Version == nil {
+		input.Version = utils.IfMatchVersion(r)
+	}
+	if input.Version == nil {
+		utils.JSONError(w, http.StatusPreconditionRequired, "version is required (send \"version\" or an If-Match header)")
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
 
-	post, err := h.svc.Update(r.Context(), id, input)
+	post, err := h.svc.Update(r.Context(), id, input, userID)
+	var stale *posts.VersionConflictError
+	if errors.As(err, &stale) {
+		utils.SetVersionETag(w, stale.Current.Version)
+		utils.JSONResponse(w, http.StatusConflict, map[string]interface{}{
+			"error":   err.Error(),
+			"version": stale.Current.Version,
+			"current": stale.Current,
+		})
+		return
+	}
+	if errors.Is(err, posts.ErrUnsupportedLanguage) {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, posts.ErrSlugConflict) {
+		utils.JSONError(w, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		utils.JSONError(w, http.StatusInternalServerError, "failed to update post")
 		return
 	}
 
-	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
 	_ = h.auditRepo.Log(r.Context(), userID, "update", "post", id, "", r.RemoteAddr)
 
+	utils.SetVersionETag(w, post.Version)
 	utils.JSONResponse(w, http.StatusOK, post)
 }
 
@@ -138,6 +182,29 @@ func (h *PostsHandler) Publish(w http.ResponseWriter, r *http.Request) {
 	utils.JSONResponse(w, http.StatusOK, map[string]string{"message": "published"})
 }
 
+// SetAuthors replaces the byline of a post: an ordered list of co-authors
+// and contributors with their roles.
+func (h *PostsHandler) SetAuthors(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var input posts.SetContributorsInput
+	if err := utils.DecodeJSON(r, &input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	post, err := h.svc.SetContributors(r.Context(), id, input)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+	_ = h.auditRepo.Log(r.Context(), userID, "set_authors", "post", id, "", r.RemoteAddr)
+
+	utils.JSONResponse(w, http.StatusOK, post)
+}
+
 // Schedule sets a post to publish at a future time.
 func (h *PostsHandler) Schedule(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
@@ -160,3 +227,317 @@ func (h *PostsHandler) Schedule(w http.ResponseWriter, r *http.Request) {
 
 	utils.JSONResponse(w, http.StatusOK, map[string]string{"message": "scheduled"})
 }
+
+// SetExpiry sets when a post is archived automatically
+// ({ "expires_at": "..." }); null clears it.
+func (h *PostsHandler) SetExpiry(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var body struct {
+		ExpiresAt *time.Time `json:"expires_at"`
+	}
+	if err := utils.DecodeJSON(r, &body); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	post, err := h.svc.SetExpiry(r.Context(), id, body.ExpiresAt)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	details := "cleared"
+	if body.ExpiresAt != nil {
+		details = body.ExpiresAt.UTC().Format(time.RFC3339)
+	}
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+	_ = h.auditRepo.Log(r.Context(), userID, "set_expiry", "post", id, details, r.RemoteAddr)
+
+	utils.SetVersionETag(w, post.Version)
+	utils.JSONResponse(w, http.StatusOK, post)
+}
+
+// ListRevisions returns the revision history of a post.
+func (h *PostsHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if _, err := h.svc.GetByID(r.Context(), id); err != nil {
+		utils.JSONError(w, http.StatusNotFound, "post not found")
+		return
+	}
+
+	items, err := h.svc.ListRevisions(r.Context(), id)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to list revisions")
+		return
+	}
+	if items == nil {
+		items = make([]posts.Revision, 0)
+	}
+
+	utils.JSONResponse(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// DiffRevisions handles GET /admin/posts/{id}/revisions/diff?from=..&to=..
+func (h *PostsHandler) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	from, errFrom := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+	to, errTo := strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+	if errFrom != nil || errTo != nil {
+		utils.JSONError(w, http.StatusBadRequest, "query parameters 'from' and 'to' must be revision IDs")
+		return
+	}
+
+	diff, err := h.svc.DiffRevisions(r.Context(), id, from, to)
+	if errors.Is(err, posts.ErrRevisionNotFound) {
+		utils.JSONError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to compare revisions")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, diff)
+}
+
+// RestoreRevision copies an older revision back onto the post.
+func (h *PostsHandler) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	revID, err := strconv.ParseInt(chi.URLParam(r, "revisionId"), 10, 64)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid revision ID")
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+
+	post, err := h.svc.RestoreRevision(r.Context(), id, revID, userID)
+	if errors.Is(err, posts.ErrRevisionNotFound) {
+		utils.JSONError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if errors.Is(err, posts.ErrSlugConflict) {
+		utils.JSONError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_ = h.auditRepo.Log(r.Context(), userID, "restore_revision", "post", id, "revision "+strconv.FormatInt(revID, 10), r.RemoteAddr)
+
+	utils.JSONResponse(w, http.StatusOK, post)
+}
+
+// CreatePreview handles POST /admin/posts/{id}/previews — mints a signed,
+// expiring preview link that works without an account.
+func (h *PostsHandler) CreatePreview(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var input posts.CreatePreviewInput
+	if r.ContentLength > 0 {
+		if err := utils.DecodeJSON(r, &input); err != nil {
+			utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+			return
+		}
+	}
+	if input.ExpiresInHours < 0 {
+		utils.JSONError(w, http.StatusBadRequest, "expires_in_hours must be positive")
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+
+	preview, err := h.svc.CreatePreview(r.Context(), id, userID, input)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "post not found")
+		return
+	}
+	preview.Token = h.previews.Sign(preview.ID, preview.ExpiresAt)
+
+	_ = h.auditRepo.Log(r.Context(), userID, "create_preview", "post", id, "preview "+preview.ID, r.RemoteAddr)
+
+	utils.JSONResponse(w, http.StatusCreated, preview)
+}
+
+// ListPreviews handles GET /admin/posts/{id}/previews
+func (h *PostsHandler) ListPreviews(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	items, err := h.svc.ListPreviews(r.Context(), id)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to list preview links")
+		return
+	}
+	if items == nil {
+		items = make([]posts.PreviewToken, 0)
+	}
+
+	utils.JSONResponse(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// RevokePreview handles DELETE /admin/posts/{id}/previews/{previewId}
+func (h *PostsHandler) RevokePreview(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	previewID := chi.URLParam(r, "previewId")
+
+	if err := h.svc.RevokePreview(r.Context(), id, previewID); err != nil {
+		utils.JSONError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+	_ = h.auditRepo.Log(r.Context(), userID, "revoke_preview", "post", id, "preview "+previewID, r.RemoteAddr)
+
+	utils.JSONResponse(w, http.StatusOK, map[string]string{"message": "revoked"})
+}
+
+// RequestChanges handles POST /admin/posts/{id}/request-changes — moves a post
+// from IN_REVIEW to CHANGES_REQUESTED with a summary and inline notes.
+func (h *PostsHandler) RequestChanges(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var input posts.RequestChangesInput
+	if err := utils.DecodeJSON(r, &input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+
+	notes, err := h.svc.RequestChanges(r.Context(), id, userID, input)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	_ = h.auditRepo.Log(r.Context(), userID, "request_changes", "post", id,
+		strconv.Itoa(len(input.Notes))+" inline notes", r.RemoteAddr)
+
+	utils.JSONResponse(w, http.StatusOK, map[string]interface{}{"items": notes})
+}
+
+// ListReviewNotes handles GET /admin/posts/{id}/review-notes
+func (h *PostsHandler) ListReviewNotes(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	items, err := h.svc.ListReviewNotes(r.Context(), id)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to list review notes")
+		return
+	}
+	if items == nil {
+		items = make([]posts.ReviewNote, 0)
+	}
+
+	utils.JSONResponse(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// ReplyToNote handles POST /admin/posts/{id}/review-notes/{noteId}/replies
+func (h *PostsHandler) ReplyToNote(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	noteID, err := strconv.ParseInt(chi.URLParam(r, "noteId"), 10, 64)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid note ID")
+		return
+	}
+
+	var input posts.ReplyNoteInput
+	if err := utils.DecodeJSON(r, &input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+
+	note, err := h.svc.ReplyToNote(r.Context(), id, noteID, userID, input)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, note)
+}
+
+// ResolveNote handles POST /admin/posts/{id}/review-notes/{noteId}/resolve
+func (h *PostsHandler) ResolveNote(w http.ResponseWriter, r *http.Request) {
+	h.setNoteResolved(w, r, true)
+}
+
+// ReopenNote handles POST /admin/posts/{id}/review-notes/{noteId}/reopen
+func (h *PostsHandler) ReopenNote(w http.ResponseWriter, r *http.Request) {
+	h.setNoteResolved(w, r, false)
+}
+
+func (h *PostsHandler) setNoteResolved(w http.ResponseWriter, r *http.Request, resolved bool) {
+	id := chi.URLParam(r, "id")
+	noteID, err := strconv.ParseInt(chi.URLParam(r, "noteId"), 10, 64)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid note ID")
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+
+	if err := h.svc.SetNoteResolved(r.Context(), id, noteID, userID, resolved); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	msg := "reopened"
+	if resolved {
+		msg = "resolved"
+	}
+	utils.JSONResponse(w, http.StatusOK, map[string]string{"message": msg})
+}
+
+// GetLock handles GET /admin/posts/{id}/lock — who, if anyone, is editing the post.
+func (h *PostsHandler) GetLock(w http.ResponseWriter, r *http.Request) {
+	lock, err := h.locks.Get(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to read edit lock")
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, map[string]interface{}{"locked": lock != nil, "lock": lock})
+}
+
+// AcquireLock handles POST /admin/posts/{id}/lock — takes the advisory edit
+// lock, or refreshes it when called again by the holder (heartbeat).
+func (h *PostsHandler) AcquireLock(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+
+	name := ""
+	if u, err := h.usersRepo.FindByID(r.Context(), userID); err == nil {
+		name = u.Name
+	}
+
+	lock, ok, err := h.locks.Acquire(r.Context(), id, userID, name)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to acquire edit lock")
+		return
+	}
+	if !ok {
+		utils.JSONResponse(w, http.StatusConflict, map[string]interface{}{
+			"error": "post is being edited by " + lock.UserName,
+			"lock":  lock,
+		})
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, map[string]interface{}{"locked": true, "lock": lock})
+}
+
+// ReleaseLock handles DELETE /admin/posts/{id}/lock
+func (h *PostsHandler) ReleaseLock(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+	if err := h.locks.Release(r.Context(), chi.URLParam(r, "id"), userID); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to release edit lock")
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, map[string]string{"message": "released"})
+}
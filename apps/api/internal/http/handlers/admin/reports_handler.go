@@ -0,0 +1,130 @@
+package admin
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rapidtest/netpulse-api/internal/domain/comments"
+	"github.com/rapidtest/netpulse-api/internal/domain/reports"
+	"github.com/rapidtest/netpulse-api/internal/http/middleware"
+	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
+	redisRepo "github.com/rapidtest/netpulse-api/internal/repository/redis"
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+// ReportsHandler is the moderator queue for reader reports.
+type ReportsHandler struct {
+	reportsRepo    *postgres.ReportsRepo
+	commentsRepo   *postgres.CommentsRepo
+	engagementRepo *postgres.EngagementRepo
+	cache          *redisRepo.Cache
+	auditRepo      *postgres.AuditRepo
+}
+
+func NewReportsHandler(reportsRepo *postgres.ReportsRepo, commentsRepo *postgres.CommentsRepo, engagementRepo *postgres.EngagementRepo, cache *redisRepo.Cache, auditRepo *postgres.AuditRepo) *ReportsHandler {
+	return &ReportsHandler{reportsRepo: reportsRepo, commentsRepo: commentsRepo, engagementRepo: engagementRepo, cache: cache, auditRepo: auditRepo}
+}
+
+// List handles GET /admin/comments/reports
+func (h *ReportsHandler) List(w http.ResponseWriter, r *http.Request) {
+	f := reports.QueueFilter{
+		Status:     utils.QueryString(r, "status", reports.StatusOpen),
+		TargetType: utils.QueryString(r, "type", ""),
+		Page:       utils.QueryInt(r, "page", 1),
+		Limit:      utils.QueryInt(r, "limit", 20),
+	}
+	if f.Status == "all" {
+		f.Status = ""
+	}
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	if f.Limit < 1 || f.Limit > 100 {
+		f.Limit = 20
+	}
+
+	result, err := h.reportsRepo.Queue(r.Context(), f)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to load reports")
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, result)
+}
+
+// Get handles GET /admin/comments/reports/{id}
+func (h *ReportsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	item, err := h.reportsRepo.FindItem(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "report not found")
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, item)
+}
+
+// Resolve handles POST /admin/comments/reports/{id}/resolve
+func (h *ReportsHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	var input reports.ResolveInput
+	if err := utils.DecodeJSON(r, &input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if input.Action != reports.ActionDismiss && input.Action != reports.ActionRemove {
+		utils.JSONError(w, http.StatusBadRequest, "action must be dismiss or remove")
+		return
+	}
+	input.Note = strings.TrimSpace(input.Note)
+
+	item, err := h.reportsRepo.FindItem(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "report not found")
+		return
+	}
+	if item.Status != reports.StatusOpen {
+		utils.JSONError(w, http.StatusConflict, "report already resolved")
+		return
+	}
+	// Removing a post archives it, which needs post editing rights on top
+	// of comment moderation.
+	if item.TargetType == reports.TargetPost && input.Action == reports.ActionRemove &&
+		!middleware.HasPermission(r, "posts.edit_any") {
+		utils.JSONError(w, http.StatusForbidden, "removing a post requires posts.edit_any")
+		return
+	}
+
+	// The comment's status before resolving decides the comment count change.
+	var before *comments.Comment
+	if item.TargetType == reports.TargetComment {
+		before, _ = h.commentsRepo.FindByID(r.Context(), item.TargetID)
+	}
+
+	userID := middleware.GetUserID(r)
+	if err := h.reportsRepo.Resolve(r.Context(), item, input.Action, input.Note, userID); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to resolve report")
+		return
+	}
+
+	if before != nil {
+		restored := input.Action == reports.ActionDismiss && item.HiddenAt != nil &&
+			item.PriorStatus == string(comments.StatusApproved) && before.Status == comments.StatusPending
+		removed := input.Action == reports.ActionRemove && before.Status == comments.StatusApproved
+		switch {
+		case restored:
+			h.engagementRepo.IncrementComments(r.Context(), item.PostID, 1)
+		case removed:
+			h.engagementRepo.IncrementComments(r.Context(), item.PostID, -1)
+		}
+	}
+	if item.TargetType == reports.TargetPost && (input.Action == reports.ActionRemove || item.HiddenAt != nil) {
+		_ = h.cache.DeleteByPrefix(r.Context(), "posts:")
+	}
+
+	details := input.Action
+	if input.Note != "" {
+		details += ": " + input.Note
+	}
+	h.auditRepo.Log(r.Context(), userID, "report."+input.Action, item.TargetType, item.TargetID,
+		details, middleware.ExtractIP(r))
+
+	utils.JSONResponse(w, http.StatusOK, map[string]string{"message": "report resolved"})
+}
@@ -0,0 +1,224 @@
+package admin
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rapidtest/netpulse-api/internal/domain/series"
+	"github.com/rapidtest/netpulse-api/internal/http/middleware"
+	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+type SeriesHandler struct {
+	seriesRepo *postgres.SeriesRepo
+	auditRepo  *postgres.AuditRepo
+}
+
+func NewSeriesHandler(seriesRepo *postgres.SeriesRepo, auditRepo *postgres.AuditRepo) *SeriesHandler {
+	return &SeriesHandler{seriesRepo: seriesRepo, auditRepo: auditRepo}
+}
+
+// List returns all series with post counts.
+func (h *SeriesHandler) List(w http.ResponseWriter, r *http.Request) {
+	items, err := h.seriesRepo.FindAll(r.Context(), false)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to list series")
+		return
+	}
+	if items == nil {
+		items = make([]series.Series, 0)
+	}
+	utils.JSONResponse(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// GetByID returns a series with all attached posts in order.
+func (h *SeriesHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	s, err := h.seriesRepo.FindByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "series not found")
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, s)
+}
+
+// Create adds a new series.
+func (h *SeriesHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input series.CreateSeriesInput
+	if err := utils.DecodeJSON(r, &input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	input.Title = strings.TrimSpace(input.Title)
+	if input.Title == "" || len(input.Title) > 200 {
+		utils.JSONError(w, http.StatusBadRequest, "title is required (max 200 chars)")
+		return
+	}
+
+	slug := utils.Slugify(input.Slug)
+	if slug == "" {
+		slug = utils.Slugify(input.Title)
+	}
+	if taken, _ := h.seriesRepo.SlugExists(r.Context(), slug, ""); taken {
+		utils.JSONError(w, http.StatusConflict, "slug already in use: "+slug)
+		return
+	}
+
+	s := &series.Series{Title: input.Title, Slug: slug, Description: input.Description}
+	if err := h.seriesRepo.Create(r.Context(), s); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to create series")
+		return
+	}
+
+	userID := middleware.GetUserID(r)
+	_ = h.auditRepo.Log(r.Context(), userID, "create", "series", s.ID, s.Title, r.RemoteAddr)
+
+	utils.JSONResponse(w, http.StatusCreated, s)
+}
+
+// Update modifies a series' title, slug or description.
+func (h *SeriesHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	s, err := h.seriesRepo.FindByID(r.Context(), id)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "series not found")
+		return
+	}
+
+	var input series.UpdateSeriesInput
+	if err := utils.DecodeJSON(r, &input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if input.Title != nil {
+		title := strings.TrimSpace(*input.Title)
+		if title == "" || len(title) > 200 {
+			utils.JSONError(w, http.StatusBadRequest, "title is required (max 200 chars)")
+			return
+		}
+		s.Title = title
+	}
+	if input.Description != nil {
+		s.Description = *input.Description
+	}
+	if input.Slug != nil {
+		slug := utils.Slugify(*input.Slug)
+		if slug == "" {
+			utils.JSONError(w, http.StatusBadRequest, "invalid slug")
+			return
+		}
+		if taken, _ := h.seriesRepo.SlugExists(r.Context(), slug, id); taken {
+			utils.JSONError(w, http.StatusConflict, "slug already in use: "+slug)
+			return
+		}
+		s.Slug = slug
+	}
+
+	if err := h.seriesRepo.Update(r.Context(), s); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to update series")
+		return
+	}
+
+	userID := middleware.GetUserID(r)
+	_ = h.auditRepo.Log(r.Context(), userID, "update", "series", id, "", r.RemoteAddr)
+
+	utils.JSONResponse(w, http.StatusOK, s)
+}
+
+// Delete removes a series. Posts are kept; only the links are dropped.
+func (h *SeriesHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.seriesRepo.Delete(r.Context(), id); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to delete series")
+		return
+	}
+
+	userID := middleware.GetUserID(r)
+	_ = h.auditRepo.Log(r.Context(), userID, "delete", "series", id, "", r.RemoteAddr)
+
+	utils.JSONResponse(w, http.StatusOK, map[string]string{"message": "deleted"})
+}
+
+// SetPosts replaces the ordered list of posts in a series.
+func (h *SeriesHandler) SetPosts(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var input series.SetPostsInput
+	if err := utils.DecodeJSON(r, &input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	seen := make(map[string]bool, len(input.PostIDs))
+	for _, postID := range input.PostIDs {
+		if postID == "" || seen[postID] {
+			utils.JSONError(w, http.StatusBadRequest, "post_ids must be unique and non-empty")
+			return
+		}
+		seen[postID] = true
+	}
+
+	if _, err := h.seriesRepo.FindByID(r.Context(), id); err != nil {
+		utils.JSONError(w, http.StatusNotFound, "series not found")
+		return
+	}
+
+	if err := h.seriesRepo.SetPosts(r.Context(), id, input.PostIDs); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "failed to set series posts (unknown post?)")
+		return
+	}
+
+	userID := middleware.GetUserID(r)
+	_ = h.auditRepo.Log(r.Context(), userID, "set_posts", "series", id, strings.Join(input.PostIDs, ","), r.RemoteAddr)
+
+	s, _ := h.seriesRepo.FindByID(r.Context(), id)
+	utils.JSONResponse(w, http.StatusOK, s)
+}
+
+// AddPost attaches a single post to a series.
+func (h *SeriesHandler) AddPost(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var input series.AddPostInput
+	if err := utils.DecodeJSON(r, &input); err != nil || input.PostID == "" {
+		utils.JSONError(w, http.StatusBadRequest, "post_id is required")
+		return
+	}
+
+	if _, err := h.seriesRepo.FindByID(r.Context(), id); err != nil {
+		utils.JSONError(w, http.StatusNotFound, "series not found")
+		return
+	}
+
+	if err := h.seriesRepo.AddPost(r.Context(), id, input.PostID, input.Position); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "failed to add post to series")
+		return
+	}
+
+	userID := middleware.GetUserID(r)
+	_ = h.auditRepo.Log(r.Context(), userID, "add_post", "series", id, input.PostID, r.RemoteAddr)
+
+	s, _ := h.seriesRepo.FindByID(r.Context(), id)
+	utils.JSONResponse(w, http.StatusOK, s)
+}
+
+// RemovePost detaches a post from a series.
+func (h *SeriesHandler) RemovePost(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	postID := chi.URLParam(r, "postId")
+
+	if err := h.seriesRepo.RemovePost(r.Context(), id, postID); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to remove post from series")
+		return
+	}
+
+	userID := middleware.GetUserID(r)
+	_ = h.auditRepo.Log(r.Context(), userID, "remove_post", "series", id, postID, r.RemoteAddr)
+
+	utils.JSONResponse(w, http.StatusOK, map[string]string{"message": "removed"})
+}
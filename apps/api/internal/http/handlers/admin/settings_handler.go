@@ -2,19 +2,23 @@ package admin
 
 import (
 	"net/http"
+	"strings"
 
+	"github.com/rapidtest/netpulse-api/internal/domain/related"
 	"github.com/rapidtest/netpulse-api/internal/http/middleware"
 	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
+	redisRepo "github.com/rapidtest/netpulse-api/internal/repository/redis"
 	"github.com/rapidtest/netpulse-api/internal/utils"
 )
 
 type SettingsHandler struct {
 	settingsRepo *postgres.SettingsRepo
 	auditRepo    *postgres.AuditRepo
+	cache        *redisRepo.Cache
 }
 
-func NewSettingsHandler(settingsRepo *postgres.SettingsRepo, auditRepo *postgres.AuditRepo) *SettingsHandler {
-	return &SettingsHandler{settingsRepo: settingsRepo, auditRepo: auditRepo}
+func NewSettingsHandler(settingsRepo *postgres.SettingsRepo, auditRepo *postgres.AuditRepo, cache *redisRepo.Cache) *SettingsHandler {
+	return &SettingsHandler{settingsRepo: settingsRepo, auditRepo: auditRepo, cache: cache}
 }
 
 // Get returns all site settings.
@@ -35,11 +39,19 @@ func (h *SettingsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	weightsChanged := false
 	for key, value := range body {
 		if err := h.settingsRepo.Set(r.Context(), key, value); err != nil {
 			utils.JSONError(w, http.StatusInternalServerError, "failed to update settings")
 			return
 		}
+		if strings.HasPrefix(key, "related_weight_") {
+			weightsChanged = true
+		}
+	}
+	// Related posts are cached with the weights they were ranked by.
+	if weightsChanged {
+		_ = h.cache.DeleteByPrefix(r.Context(), related.CachePrefix)
 	}
 
 	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
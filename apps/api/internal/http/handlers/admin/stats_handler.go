@@ -2,18 +2,21 @@ package admin
 
 import (
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/rapidtest/netpulse-api/internal/domain/searchlog"
 	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
 	"github.com/rapidtest/netpulse-api/internal/utils"
 )
 
 type StatsHandler struct {
 	engagementRepo *postgres.EngagementRepo
+	searchLogRepo  *postgres.SearchLogRepo
 }
 
-func NewStatsHandler(engagementRepo *postgres.EngagementRepo) *StatsHandler {
-	return &StatsHandler{engagementRepo: engagementRepo}
+func NewStatsHandler(engagementRepo *postgres.EngagementRepo, searchLogRepo *postgres.SearchLogRepo) *StatsHandler {
+	return &StatsHandler{engagementRepo: engagementRepo, searchLogRepo: searchLogRepo}
 }
 
 // Dashboard handles GET /admin/stats/dashboard
@@ -67,3 +70,34 @@ func (h *StatsHandler) TrafficOverview(w http.ResponseWriter, r *http.Request) {
 	}
 	utils.JSONResponse(w, http.StatusOK, stats)
 }
+
+// Search handles GET /admin/stats/search?days=30&limit=20&source=search
+// with the top queries, zero-result queries and CTR by result position.
+func (h *StatsHandler) Search(w http.ResponseWriter, r *http.Request) {
+	source := utils.QueryString(r, "source", searchlog.SourceSearch)
+	if source != searchlog.SourceSearch && source != searchlog.SourceSuggest {
+		utils.JSONError(w, http.StatusBadRequest, "source must be search or suggest")
+		return
+	}
+	days := utils.QueryInt(r, "days", 30)
+	if days < 1 || days > 365 {
+		days = 30
+	}
+	limit := utils.QueryInt(r, "limit", 20)
+	if limit < 1 || limit > 100 {
+		limit = 20
+	}
+
+	report, err := h.searchLogRepo.Report(r.Context(), searchlog.ReportFilter{
+		Source:    source,
+		Since:     time.Now().AddDate(0, 0, -days),
+		Limit:     limit,
+		Positions: 10,
+	})
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to load search stats")
+		return
+	}
+	report.Days = days
+	utils.JSONResponse(w, http.StatusOK, report)
+}
@@ -0,0 +1,189 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rapidtest/netpulse-api/internal/domain/posts"
+	"github.com/rapidtest/netpulse-api/internal/http/middleware"
+	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
+	redisRepo "github.com/rapidtest/netpulse-api/internal/repository/redis"
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+type TagsHandler struct {
+	repo      *postgres.TagsRepo
+	auditRepo *postgres.AuditRepo
+	cache     *redisRepo.Cache
+}
+
+func NewTagsHandler(repo *postgres.TagsRepo, auditRepo *postgres.AuditRepo, cache *redisRepo.Cache) *TagsHandler {
+	return &TagsHandler{repo: repo, auditRepo: auditRepo, cache: cache}
+}
+
+// List returns all tags with post counts (all statuses).
+func (h *TagsHandler) List(w http.ResponseWriter, r *http.Request) {
+	items, err := h.repo.FindAll(r.Context(), false)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to list tags")
+		return
+	}
+	if items == nil {
+		items = make([]posts.Tag, 0)
+	}
+	utils.JSONResponse(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// GetByID returns a single tag.
+func (h *TagsHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	t, err := h.repo.FindByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "tag not found")
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, t)
+}
+
+// Create adds a tag.
+func (h *TagsHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var input posts.CreateTagInput
+	if err := utils.DecodeJSON(r, &input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	input.Name = strings.TrimSpace(input.Name)
+	if input.Name == "" || len(input.Name) > 50 {
+		utils.JSONError(w, http.StatusBadRequest, "name is required (max 50 chars)")
+		return
+	}
+
+	slug := utils.Slugify(input.Slug)
+	if slug == "" {
+		slug = utils.Slugify(input.Name)
+	}
+	if taken, _ := h.repo.Conflicts(r.Context(), input.Name, slug, ""); taken {
+		utils.JSONError(w, http.StatusConflict, "tag name or slug already in use: "+slug)
+		return
+	}
+
+	t := &posts.Tag{Name: input.Name, Slug: slug}
+	if err := h.repo.Create(r.Context(), t); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to create tag")
+		return
+	}
+
+	userID := middleware.GetUserID(r)
+	_ = h.auditRepo.Log(r.Context(), userID, "create", "tag", t.ID, t.Name, r.RemoteAddr)
+
+	utils.JSONResponse(w, http.StatusCreated, t)
+}
+
+// Update renames or re-slugs a tag. The old slug keeps redirecting to it.
+func (h *TagsHandler) Update(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	t, err := h.repo.FindByID(r.Context(), id)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "tag not found")
+		return
+	}
+	oldSlug := t.Slug
+
+	var input posts.UpdateTagInput
+	if err := utils.DecodeJSON(r, &input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if input.Name != nil {
+		name := strings.TrimSpace(*input.Name)
+		if name == "" || len(name) > 50 {
+			utils.JSONError(w, http.StatusBadRequest, "name is required (max 50 chars)")
+			return
+		}
+		t.Name = name
+	}
+	if input.Slug != nil {
+		slug := utils.Slugify(*input.Slug)
+		if slug == "" {
+			utils.JSONError(w, http.StatusBadRequest, "invalid slug")
+			return
+		}
+		t.Slug = slug
+	}
+	if taken, _ := h.repo.Conflicts(r.Context(), t.Name, t.Slug, id); taken {
+		utils.JSONError(w, http.StatusConflict, "tag name or slug already in use: "+t.Slug)
+		return
+	}
+
+	if err := h.repo.Update(r.Context(), t, oldSlug); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to update tag")
+		return
+	}
+	_ = h.cache.DeleteByPrefix(r.Context(), "posts:")
+
+	userID := middleware.GetUserID(r)
+	_ = h.auditRepo.Log(r.Context(), userID, "update", "tag", id, "", r.RemoteAddr)
+
+	utils.JSONResponse(w, http.StatusOK, t)
+}
+
+// Delete removes a tag from all posts and deletes it.
+func (h *TagsHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.repo.Delete(r.Context(), id); err != nil {
+		utils.JSONError(w, http.StatusNotFound, "tag not found")
+		return
+	}
+	_ = h.cache.DeleteByPrefix(r.Context(), "posts:")
+
+	userID := middleware.GetUserID(r)
+	_ = h.auditRepo.Log(r.Context(), userID, "delete", "tag", id, "", r.RemoteAddr)
+
+	utils.JSONResponse(w, http.StatusOK, map[string]string{"message": "deleted"})
+}
+
+// Merge folds the source tags into the tag in the URL.
+func (h *TagsHandler) Merge(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var input posts.MergeTagsInput
+	if err := utils.DecodeJSON(r, &input); err != nil || len(input.SourceIDs) == 0 {
+		utils.JSONError(w, http.StatusBadRequest, "source_ids is required")
+		return
+	}
+	seen := make(map[string]bool, len(input.SourceIDs))
+	for _, sourceID := range input.SourceIDs {
+		if sourceID == "" || sourceID == id || seen[sourceID] {
+			utils.JSONError(w, http.StatusBadRequest, "source_ids must be unique, non-empty and differ from the target")
+			return
+		}
+		seen[sourceID] = true
+	}
+
+	if _, err := h.repo.FindByID(r.Context(), id); err != nil {
+		utils.JSONError(w, http.StatusNotFound, "tag not found")
+		return
+	}
+
+	retagged, err := h.repo.Merge(r.Context(), id, input.SourceIDs)
+	if errors.Is(err, posts.ErrTagNotFound) {
+		utils.JSONError(w, http.StatusNotFound, "source tag not found")
+		return
+	}
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to merge tags")
+		return
+	}
+	_ = h.cache.DeleteByPrefix(r.Context(), "posts:")
+
+	userID := middleware.GetUserID(r)
+	_ = h.auditRepo.Log(r.Context(), userID, "merge", "tag", id, strings.Join(input.SourceIDs, ","), r.RemoteAddr)
+
+	t, _ := h.repo.FindByID(r.Context(), id)
+	utils.JSONResponse(w, http.StatusOK, map[string]interface{}{"tag": t, "retagged_posts": retagged})
+}
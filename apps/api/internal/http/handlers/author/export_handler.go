@@ -0,0 +1,62 @@
+package author
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/rapidtest/netpulse-api/internal/domain/export"
+	"github.com/rapidtest/netpulse-api/internal/http/middleware"
+	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+// ExportHandler lets authors download an archive of their own posts.
+type ExportHandler struct {
+	exporter  *export.Exporter
+	auditRepo *postgres.AuditRepo
+}
+
+func NewExportHandler(exporter *export.Exporter, auditRepo *postgres.AuditRepo) *ExportHandler {
+	return &ExportHandler{exporter: exporter, auditRepo: auditRepo}
+}
+
+// Export streams a zip archive of the author's posts with their comments,
+// revisions and uploads. Optional filters: category, from, to.
+func (h *ExportHandler) Export(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+	if userID == "" {
+		utils.JSONError(w, http.StatusUnauthorized, "unauthorized")
+		return
+	}
+
+	filter := export.Filter{
+		AuthorID:     userID,
+		CategorySlug: utils.QueryString(r, "category", ""),
+	}
+	if err := filter.SetRange(utils.QueryString(r, "from", ""), utils.QueryString(r, "to", "")); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	archive, err := h.exporter.Load(r.Context(), filter)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to export posts")
+		return
+	}
+
+	// Archives can take longer than the server's write timeout.
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", `attachment; filename="`+export.Filename(archive.GeneratedAt())+`"`)
+	w.WriteHeader(http.StatusOK)
+	m, err := archive.Write(w)
+	if err != nil {
+		log.Error().Err(err).Str("user_id", userID).Msg("export interrupted")
+		return
+	}
+	_ = h.auditRepo.Log(r.Context(), userID, "export", "post", "",
+		fmt.Sprintf("%d posts, %d comments, %d files", m.Posts, m.Comments, m.Files), r.RemoteAddr)
+}
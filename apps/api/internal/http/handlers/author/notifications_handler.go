@@ -0,0 +1,115 @@
+package author
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rapidtest/netpulse-api/internal/domain/notifications"
+	"github.com/rapidtest/netpulse-api/internal/http/middleware"
+	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+// NotificationsHandler lists comment notifications and manages opt-outs
+// for the current user.
+type NotificationsHandler struct {
+	notificationsRepo *postgres.NotificationsRepo
+}
+
+func NewNotificationsHandler(notificationsRepo *postgres.NotificationsRepo) *NotificationsHandler {
+	return &NotificationsHandler{notificationsRepo: notificationsRepo}
+}
+
+// List handles GET /user/notifications
+func (h *NotificationsHandler) List(w http.ResponseWriter, r *http.Request) {
+	f := notifications.Filter{
+		UserID:     middleware.GetUserID(r),
+		UnreadOnly: utils.QueryString(r, "unread", "") == "true",
+		Page:       utils.QueryInt(r, "page", 1),
+		Limit:      utils.QueryInt(r, "limit", 20),
+	}
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	if f.Limit < 1 || f.Limit > 50 {
+		f.Limit = 20
+	}
+
+	result, err := h.notificationsRepo.List(r.Context(), f)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to load notifications")
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, result)
+}
+
+// UnreadCount handles GET /user/notifications/unread-count
+func (h *NotificationsHandler) UnreadCount(w http.ResponseWriter, r *http.Request) {
+	n, err := h.notificationsRepo.UnreadCount(r.Context(), middleware.GetUserID(r))
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to count notifications")
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, map[string]int{"unread": n})
+}
+
+// MarkRead handles POST /user/notifications/{id}/read
+func (h *NotificationsHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	found, err := h.notificationsRepo.MarkRead(r.Context(), middleware.GetUserID(r), chi.URLParam(r, "id"))
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to update notification")
+		return
+	}
+	if !found {
+		utils.JSONError(w, http.StatusNotFound, "notification not found")
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, map[string]string{"message": "notification read"})
+}
+
+// MarkAllRead handles POST /user/notifications/read-all
+func (h *NotificationsHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	if err := h.notificationsRepo.MarkAllRead(r.Context(), middleware.GetUserID(r)); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to update notifications")
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, map[string]string{"message": "notifications read"})
+}
+
+// GetPreferences handles GET /user/notifications/preferences
+func (h *NotificationsHandler) GetPreferences(w http.ResponseWriter, r *http.Request) {
+	prefs, err := h.notificationsRepo.Preferences(r.Context(), middleware.GetUserID(r))
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to load preferences")
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, prefs)
+}
+
+// UpdatePreferences handles PUT /user/notifications/preferences. Types
+// left out keep their setting.
+func (h *NotificationsHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	var input notifications.Preferences
+	if err := utils.DecodeJSON(r, &input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	for t := range input {
+		if !notifications.ValidType(t) {
+			utils.JSONError(w, http.StatusBadRequest, "unknown notification type: "+t)
+			return
+		}
+	}
+
+	userID := middleware.GetUserID(r)
+	if err := h.notificationsRepo.SetPreferences(r.Context(), userID, input); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to update preferences")
+		return
+	}
+	prefs, err := h.notificationsRepo.Preferences(r.Context(), userID)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to load preferences")
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, prefs)
+}
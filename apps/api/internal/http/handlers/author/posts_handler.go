@@ -1,8 +1,10 @@
 package author
 
 import (
+	"errors"
 	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/rapidtest/netpulse-api/internal/domain/posts"
@@ -50,7 +52,7 @@ func (h *PostsHandler) List(w http.ResponseWriter, r *http.Request) {
 	utils.JSONResponse(w, http.StatusOK, result)
 }
 
-// GetByID returns a specific post owned by the author.
+// GetByID returns a specific post the author owns or co-authors.
 func (h *PostsHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
 	postID := chi.URLParam(r, "id")
@@ -61,12 +63,13 @@ func (h *PostsHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Authors can only view their own posts
-	if post.AuthorID != userID {
+	// Authors can only view posts they are credited on
+	if !post.HasContributor(userID) {
 		utils.JSONError(w, http.StatusForbidden, "you can only view your own posts")
 		return
 	}
 
+	utils.SetVersionETag(w, post.Version)
 	utils.JSONResponse(w, http.StatusOK, post)
 }
 
@@ -90,6 +93,14 @@ func (h *PostsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	}
 
 	post, err := h.postsSvc.Create(r.Context(), input, userID)
+	if errors.Is(err, posts.ErrUnsupportedLanguage) {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, posts.ErrSlugConflict) {
+		utils.JSONError(w, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		utils.JSONError(w, http.StatusInternalServerError, "failed to create post: "+err.Error())
 		return
@@ -99,7 +110,8 @@ func (h *PostsHandler) Create(w http.ResponseWriter, r *http.Request) {
 	utils.JSONResponse(w, http.StatusCreated, post)
 }
 
-// Update updates an author's own post (only if DRAFT or CHANGES_REQUESTED).
+// Update updates a post the author owns or co-authors (only if DRAFT or
+// CHANGES_REQUESTED).
 func (h *PostsHandler) Update(w http.ResponseWriter, r *http.Request) {
 	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
 	postID := chi.URLParam(r, "id")
@@ -110,7 +122,7 @@ func (h *PostsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if post.AuthorID != userID {
+	if !post.HasContributor(userID) {
 		utils.JSONError(w, http.StatusForbidden, "you can only edit your own posts")
 		return
 	}
@@ -125,14 +137,42 @@ func (h *PostsHandler) Update(w http.ResponseWriter, r *http.Request) {
 		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
 		return
 	}
+	// Co-authors edit the same post, so every edit must name the version
+	// it is based on
+	if input.Version == nil {
+		input.Version = utils.IfMatchVersion(r)
+	}
+	if input.Version == nil {
+		utils.JSONError(w, http.StatusPreconditionRequired, "version is required (send \"version\" or an If-Match header)")
+		return
+	}
 
-	updated, err := h.postsSvc.Update(r.Context(), postID, input)
+	updated, err := h.postsSvc.Update(r.Context(), postID, input, userID)
+	var stale *posts.VersionConflictError
+	if errors.As(err, &stale) {
+		utils.SetVersionETag(w, stale.Current.Version)
+		utils.JSONResponse(w, http.StatusConflict, map[string]interface{}{
+			"error":   err.Error(),
+			"version": stale.Current.Version,
+			"current": stale.Current,
+		})
+		return
+	}
+	if errors.Is(err, posts.ErrUnsupportedLanguage) {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, posts.ErrSlugConflict) {
+		utils.JSONError(w, http.StatusConflict, err.Error())
+		return
+	}
 	if err != nil {
 		utils.JSONError(w, http.StatusInternalServerError, "failed to update post")
 		return
 	}
 
 	h.auditRepo.Log(r.Context(), userID, "update", "post", postID, "Author updated post", r.RemoteAddr)
+	utils.SetVersionETag(w, updated.Version)
 	utils.JSONResponse(w, http.StatusOK, updated)
 }
 
@@ -148,7 +188,7 @@ func (h *PostsHandler) Delete(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if post.AuthorID != userID {
-		utils.JSONError(w, http.StatusForbidden, "you can only delete your own posts")
+		utils.JSONError(w, http.StatusForbidden, "only the post's owner can delete it")
 		return
 	}
 
@@ -177,7 +217,7 @@ func (h *PostsHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if post.AuthorID != userID {
+	if !post.HasContributor(userID) {
 		utils.JSONError(w, http.StatusForbidden, "you can only submit your own posts for review")
 		return
 	}
@@ -191,7 +231,7 @@ func (h *PostsHandler) SubmitReview(w http.ResponseWriter, r *http.Request) {
 	utils.JSONResponse(w, http.StatusOK, map[string]string{"message": "post submitted for review"})
 }
 
-// Stats returns the author's aggregated stats.
+// Stats returns the author's aggregated stats, co-authored posts included.
 func (h *PostsHandler) Stats(w http.ResponseWriter, r *http.Request) {
 	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
 
@@ -248,3 +288,224 @@ func (h *PostsHandler) Stats(w http.ResponseWriter, r *http.Request) {
 
 	utils.JSONResponse(w, http.StatusOK, stats)
 }
+
+// ListRevisions returns the revision history of the author's own post.
+func (h *PostsHandler) ListRevisions(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+	postID := chi.URLParam(r, "id")
+
+	post, err := h.postsSvc.GetByID(r.Context(), postID)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "post not found")
+		return
+	}
+
+	if !post.HasContributor(userID) {
+		utils.JSONError(w, http.StatusForbidden, "you can only view your own posts")
+		return
+	}
+
+	items, err := h.postsSvc.ListRevisions(r.Context(), postID)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to list revisions")
+		return
+	}
+	if items == nil {
+		items = make([]posts.Revision, 0)
+	}
+
+	utils.JSONResponse(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// DiffRevisions compares two revisions of the author's own post.
+func (h *PostsHandler) DiffRevisions(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+	postID := chi.URLParam(r, "id")
+
+	post, err := h.postsSvc.GetByID(r.Context(), postID)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "post not found")
+		return
+	}
+
+	if !post.HasContributor(userID) {
+		utils.JSONError(w, http.StatusForbidden, "you can only view your own posts")
+		return
+	}
+
+	from, errFrom := strconv.ParseInt(r.URL.Query().Get("from"), 10, 64)
+	to, errTo := strconv.ParseInt(r.URL.Query().Get("to"), 10, 64)
+	if errFrom != nil || errTo != nil {
+		utils.JSONError(w, http.StatusBadRequest, "query parameters 'from' and 'to' must be revision IDs")
+		return
+	}
+
+	diff, err := h.postsSvc.DiffRevisions(r.Context(), postID, from, to)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, diff)
+}
+
+// RestoreRevision restores an older revision of the author's own post
+// (only if DRAFT or CHANGES_REQUESTED).
+func (h *PostsHandler) RestoreRevision(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+	postID := chi.URLParam(r, "id")
+
+	post, err := h.postsSvc.GetByID(r.Context(), postID)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "post not found")
+		return
+	}
+
+	if !post.HasContributor(userID) {
+		utils.JSONError(w, http.StatusForbidden, "you can only edit your own posts")
+		return
+	}
+
+	if post.Status != posts.StatusDraft && post.Status != posts.StatusChangesRequested {
+		utils.JSONError(w, http.StatusBadRequest, "post can only be edited in DRAFT or CHANGES_REQUESTED status")
+		return
+	}
+
+	revID, err := strconv.ParseInt(chi.URLParam(r, "revisionId"), 10, 64)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid revision ID")
+		return
+	}
+
+	updated, err := h.postsSvc.RestoreRevision(r.Context(), postID, revID, userID)
+	if errors.Is(err, posts.ErrSlugConflict) {
+		utils.JSONError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.auditRepo.Log(r.Context(), userID, "restore_revision", "post", postID, "Author restored revision "+strconv.FormatInt(revID, 10), r.RemoteAddr)
+	utils.JSONResponse(w, http.StatusOK, updated)
+}
+
+// ownPost loads a post and checks the current user owns or co-authors it,
+// writing the error response otherwise.
+func (h *PostsHandler) ownPost(w http.ResponseWriter, r *http.Request) (*posts.Post, bool) {
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+
+	post, err := h.postsSvc.GetByID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "post not found")
+		return nil, false
+	}
+	if !post.HasContributor(userID) {
+		utils.JSONError(w, http.StatusForbidden, "you can only view your own posts")
+		return nil, false
+	}
+	return post, true
+}
+
+// ListReviewNotes returns the editorial review thread of the author's own post.
+func (h *PostsHandler) ListReviewNotes(w http.ResponseWriter, r *http.Request) {
+	post, ok := h.ownPost(w, r)
+	if !ok {
+		return
+	}
+
+	items, err := h.postsSvc.ListReviewNotes(r.Context(), post.ID)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to list review notes")
+		return
+	}
+	if items == nil {
+		items = make([]posts.ReviewNote, 0)
+	}
+
+	utils.JSONResponse(w, http.StatusOK, map[string]interface{}{"items": items})
+}
+
+// ReplyToNote answers an editor's review note on the author's own post.
+func (h *PostsHandler) ReplyToNote(w http.ResponseWriter, r *http.Request) {
+	post, ok := h.ownPost(w, r)
+	if !ok {
+		return
+	}
+	noteID, err := strconv.ParseInt(chi.URLParam(r, "noteId"), 10, 64)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid note ID")
+		return
+	}
+
+	var input posts.ReplyNoteInput
+	if err := utils.DecodeJSON(r, &input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+
+	note, err := h.postsSvc.ReplyToNote(r.Context(), post.ID, noteID, userID, input)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, note)
+}
+
+// ResolveNote marks a review note on the author's own post as addressed.
+func (h *PostsHandler) ResolveNote(w http.ResponseWriter, r *http.Request) {
+	post, ok := h.ownPost(w, r)
+	if !ok {
+		return
+	}
+	noteID, err := strconv.ParseInt(chi.URLParam(r, "noteId"), 10, 64)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid note ID")
+		return
+	}
+
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+
+	if err := h.postsSvc.SetNoteResolved(r.Context(), post.ID, noteID, userID, true); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, map[string]string{"message": "resolved"})
+}
+
+// SetAuthors replaces the byline of a post. Only the post's owner can change
+// who is credited.
+func (h *PostsHandler) SetAuthors(w http.ResponseWriter, r *http.Request) {
+	userID, _ := r.Context().Value(middleware.CtxUserID).(string)
+	postID := chi.URLParam(r, "id")
+
+	post, err := h.postsSvc.GetByID(r.Context(), postID)
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "post not found")
+		return
+	}
+	if post.AuthorID != userID {
+		utils.JSONError(w, http.StatusForbidden, "only the post's owner can change its authors")
+		return
+	}
+
+	var input posts.SetContributorsInput
+	if err := utils.DecodeJSON(r, &input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	updated, err := h.postsSvc.SetContributors(r.Context(), postID, input)
+	if err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	h.auditRepo.Log(r.Context(), userID, "set_authors", "post", postID, "Author updated post byline", r.RemoteAddr)
+	utils.JSONResponse(w, http.StatusOK, updated)
+}
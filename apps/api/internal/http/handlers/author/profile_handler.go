@@ -3,9 +3,11 @@ package author
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"net/http"
 	"time"
 
+	"github.com/rapidtest/netpulse-api/internal/domain/users"
 	"github.com/rapidtest/netpulse-api/internal/http/middleware"
 	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
 	"github.com/rapidtest/netpulse-api/internal/security"
@@ -51,6 +53,7 @@ func (h *ProfileHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
 
 	var input struct {
 		Name            *string `json:"name,omitempty"`
+		Username        *string `json:"username,omitempty"`
 		Bio             *string `json:"bio,omitempty"`
 		Avatar          *string `json:"avatar,omitempty"`
 		Website         *string `json:"website,omitempty"`
@@ -73,6 +76,34 @@ func (h *ProfileHandler) UpdateMe(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if input.Username != nil {
+		username, ok := users.NormalizeUsername(*input.Username)
+		if !ok {
+			utils.JSONError(w, http.StatusBadRequest, "username must be 3-30 letters, digits or underscores")
+			return
+		}
+		if username != user.Username {
+			taken, err := h.usersRepo.UsernameTaken(r.Context(), username, userID)
+			if err != nil {
+				utils.JSONError(w, http.StatusInternalServerError, "failed to update profile")
+				return
+			}
+			if taken {
+				utils.JSONError(w, http.StatusConflict, "username is already taken")
+				return
+			}
+			err = h.usersRepo.UpdateUsername(r.Context(), userID, username)
+			if errors.Is(err, users.ErrUsernameTaken) {
+				utils.JSONError(w, http.StatusConflict, "username is already taken")
+				return
+			}
+			if err != nil {
+				utils.JSONError(w, http.StatusInternalServerError, "failed to update profile")
+				return
+			}
+			user.Username = username
+		}
+	}
 	if input.Name != nil {
 		user.Name = *input.Name
 	}
@@ -3,6 +3,8 @@ package public
 import (
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/rapidtest/netpulse-api/internal/domain/posts"
 	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
 	"github.com/rapidtest/netpulse-api/internal/utils"
 )
@@ -15,11 +17,47 @@ func NewCategoriesHandler(repo *postgres.CategoriesRepo) *CategoriesHandler {
 	return &CategoriesHandler{repo: repo}
 }
 
+// List returns categories with published post counts. With ?tree=true the
+// categories are nested under their parents.
 func (h *CategoriesHandler) List(w http.ResponseWriter, r *http.Request) {
-	cats, err := h.repo.FindAll(r.Context())
+	cats, err := h.repo.FindAll(r.Context(), true)
 	if err != nil {
 		utils.JSONError(w, http.StatusInternalServerError, "failed to fetch categories")
 		return
 	}
+	if utils.QueryString(r, "tree", "") == "true" {
+		cats = posts.CategoryTree(cats)
+	}
+	if cats == nil {
+		cats = make([]posts.Category, 0)
+	}
 	utils.JSONResponse(w, http.StatusOK, cats)
 }
+
+// GetBySlug returns one category with its nested subcategories.
+func (h *CategoriesHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	cats, err := h.repo.FindAll(r.Context(), true)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to fetch categories")
+		return
+	}
+	if c := findCategory(posts.CategoryTree(cats), slug); c != nil {
+		utils.JSONResponse(w, http.StatusOK, c)
+		return
+	}
+	utils.JSONError(w, http.StatusNotFound, "category not found")
+}
+
+func findCategory(tree []posts.Category, slug string) *posts.Category {
+	for i := range tree {
+		if tree[i].Slug == slug {
+			return &tree[i]
+		}
+		if c := findCategory(tree[i].Children, slug); c != nil {
+			return c
+		}
+	}
+	return nil
+}
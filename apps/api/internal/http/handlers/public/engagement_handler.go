@@ -5,18 +5,27 @@ import (
 	"encoding/hex"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/rapidtest/netpulse-api/internal/domain/comments"
+	"github.com/rapidtest/netpulse-api/internal/domain/engagement"
+	"github.com/rapidtest/netpulse-api/internal/domain/notifications"
+	"github.com/rapidtest/netpulse-api/internal/domain/spam"
+	"github.com/rapidtest/netpulse-api/internal/markdown"
 	"github.com/rapidtest/netpulse-api/internal/http/middleware"
 	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
 	redisRepo "github.com/rapidtest/netpulse-api/internal/repository/redis"
 	"github.com/rapidtest/netpulse-api/internal/utils"
+	"github.com/rs/zerolog/log"
 )
 
 type EngagementHandler struct {
 	commentsRepo   *postgres.CommentsRepo
 	engagementRepo *postgres.EngagementRepo
+	settingsRepo   *postgres.SettingsRepo
+	spam           *spam.Classifier
+	notifications  *postgres.NotificationsRepo
 	engCache       *redisRepo.EngagementCache
 	auditRepo      *postgres.AuditRepo
 }
@@ -24,12 +33,18 @@ type EngagementHandler struct {
 func NewEngagementHandler(
 	commentsRepo *postgres.CommentsRepo,
 	engagementRepo *postgres.EngagementRepo,
+	settingsRepo *postgres.SettingsRepo,
+	spamClassifier *spam.Classifier,
+	notificationsRepo *postgres.NotificationsRepo,
 	engCache *redisRepo.EngagementCache,
 	auditRepo *postgres.AuditRepo,
 ) *EngagementHandler {
 	return &EngagementHandler{
 		commentsRepo:   commentsRepo,
 		engagementRepo: engagementRepo,
+		settingsRepo:   settingsRepo,
+		spam:           spamClassifier,
+		notifications:  notificationsRepo,
 		engCache:       engCache,
 		auditRepo:      auditRepo,
 	}
@@ -43,13 +58,27 @@ func (h *EngagementHandler) ListComments(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	sort := utils.QueryString(r, "sort", comments.SortNewest)
+	if !comments.ValidSort(sort) {
+		utils.JSONError(w, http.StatusBadRequest, "sort must be newest, oldest or top")
+		return
+	}
+
 	page := utils.QueryInt(r, "page", 1)
 	limit := utils.QueryInt(r, "limit", 20)
 	if limit > 50 {
 		limit = 50
 	}
 
-	result, err := h.commentsRepo.FindByPost(r.Context(), postID, page, limit)
+	f := comments.ThreadFilter{
+		PostID:   postID,
+		MaxDepth: h.maxDepth(r),
+		Sort:     sort,
+		Viewer:   commentViewer(r),
+		Page:     page,
+		Limit:    limit,
+	}
+	result, err := h.commentsRepo.FindThread(r.Context(), f)
 	if err != nil {
 		utils.JSONError(w, http.StatusInternalServerError, "failed to load comments")
 		return
@@ -58,9 +87,17 @@ func (h *EngagementHandler) ListComments(w http.ResponseWriter, r *http.Request)
 	// Load replies for each comment (max 3 preview)
 	for i := range result.Items {
 		if result.Items[i].ReplyCount > 0 {
-			replies, err := h.commentsRepo.FindReplies(r.Context(), result.Items[i].ID, 3)
+			replies, err := h.commentsRepo.FindThread(r.Context(), comments.ThreadFilter{
+				ParentID: result.Items[i].ID,
+				Depth:    1,
+				MaxDepth: f.MaxDepth,
+				Sort:     comments.SortOldest,
+				Viewer:   f.Viewer,
+				Page:     1,
+				Limit:    3,
+			})
 			if err == nil {
-				result.Items[i].Replies = replies
+				result.Items[i].Replies = replies.Items
 			}
 		}
 	}
@@ -68,6 +105,57 @@ func (h *EngagementHandler) ListComments(w http.ResponseWriter, r *http.Request)
 	utils.JSONResponse(w, http.StatusOK, result)
 }
 
+// ListReplies handles GET /posts/{id}/comments/{commentId}/replies
+func (h *EngagementHandler) ListReplies(w http.ResponseWriter, r *http.Request) {
+	// Deleted comments keep their replies
+	parent, err := h.commentsRepo.FindByIDWithDeleted(r.Context(), chi.URLParam(r, "commentId"))
+	if err != nil || parent.PostID != chi.URLParam(r, "id") || parent.Status != comments.StatusApproved {
+		utils.JSONError(w, http.StatusNotFound, "comment not found")
+		return
+	}
+
+	sort := utils.QueryString(r, "sort", comments.SortOldest)
+	if !comments.ValidSort(sort) {
+		utils.JSONError(w, http.StatusBadRequest, "sort must be newest, oldest or top")
+		return
+	}
+
+	page := utils.QueryInt(r, "page", 1)
+	limit := utils.QueryInt(r, "limit", 20)
+	if limit > 50 {
+		limit = 50
+	}
+
+	depth, err := h.commentsRepo.Depth(r.Context(), parent.ID)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to load replies")
+		return
+	}
+
+	// Replies below the flattening depth are listed under the comment at
+	// that depth, so their depth never exceeds it.
+	maxDepth := h.maxDepth(r)
+	if depth >= maxDepth {
+		depth = maxDepth - 1
+	}
+
+	result, err := h.commentsRepo.FindThread(r.Context(), comments.ThreadFilter{
+		ParentID: parent.ID,
+		Depth:    depth + 1,
+		MaxDepth: maxDepth,
+		Sort:     sort,
+		Viewer:   commentViewer(r),
+		Page:     page,
+		Limit:    limit,
+	})
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to load replies")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, result)
+}
+
 // CreateComment handles POST /posts/{id}/comments
 func (h *EngagementHandler) CreateComment(w http.ResponseWriter, r *http.Request) {
 	postID := chi.URLParam(r, "id")
@@ -82,19 +170,23 @@ func (h *EngagementHandler) CreateComment(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	// Validate content
-	input.Content = strings.TrimSpace(input.Content)
-	if len(input.Content) < 3 {
-		utils.JSONError(w, http.StatusBadRequest, "comment must be at least 3 characters")
-		return
-	}
-	if len(input.Content) > 5000 {
-		utils.JSONError(w, http.StatusBadRequest, "comment must not exceed 5000 characters")
+	content, msg := cleanCommentContent(input.Content)
+	if msg != "" {
+		utils.JSONError(w, http.StatusBadRequest, msg)
 		return
 	}
+	input.Content = content
 
-	// Sanitize — strip any HTML tags (basic)
-	input.Content = sanitizeText(input.Content)
+	// Replies must answer a visible comment on the same post
+	if input.ParentID != nil && *input.ParentID != "" {
+		parent, err := h.commentsRepo.FindByID(r.Context(), *input.ParentID)
+		if err != nil || parent.PostID != postID || parent.Status != comments.StatusApproved {
+			utils.JSONError(w, http.StatusBadRequest, "parent comment not found")
+			return
+		}
+	} else {
+		input.ParentID = nil
+	}
 
 	ip := middleware.ExtractIP(r)
 	ua := r.UserAgent()
@@ -124,12 +216,20 @@ func (h *EngagementHandler) CreateComment(w http.ResponseWriter, r *http.Request
 		Status:    comments.StatusPending,
 		IPAddress: ip,
 		UserAgent: ua,
+		ContentHash: spam.ContentHash(input.Content),
 	}
 
-	// Auto-approve if authenticated and verified
-	if userID != "" {
-		comment.Status = comments.StatusApproved
-	}
+	// Score for spam: spam is filed away, clean comments from trusted
+	// users skip moderation
+	verdict := h.spam.Classify(r.Context(), spam.Input{
+		PostID:     postID,
+		UserID:     userID,
+		Content:    input.Content,
+		GuestEmail: input.GuestEmail,
+		IPHash:     hashIP(ip),
+	})
+	comment.Status = verdict.Status
+	comment.SpamScore = verdict.Score
 
 	err := h.commentsRepo.Create(r.Context(), comment)
 	if err != nil {
@@ -141,10 +241,121 @@ func (h *EngagementHandler) CreateComment(w http.ResponseWriter, r *http.Request
 	if comment.Status == comments.StatusApproved {
 		h.engagementRepo.IncrementComments(r.Context(), postID, 1)
 	}
+	h.notify(r, comment)
 
 	utils.JSONResponse(w, http.StatusCreated, comment)
 }
 
+// UpdateComment handles PATCH /posts/{id}/comments/{commentId}
+func (h *EngagementHandler) UpdateComment(w http.ResponseWriter, r *http.Request) {
+	c, ok := h.findOwnComment(w, r)
+	if !ok {
+		return
+	}
+
+	var input comments.UpdateCommentInput
+	if err := utils.DecodeJSON(r, &input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	content, msg := cleanCommentContent(input.Content)
+	if msg != "" {
+		utils.JSONError(w, http.StatusBadRequest, msg)
+		return
+	}
+	if content == c.Content {
+		utils.JSONResponse(w, http.StatusOK, c)
+		return
+	}
+
+	// Score the new content like a new comment, so approved comments
+	// cannot be edited into spam without moderation
+	userID := middleware.GetUserID(r)
+	verdict := h.spam.Classify(r.Context(), spam.Input{
+		PostID:  c.PostID,
+		UserID:  userID,
+		Content: content,
+		IPHash:  hashIP(middleware.ExtractIP(r)),
+		Edit:    true,
+	})
+	wasApproved := c.Status == comments.StatusApproved
+	c.Content = content
+	c.ContentHash = spam.ContentHash(content)
+	c.Status = verdict.EditStatus(c.Status)
+	c.SpamScore = verdict.Score
+
+	if err := h.commentsRepo.UpdateContent(r.Context(), c, userID); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to update comment")
+		return
+	}
+	if wasApproved && c.Status != comments.StatusApproved {
+		h.engagementRepo.IncrementComments(r.Context(), c.PostID, -1)
+	}
+
+	updated, err := h.commentsRepo.FindByID(r.Context(), c.ID)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to update comment")
+		return
+	}
+	// Users newly mentioned in the edit are notified; others already were.
+	h.notify(r, updated)
+	utils.JSONResponse(w, http.StatusOK, updated)
+}
+
+// notify sends the notifications of a visible comment. Failures are
+// logged and never fail the request.
+func (h *EngagementHandler) notify(r *http.Request, c *comments.Comment) {
+	if err := notifications.NotifyComment(r.Context(), h.notifications, c); err != nil {
+		log.Warn().Err(err).Str("comment_id", c.ID).Msg("failed to send comment notifications")
+	}
+}
+
+// DeleteComment handles DELETE /posts/{id}/comments/{commentId}
+func (h *EngagementHandler) DeleteComment(w http.ResponseWriter, r *http.Request) {
+	c, ok := h.findOwnComment(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.commentsRepo.SoftDelete(r.Context(), c.ID); err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to delete comment")
+		return
+	}
+
+	if c.Status == comments.StatusApproved {
+		h.engagementRepo.IncrementComments(r.Context(), c.PostID, -1)
+	}
+
+	h.auditRepo.Log(r.Context(), middleware.GetUserID(r), "comment.delete", "comment", c.ID,
+		"deleted by author", middleware.ExtractIP(r))
+
+	utils.JSONResponse(w, http.StatusOK, map[string]string{"message": "comment deleted"})
+}
+
+// findOwnComment loads comment {commentId} of post {id} for its author,
+// writing an error unless it is theirs and still within the edit window.
+func (h *EngagementHandler) findOwnComment(w http.ResponseWriter, r *http.Request) (*comments.Comment, bool) {
+	c, err := h.commentsRepo.FindByID(r.Context(), chi.URLParam(r, "commentId"))
+	if err != nil || c.PostID != chi.URLParam(r, "id") {
+		utils.JSONError(w, http.StatusNotFound, "comment not found")
+		return nil, false
+	}
+	if c.UserID == nil || *c.UserID != middleware.GetUserID(r) {
+		utils.JSONError(w, http.StatusForbidden, "you can only change your own comments")
+		return nil, false
+	}
+
+	window := comments.DefaultEditWindow
+	if all, err := h.settingsRepo.GetAll(r.Context()); err == nil {
+		window = comments.EditWindowFromSettings(all)
+	}
+	if !c.Editable(window, time.Now()) {
+		utils.JSONError(w, http.StatusForbidden, "the edit window for this comment has passed")
+		return nil, false
+	}
+	return c, true
+}
+
 // ToggleLike handles POST /posts/{id}/like
 func (h *EngagementHandler) ToggleLike(w http.ResponseWriter, r *http.Request) {
 	postID := chi.URLParam(r, "id")
@@ -202,6 +413,81 @@ func (h *EngagementHandler) ToggleLike(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// LikeComment handles POST /posts/{id}/comments/{commentId}/like
+func (h *EngagementHandler) LikeComment(w http.ResponseWriter, r *http.Request) {
+	c, ok := h.findVisibleComment(w, r)
+	if !ok {
+		return
+	}
+
+	count := c.LikesCount
+	added, err := h.commentsRepo.AddLike(r.Context(), c.ID, commentViewer(r), hashIP(middleware.ExtractIP(r)))
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to like comment")
+		return
+	}
+	if added {
+		if count, err = h.commentsRepo.IncrementLikes(r.Context(), c.ID, 1); err != nil {
+			utils.JSONError(w, http.StatusInternalServerError, "failed to like comment")
+			return
+		}
+	}
+
+	utils.JSONResponse(w, http.StatusOK, engagement.LikeResponse{Liked: true, LikesCount: int64(count)})
+}
+
+// UnlikeComment handles DELETE /posts/{id}/comments/{commentId}/like
+func (h *EngagementHandler) UnlikeComment(w http.ResponseWriter, r *http.Request) {
+	c, ok := h.findVisibleComment(w, r)
+	if !ok {
+		return
+	}
+
+	count := c.LikesCount
+	removed, err := h.commentsRepo.RemoveLike(r.Context(), c.ID, commentViewer(r))
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to unlike comment")
+		return
+	}
+	if removed {
+		if count, err = h.commentsRepo.IncrementLikes(r.Context(), c.ID, -1); err != nil {
+			utils.JSONError(w, http.StatusInternalServerError, "failed to unlike comment")
+			return
+		}
+	}
+
+	utils.JSONResponse(w, http.StatusOK, engagement.LikeResponse{Liked: false, LikesCount: int64(count)})
+}
+
+// findVisibleComment loads the approved comment {commentId} of post {id},
+// writing a 404 when there is none.
+func (h *EngagementHandler) findVisibleComment(w http.ResponseWriter, r *http.Request) (*comments.Comment, bool) {
+	c, err := h.commentsRepo.FindByID(r.Context(), chi.URLParam(r, "commentId"))
+	if err != nil || c.PostID != chi.URLParam(r, "id") || c.Status != comments.StatusApproved {
+		utils.JSONError(w, http.StatusNotFound, "comment not found")
+		return nil, false
+	}
+	return c, true
+}
+
+// maxDepth returns the number of nested reply levels from settings.
+func (h *EngagementHandler) maxDepth(r *http.Request) int {
+	all, err := h.settingsRepo.GetAll(r.Context())
+	if err != nil {
+		return comments.DefaultMaxDepth
+	}
+	return comments.MaxDepthFromSettings(all)
+}
+
+// commentViewer identifies the reader of a comment thread: the signed-in
+// user, or a guest by IP and user agent.
+func commentViewer(r *http.Request) comments.Viewer {
+	if userID := middleware.GetUserID(r); userID != "" {
+		return comments.Viewer{UserID: userID}
+	}
+	return comments.Viewer{GuestKey: generateGuestKey(middleware.ExtractIP(r), r.UserAgent())}
+}
+
 // RecordView handles POST /posts/{id}/view
 func (h *EngagementHandler) RecordView(w http.ResponseWriter, r *http.Request) {
 	postID := chi.URLParam(r, "id")
@@ -278,23 +564,23 @@ func hashIP(ip string) string {
 	return hex.EncodeToString(h[:16])
 }
 
-// sanitizeText removes HTML tags from text (basic sanitization).
-func sanitizeText(s string) string {
-	// Simple tag removal
-	result := strings.Builder{}
-	inTag := false
-	for _, ch := range s {
-		if ch == '<' {
-			inTag = true
-			continue
-		}
-		if ch == '>' {
-			inTag = false
-			continue
-		}
-		if !inTag {
-			result.WriteRune(ch)
-		}
+// cleanCommentContent trims and sanitizes comment content, returning an
+// error message when its length is out of bounds.
+func cleanCommentContent(s string) (string, string) {
+	s = strings.TrimSpace(s)
+	if len(s) < 3 {
+		return "", "comment must be at least 3 characters"
 	}
-	return result.String()
+	if len(s) > 5000 {
+		return "", "comment must not exceed 5000 characters"
+	}
+
+	// Sanitize — strip any HTML tags (basic)
+	return sanitizeText(s), ""
+}
+
+// sanitizeText removes HTML markup from text, including the contents of
+// script-like elements.
+func sanitizeText(s string) string {
+	return markdown.StripTags(s)
 }
@@ -0,0 +1,311 @@
+package public
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rapidtest/netpulse-api/internal/domain/feed"
+	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
+	redisRepo "github.com/rapidtest/netpulse-api/internal/repository/redis"
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+// feedCacheTTL bounds staleness for sources that don't invalidate the
+// "posts:" prefix (store listings). Post changes clear these keys immediately.
+const feedCacheTTL = 15 * time.Minute
+
+type feedFormat int
+
+const (
+	formatRSS feedFormat = iota
+	formatAtom
+	formatJSON
+)
+
+type FeedsHandler struct {
+	repo         *postgres.FeedRepo
+	settingsRepo *postgres.SettingsRepo
+	cache        *redisRepo.Cache
+	siteURL      string
+	storeURL     string
+}
+
+func NewFeedsHandler(repo *postgres.FeedRepo, settingsRepo *postgres.SettingsRepo, cache *redisRepo.Cache, siteURL, storeURL string) *FeedsHandler {
+	return &FeedsHandler{
+		repo:         repo,
+		settingsRepo: settingsRepo,
+		cache:        cache,
+		siteURL:      strings.TrimRight(siteURL, "/"),
+		storeURL:     strings.TrimRight(storeURL, "/"),
+	}
+}
+
+// ── Sitemap ─────────────────────────────────────────
+
+// Sitemap handles GET /sitemap.xml — a urlset, or a sitemap index once the
+// site has more than feed.MaxSitemapURLs URLs.
+func (h *FeedsHandler) Sitemap(w http.ResponseWriter, r *http.Request) {
+	h.serveCached(w, r, "posts:sitemap:index", "application/xml; charset=utf-8", func(ctx context.Context, out io.Writer) error {
+		total, err := h.sitemapTotal(ctx)
+		if err != nil {
+			return err
+		}
+		if total <= feed.MaxSitemapURLs {
+			return h.writeURLSet(ctx, out, 1)
+		}
+
+		lastMod, err := h.repo.LastModified(ctx)
+		if err != nil {
+			return err
+		}
+		pages := (total + feed.MaxSitemapURLs - 1) / feed.MaxSitemapURLs
+		locs := make([]string, 0, pages)
+		for i := 1; i <= pages; i++ {
+			locs = append(locs, fmt.Sprintf("%s/sitemap-%d.xml", h.siteURL, i))
+		}
+		return feed.WriteSitemapIndex(out, locs, lastMod)
+	})
+}
+
+// SitemapPage handles GET /sitemap-{page}.xml — one child sitemap of the index.
+func (h *FeedsHandler) SitemapPage(w http.ResponseWriter, r *http.Request) {
+	page, err := strconv.Atoi(chi.URLParam(r, "page"))
+	if err != nil || page < 1 {
+		utils.JSONError(w, http.StatusNotFound, "sitemap not found")
+		return
+	}
+
+	total, err := h.sitemapTotal(r.Context())
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to build sitemap")
+		return
+	}
+	if (page-1)*feed.MaxSitemapURLs >= total {
+		utils.JSONError(w, http.StatusNotFound, "sitemap not found")
+		return
+	}
+
+	key := fmt.Sprintf("posts:sitemap:%d", page)
+	h.serveCached(w, r, key, "application/xml; charset=utf-8", func(ctx context.Context, out io.Writer) error {
+		return h.writeURLSet(ctx, out, page)
+	})
+}
+
+// sitemapTotal counts every sitemap URL, including the home page.
+func (h *FeedsHandler) sitemapTotal(ctx context.Context) (int, error) {
+	n, err := h.repo.CountSitemapURLs(ctx)
+	return n + 1, err
+}
+
+// writeURLSet writes one page of at most feed.MaxSitemapURLs URLs. The home
+// page is the first URL of page 1, so the entries of each page shift by one.
+func (h *FeedsHandler) writeURLSet(ctx context.Context, out io.Writer, page int) error {
+	if err := feed.WriteURLSetStart(out); err != nil {
+		return err
+	}
+	offset, limit := (page-1)*feed.MaxSitemapURLs-1, feed.MaxSitemapURLs
+	if page == 1 {
+		if err := feed.WriteURL(out, feed.URL{Loc: h.siteURL + "/", ChangeFreq: "daily", Priority: "1.0"}); err != nil {
+			return err
+		}
+		offset, limit = 0, feed.MaxSitemapURLs-1
+	}
+	err := h.repo.StreamSitemap(ctx, offset, limit, func(e feed.Entry) error {
+		return feed.WriteURL(out, h.sitemapURL(e))
+	})
+	if err != nil {
+		return err
+	}
+	return feed.WriteURLSetEnd(out)
+}
+
+func (h *FeedsHandler) sitemapURL(e feed.Entry) feed.URL {
+	u := feed.URL{LastMod: e.LastMod}
+	switch e.Kind {
+	case feed.KindPost:
+		u.Loc, u.ChangeFreq, u.Priority = h.siteURL+"/posts/"+e.Key, "weekly", "0.8"
+	case feed.KindCategory:
+		u.Loc, u.ChangeFreq, u.Priority = h.siteURL+"/categories/"+e.Key, "daily", "0.6"
+	case feed.KindTag:
+		u.Loc, u.ChangeFreq, u.Priority = h.siteURL+"/tags/"+e.Key, "weekly", "0.4"
+	case feed.KindAuthor:
+		u.Loc, u.ChangeFreq, u.Priority = h.siteURL+"/u/"+e.Key, "weekly", "0.4"
+	case feed.KindListing:
+		u.Loc, u.ChangeFreq, u.Priority = h.storeURL+"/"+e.Key, "weekly", "0.6"
+	}
+	return u
+}
+
+// ── Site-wide feeds ─────────────────────────────────
+
+// RSS handles GET /feed.xml
+func (h *FeedsHandler) RSS(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, formatRSS, feed.Filter{})
+}
+
+// Atom handles GET /atom.xml
+func (h *FeedsHandler) Atom(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, formatAtom, feed.Filter{})
+}
+
+// JSON handles GET /feed.json
+func (h *FeedsHandler) JSON(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, formatJSON, feed.Filter{})
+}
+
+// ── Category / tag feeds ────────────────────────────
+
+// CategoryRSS handles GET /categories/{slug}/feed.xml
+func (h *FeedsHandler) CategoryRSS(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, formatRSS, feed.Filter{CategorySlug: chi.URLParam(r, "slug")})
+}
+
+// CategoryAtom handles GET /categories/{slug}/atom.xml
+func (h *FeedsHandler) CategoryAtom(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, formatAtom, feed.Filter{CategorySlug: chi.URLParam(r, "slug")})
+}
+
+// CategoryJSON handles GET /categories/{slug}/feed.json
+func (h *FeedsHandler) CategoryJSON(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, formatJSON, feed.Filter{CategorySlug: chi.URLParam(r, "slug")})
+}
+
+// TagRSS handles GET /tags/{slug}/feed.xml
+func (h *FeedsHandler) TagRSS(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, formatRSS, feed.Filter{TagSlug: chi.URLParam(r, "slug")})
+}
+
+// TagAtom handles GET /tags/{slug}/atom.xml
+func (h *FeedsHandler) TagAtom(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, formatAtom, feed.Filter{TagSlug: chi.URLParam(r, "slug")})
+}
+
+// TagJSON handles GET /tags/{slug}/feed.json
+func (h *FeedsHandler) TagJSON(w http.ResponseWriter, r *http.Request) {
+	h.serveFeed(w, r, formatJSON, feed.Filter{TagSlug: chi.URLParam(r, "slug")})
+}
+
+func (h *FeedsHandler) serveFeed(w http.ResponseWriter, r *http.Request, format feedFormat, f feed.Filter) {
+	ctx := r.Context()
+
+	settings, _ := h.settingsRepo.GetAll(ctx)
+	ch := feed.Channel{
+		Title:       settings["site_title"],
+		Description: settings["site_description"],
+		Link:        h.siteURL + "/",
+		Language:    "id",
+	}
+	if ch.Title == "" {
+		ch.Title = "NetPulse"
+	}
+
+	var path, scope string
+	switch {
+	case f.CategorySlug != "":
+		name, err := h.repo.FindCategoryName(ctx, f.CategorySlug)
+		if err != nil {
+			utils.JSONError(w, http.StatusNotFound, "category not found")
+			return
+		}
+		ch.Title = name + " — " + ch.Title
+		ch.Link = h.siteURL + "/categories/" + f.CategorySlug
+		path, scope = "/categories/"+f.CategorySlug, "category:"+f.CategorySlug
+	case f.TagSlug != "":
+		name, err := h.repo.FindTagName(ctx, f.TagSlug)
+		if err != nil {
+			utils.JSONError(w, http.StatusNotFound, "tag not found")
+			return
+		}
+		ch.Title = "#" + name + " — " + ch.Title
+		ch.Link = h.siteURL + "/tags/" + f.TagSlug
+		path, scope = "/tags/"+f.TagSlug, "tag:"+f.TagSlug
+	default:
+		scope = "all"
+	}
+
+	var file, contentType, ext string
+	switch format {
+	case formatAtom:
+		file, contentType, ext = "/atom.xml", "application/atom+xml; charset=utf-8", "atom"
+	case formatJSON:
+		file, contentType, ext = "/feed.json", "application/feed+json; charset=utf-8", "json"
+	default:
+		file, contentType, ext = "/feed.xml", "application/rss+xml; charset=utf-8", "rss"
+	}
+	ch.FeedURL = h.siteURL + path + file
+
+	key := "posts:feed:" + ext + ":" + scope
+	h.serveCached(w, r, key, contentType, func(ctx context.Context, out io.Writer) error {
+		items, err := h.repo.FindItems(ctx, f)
+		if err != nil {
+			return err
+		}
+		for i := range items {
+			items[i].Link = h.siteURL + "/posts/" + items[i].Link
+			if items[i].UpdatedAt.After(ch.UpdatedAt) {
+				ch.UpdatedAt = items[i].UpdatedAt
+			}
+		}
+		if ch.UpdatedAt.IsZero() {
+			ch.UpdatedAt = time.Now()
+		}
+
+		switch format {
+		case formatAtom:
+			return feed.WriteAtom(out, ch, items)
+		case formatJSON:
+			return feed.WriteJSONFeed(out, ch, items)
+		default:
+			return feed.WriteRSS(out, ch, items)
+		}
+	})
+}
+
+// serveCached writes the cached body for key if present; otherwise it streams
+// render straight to the client while keeping a copy for the cache.
+func (h *FeedsHandler) serveCached(w http.ResponseWriter, r *http.Request, key, contentType string, render func(context.Context, io.Writer) error) {
+	ctx := r.Context()
+	w.Header().Set("Cache-Control", "public, max-age=900")
+
+	if cached, err := h.cache.Get(ctx, key); err == nil && cached != "" {
+		w.Header().Set("Content-Type", contentType)
+		_, _ = io.WriteString(w, cached)
+		return
+	}
+
+	// An error before the first byte is still reportable as JSON; after that
+	// the response is simply cut short and nothing is cached.
+	var buf bytes.Buffer
+	bw := &firstWriteHeader{w: w, contentType: contentType}
+	if err := render(ctx, io.MultiWriter(bw, &buf)); err != nil {
+		if !bw.wrote {
+			utils.JSONError(w, http.StatusInternalServerError, "failed to build feed")
+		}
+		return
+	}
+
+	_ = h.cache.Set(ctx, key, buf.String(), feedCacheTTL)
+}
+
+// firstWriteHeader sets the content type lazily so an error before the first
+// byte can still be sent as JSON.
+type firstWriteHeader struct {
+	w           http.ResponseWriter
+	contentType string
+	wrote       bool
+}
+
+func (f *firstWriteHeader) Write(p []byte) (int, error) {
+	if !f.wrote {
+		f.w.Header().Set("Content-Type", f.contentType)
+		f.wrote = true
+	}
+	return f.w.Write(p)
+}
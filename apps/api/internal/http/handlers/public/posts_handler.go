@@ -1,6 +1,7 @@
 package public
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
@@ -45,6 +46,18 @@ func (h *PostsHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
 	}
 
 	post, err := h.svc.GetBySlug(r.Context(), slug)
+	var moved *posts.RedirectError
+	if errors.As(err, &moved) {
+		// Old slug of a renamed post: permanent redirect to the current one.
+		w.Header().Set("Location", "/posts/"+moved.Slug)
+		utils.JSONResponse(w, http.StatusMovedPermanently, map[string]string{"redirect_to": moved.Slug})
+		return
+	}
+	if errors.Is(err, posts.ErrGone) {
+		// Archived (e.g. expired) posts are gone for good, so crawlers drop them.
+		utils.JSONError(w, http.StatusGone, err.Error())
+		return
+	}
 	if err != nil {
 		utils.JSONError(w, http.StatusNotFound, "post not found")
 		return
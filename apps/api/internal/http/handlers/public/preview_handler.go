@@ -0,0 +1,46 @@
+package public
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rapidtest/netpulse-api/internal/domain/posts"
+	"github.com/rapidtest/netpulse-api/internal/security"
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+type PreviewHandler struct {
+	svc    *posts.Service
+	signer *security.PreviewSigner
+}
+
+func NewPreviewHandler(svc *posts.Service, signer *security.PreviewSigner) *PreviewHandler {
+	return &PreviewHandler{svc: svc, signer: signer}
+}
+
+// Get handles GET /preview/{token} — returns the post behind a preview link,
+// whatever its status.
+func (h *PreviewHandler) Get(w http.ResponseWriter, r *http.Request) {
+	// Previews must never be cached or indexed.
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Robots-Tag", "noindex, nofollow")
+
+	previewID, err := h.signer.Verify(chi.URLParam(r, "token"))
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, posts.ErrPreviewUnavailable.Error())
+		return
+	}
+
+	post, err := h.svc.GetPreview(r.Context(), previewID)
+	if errors.Is(err, posts.ErrPreviewUnavailable) {
+		utils.JSONError(w, http.StatusNotFound, err.Error())
+		return
+	}
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "post not found")
+		return
+	}
+
+	utils.JSONResponse(w, http.StatusOK, post)
+}
@@ -0,0 +1,28 @@
+package public
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rapidtest/netpulse-api/internal/domain/related"
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+type RelatedHandler struct {
+	svc *related.Service
+}
+
+func NewRelatedHandler(svc *related.Service) *RelatedHandler {
+	return &RelatedHandler{svc: svc}
+}
+
+// List handles GET /posts/{id}/related?limit=5. The post may be given by ID
+// or slug.
+func (h *RelatedHandler) List(w http.ResponseWriter, r *http.Request) {
+	items, err := h.svc.Related(r.Context(), chi.URLParam(r, "id"), utils.QueryInt(r, "limit", related.DefaultLimit))
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "post not found")
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, map[string]interface{}{"items": items})
+}
@@ -0,0 +1,144 @@
+package public
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rapidtest/netpulse-api/internal/domain/comments"
+	"github.com/rapidtest/netpulse-api/internal/domain/reports"
+	"github.com/rapidtest/netpulse-api/internal/http/middleware"
+	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
+	redisRepo "github.com/rapidtest/netpulse-api/internal/repository/redis"
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+type ReportsHandler struct {
+	reportsRepo    *postgres.ReportsRepo
+	commentsRepo   *postgres.CommentsRepo
+	engagementRepo *postgres.EngagementRepo
+	settingsRepo   *postgres.SettingsRepo
+	cache          *redisRepo.Cache
+	auditRepo      *postgres.AuditRepo
+}
+
+func NewReportsHandler(
+	reportsRepo *postgres.ReportsRepo,
+	commentsRepo *postgres.CommentsRepo,
+	engagementRepo *postgres.EngagementRepo,
+	settingsRepo *postgres.SettingsRepo,
+	cache *redisRepo.Cache,
+	auditRepo *postgres.AuditRepo,
+) *ReportsHandler {
+	return &ReportsHandler{
+		reportsRepo:    reportsRepo,
+		commentsRepo:   commentsRepo,
+		engagementRepo: engagementRepo,
+		settingsRepo:   settingsRepo,
+		cache:          cache,
+		auditRepo:      auditRepo,
+	}
+}
+
+// ReportPost handles POST /posts/{id}/report
+func (h *ReportsHandler) ReportPost(w http.ResponseWriter, r *http.Request) {
+	postID, err := h.reportsRepo.PublishedPostID(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to report post")
+		return
+	}
+	if postID == "" {
+		utils.JSONError(w, http.StatusNotFound, "post not found")
+		return
+	}
+	h.file(w, r, reports.TargetPost, postID, postID)
+}
+
+// ReportComment handles POST /posts/{id}/comments/{commentId}/report
+func (h *ReportsHandler) ReportComment(w http.ResponseWriter, r *http.Request) {
+	c, err := h.commentsRepo.FindByID(r.Context(), chi.URLParam(r, "commentId"))
+	if err != nil || c.PostID != chi.URLParam(r, "id") || c.Status != comments.StatusApproved {
+		utils.JSONError(w, http.StatusNotFound, "comment not found")
+		return
+	}
+	h.file(w, r, reports.TargetComment, c.ID, c.PostID)
+}
+
+// file records a report and hides the target once its open reports reach
+// the threshold.
+func (h *ReportsHandler) file(w http.ResponseWriter, r *http.Request, targetType, targetID, postID string) {
+	var input reports.CreateReportInput
+	if err := utils.DecodeJSON(r, &input); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if !reports.ValidReason(input.Reason) {
+		utils.JSONError(w, http.StatusBadRequest, "reason must be one of: "+strings.Join(reports.Reasons, ", "))
+		return
+	}
+	input.Details = sanitizeText(strings.TrimSpace(input.Details))
+	if len(input.Details) > reports.MaxDetails {
+		utils.JSONError(w, http.StatusBadRequest, fmt.Sprintf("details must not exceed %d characters", reports.MaxDetails))
+		return
+	}
+
+	ip := middleware.ExtractIP(r)
+	rep := &reports.Report{
+		Reason:  input.Reason,
+		Details: input.Details,
+		IPHash:  hashIP(ip),
+	}
+	if userID := middleware.GetUserID(r); userID != "" {
+		rep.ReporterID = &userID
+		rep.ReporterKey = "u:" + userID
+	} else {
+		rep.ReporterKey = "g:" + generateGuestKey(ip, r.UserAgent())
+	}
+
+	item, added, err := h.reportsRepo.Add(r.Context(), targetType, targetID, postID, rep)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to save report")
+		return
+	}
+	if !added {
+		utils.JSONError(w, http.StatusConflict, "you have already reported this "+targetType)
+		return
+	}
+
+	if item.HiddenAt == nil {
+		threshold := reports.DefaultHideThresholdComments
+		if targetType == reports.TargetPost {
+			threshold = reports.DefaultHideThresholdPosts
+		}
+		if all, err := h.settingsRepo.GetAll(r.Context()); err == nil {
+			threshold = reports.HideThreshold(all, targetType)
+		}
+		if threshold > 0 && item.OpenReports >= threshold {
+			// Reporters are told apart by IP: guest keys change with the
+			// User-Agent and would let one client hide content alone.
+			if n, err := h.reportsRepo.DistinctReporters(r.Context(), item); err == nil && n >= threshold {
+				h.hide(r, item)
+			}
+		}
+	}
+
+	utils.JSONResponse(w, http.StatusCreated, map[string]string{"message": "report received"})
+}
+
+// hide takes a reported item out of public view until a moderator
+// resolves it.
+func (h *ReportsHandler) hide(r *http.Request, item *reports.Item) {
+	hidden, err := h.reportsRepo.Hide(r.Context(), item)
+	if err != nil || !hidden {
+		return
+	}
+
+	if item.TargetType == reports.TargetComment {
+		h.engagementRepo.IncrementComments(r.Context(), item.PostID, -1)
+	} else {
+		_ = h.cache.DeleteByPrefix(r.Context(), "posts:")
+	}
+	h.auditRepo.Log(r.Context(), "", "report.auto_hide", item.TargetType, item.TargetID,
+		fmt.Sprintf("hidden after %d reports", item.OpenReports), "")
+}
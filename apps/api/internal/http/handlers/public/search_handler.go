@@ -1,63 +1,262 @@
 package public
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/rapidtest/netpulse-api/internal/domain/posts"
+	"github.com/rapidtest/netpulse-api/internal/domain/search"
+	"github.com/rapidtest/netpulse-api/internal/domain/searchlog"
+	"github.com/rapidtest/netpulse-api/internal/http/middleware"
 	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
 	redisRepo "github.com/rapidtest/netpulse-api/internal/repository/redis"
 	"github.com/rapidtest/netpulse-api/internal/utils"
 )
 
 type SearchHandler struct {
-	postsRepo *postgres.PostsRepo
-	cache     *redisRepo.Cache
+	postsRepo  *postgres.PostsRepo
+	searchRepo *postgres.SearchRepo
+	cache      *redisRepo.Cache
+	searchLog  *searchlog.Recorder
+	siteURL    string
+	storeURL   string
 }
 
-func NewSearchHandler(postsRepo *postgres.PostsRepo, cache *redisRepo.Cache) *SearchHandler {
-	return &SearchHandler{postsRepo: postsRepo, cache: cache}
+func NewSearchHandler(postsRepo *postgres.PostsRepo, searchRepo *postgres.SearchRepo, cache *redisRepo.Cache, searchLog *searchlog.Recorder, siteURL, storeURL string) *SearchHandler {
+	return &SearchHandler{
+		postsRepo:  postsRepo,
+		searchRepo: searchRepo,
+		cache:      cache,
+		searchLog:  searchLog,
+		siteURL:    strings.TrimRight(siteURL, "/"),
+		storeURL:   strings.TrimRight(storeURL, "/"),
+	}
+}
+
+// logSearch queues a search for the query log and returns its ID, which
+// clients send back with result clicks.
+func (h *SearchHandler) logSearch(r *http.Request, s searchlog.Search) string {
+	s.ID = utils.NewID()
+	s.Query = searchlog.Normalize(s.Query)
+	s.IPHash = hashIP(middleware.ExtractIP(r))
+	h.searchLog.RecordSearch(s)
+	return s.ID
+}
+
+// queryLanguage returns the "lang" parameter, or the language detected
+// from the query when it is absent.
+func queryLanguage(r *http.Request, q string) (posts.Language, bool) {
+	raw := utils.QueryString(r, "lang", "")
+	if raw == "" {
+		return posts.DetectLanguage(q), true
+	}
+	return posts.ParseLanguage(raw)
 }
 
-// Search handles GET /search?q=...&page=1&limit=10&sort=relevance
+// searchTypeAll ranks every content type together.
+const searchTypeAll = "all"
+
+// postSearchResponse is the body of GET /search.
+type postSearchResponse struct {
+	*posts.PostListResult
+	SearchID string `json:"search_id"`
+}
+
+// unifiedSearchResponse is the body of GET /search?type=all|listing|portfolio.
+type unifiedSearchResponse struct {
+	*search.ResultList
+	SearchID string `json:"search_id"`
+}
+
+// Search handles GET /search?q=...&page=1&limit=10&sort=relevance&lang=id
+// with optional filters type (post|listing|portfolio|all), category, tag
+// (repeatable or comma-separated), author, from and to.
 func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
 	q := utils.QueryString(r, "q", "")
 	if q == "" || len(q) > 120 {
 		utils.JSONError(w, http.StatusBadRequest, "query parameter 'q' is required (max 120 chars)")
 		return
 	}
+	lang, ok := queryLanguage(r, q)
+	if !ok {
+		utils.JSONError(w, http.StatusBadRequest, posts.ErrUnsupportedLanguage.Error())
+		return
+	}
+	contentType := utils.QueryString(r, "type", search.TypePost)
+	if contentType != search.TypePost && contentType != search.TypeListing &&
+		contentType != search.TypePortfolio && contentType != searchTypeAll {
+		utils.JSONError(w, http.StatusBadRequest, "type must be post, listing, portfolio or all")
+		return
+	}
+
+	f := posts.SearchFilter{
+		Query:    q,
+		Language: lang,
+		Category: utils.QueryString(r, "category", ""),
+		AuthorID: utils.QueryString(r, "author", ""),
+		Page:     utils.QueryInt(r, "page", 1),
+		Limit:    utils.QueryInt(r, "limit", 10),
+		Sort:     utils.QueryString(r, "sort", "relevance"),
+	}
+	if f.Page < 1 {
+		f.Page = 1
+	}
+	if f.Limit < 1 || f.Limit > 50 {
+		f.Limit = 10
+	}
+	for _, v := range r.URL.Query()["tag"] {
+		for _, slug := range strings.Split(v, ",") {
+			if slug = strings.TrimSpace(slug); slug != "" {
+				f.Tags = append(f.Tags, slug)
+			}
+		}
+	}
+	var err error
+	if f.From, f.To, err = utils.ParseDateRange(utils.QueryString(r, "from", ""), utils.QueryString(r, "to", "")); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	logged := searchlog.Search{
+		Query:         q,
+		Source:        searchlog.SourceSearch,
+		ContentType:   contentType,
+		Language:      string(lang),
+		FirstPosition: (f.Page-1)*f.Limit + 1,
+	}
 
-	page := utils.QueryInt(r, "page", 1)
-	limit := utils.QueryInt(r, "limit", 10)
-	sort := utils.QueryString(r, "sort", "relevance")
+	if contentType != search.TypePost {
+		sf := search.Filter{Posts: f, Types: search.Types}
+		if contentType != searchTypeAll {
+			sf.Types = []string{contentType}
+		}
+		result, err := h.searchRepo.Search(r.Context(), sf)
+		if err != nil {
+			utils.JSONError(w, http.StatusInternalServerError, "search failed")
+			return
+		}
+		for i := range result.Items {
+			result.Items[i].SetURL(h.siteURL, h.storeURL)
+		}
 
-	result, err := h.postsRepo.Search(r.Context(), q, page, limit, sort)
+		logged.Results, logged.Shown = result.Total, len(result.Items)
+		utils.JSONResponse(w, http.StatusOK, unifiedSearchResponse{ResultList: result, SearchID: h.logSearch(r, logged)})
+		return
+	}
+
+	result, err := h.postsRepo.Search(r.Context(), f)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "search failed")
+		return
+	}
+	counts, err := h.searchRepo.CountByType(r.Context(), search.Filter{Posts: f})
 	if err != nil {
 		utils.JSONError(w, http.StatusInternalServerError, "search failed")
 		return
 	}
+	// Fuzzy matches count as post results.
+	counts[search.TypePost] = result.Total
+	result.Facets.Type = search.TypeFacet(counts)
+
+	logged.Results, logged.Shown = result.Total, len(result.Items)
+	utils.JSONResponse(w, http.StatusOK, postSearchResponse{PostListResult: result, SearchID: h.logSearch(r, logged)})
+}
 
-	utils.JSONResponse(w, http.StatusOK, result)
+// suggestCacheTTL bounds how long suggestions are cached; post changes
+// clear them immediately through the "posts:" prefix.
+const suggestCacheTTL = 10 * time.Minute
+
+// suggestResponse is the body of GET /search/suggest. SearchID is set per
+// request and not cached.
+type suggestResponse struct {
+	Items      []posts.SuggestResult `json:"items"`
+	DidYouMean []string              `json:"did_you_mean"`
+	SearchID   string                `json:"search_id,omitempty"`
 }
 
-// Suggest handles GET /search/suggest?q=...&limit=5
+// Suggest handles GET /search/suggest?q=...&limit=5&lang=id
 func (h *SearchHandler) Suggest(w http.ResponseWriter, r *http.Request) {
 	q := utils.QueryString(r, "q", "")
 	if q == "" || len(q) < 2 || len(q) > 80 {
-		utils.JSONResponse(w, http.StatusOK, []interface{}{})
+		utils.JSONResponse(w, http.StatusOK, suggestResponse{Items: []posts.SuggestResult{}, DidYouMean: []string{}})
+		return
+	}
+	lang, ok := queryLanguage(r, q)
+	if !ok {
+		utils.JSONError(w, http.StatusBadRequest, posts.ErrUnsupportedLanguage.Error())
 		return
 	}
 
 	limit := utils.QueryInt(r, "limit", 5)
+	if limit < 1 || limit > 10 {
+		limit = 5
+	}
 
-	results, err := h.postsRepo.Suggest(r.Context(), q, limit)
-	if err != nil {
-		utils.JSONError(w, http.StatusInternalServerError, "suggest failed")
-		return
+	key := fmt.Sprintf("posts:suggest:%s:%d:%s", lang, limit, strings.ToLower(q))
+	var resp suggestResponse
+	cached, err := h.cache.Get(r.Context(), key)
+	if err != nil || cached == "" || json.Unmarshal([]byte(cached), &resp) != nil {
+		results, err := h.postsRepo.Suggest(r.Context(), q, lang, limit)
+		if err != nil {
+			utils.JSONError(w, http.StatusInternalServerError, "suggest failed")
+			return
+		}
+		corrections, err := h.postsRepo.SuggestCorrections(r.Context(), q, 3)
+		if err != nil {
+			corrections = nil
+		}
+
+		resp = suggestResponse{Items: results, DidYouMean: corrections}
+		if resp.Items == nil {
+			resp.Items = make([]posts.SuggestResult, 0)
+		}
+		if resp.DidYouMean == nil {
+			resp.DidYouMean = make([]string, 0)
+		}
+
+		if data, err := json.Marshal(resp); err == nil {
+			_ = h.cache.Set(r.Context(), key, string(data), suggestCacheTTL)
+		}
 	}
 
-	if results == nil {
-		results = make([]posts.SuggestResult, 0)
+	resp.SearchID = h.logSearch(r, searchlog.Search{
+		Query:         q,
+		Source:        searchlog.SourceSuggest,
+		ContentType:   search.TypePost,
+		Language:      string(lang),
+		Results:       len(resp.Items),
+		FirstPosition: 1,
+		Shown:         len(resp.Items),
+	})
+	utils.JSONResponse(w, http.StatusOK, resp)
+}
+
+// maxClickPosition bounds the result position of a click.
+const maxClickPosition = 1000
+
+// Click handles POST /search/click, sent when a reader opens a search result:
+// {"search_id": "...", "position": 1, "type": "post", "id": "..."}.
+// It accepts navigator.sendBeacon requests, which are sent as text/plain.
+func (h *SearchHandler) Click(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, 4<<10)
+	var c searchlog.Click
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		utils.JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+	if c.ResultType == "" {
+		c.ResultType = search.TypePost
+	}
+	if c.SearchID == "" || len(c.SearchID) > 64 || len(c.ResultID) > 200 ||
+		c.Position < 1 || c.Position > maxClickPosition ||
+		(c.ResultType != search.TypePost && c.ResultType != search.TypeListing && c.ResultType != search.TypePortfolio) {
+		utils.JSONError(w, http.StatusBadRequest, "search_id, position (1-1000) and type (post|listing|portfolio) are required")
+		return
 	}
 
-	utils.JSONResponse(w, http.StatusOK, results)
+	h.searchLog.RecordClick(c)
+	utils.JSONResponse(w, http.StatusAccepted, map[string]string{"message": "recorded"})
 }
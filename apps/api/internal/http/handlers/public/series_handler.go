@@ -0,0 +1,41 @@
+package public
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/rapidtest/netpulse-api/internal/domain/series"
+	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+type SeriesHandler struct {
+	repo *postgres.SeriesRepo
+}
+
+func NewSeriesHandler(repo *postgres.SeriesRepo) *SeriesHandler {
+	return &SeriesHandler{repo: repo}
+}
+
+// List handles GET /series — series that have at least one published post.
+func (h *SeriesHandler) List(w http.ResponseWriter, r *http.Request) {
+	items, err := h.repo.FindAll(r.Context(), true)
+	if err != nil {
+		utils.JSONError(w, http.StatusInternalServerError, "failed to fetch series")
+		return
+	}
+	if items == nil {
+		items = make([]series.Series, 0)
+	}
+	utils.JSONResponse(w, http.StatusOK, items)
+}
+
+// GetBySlug handles GET /series/{slug} — the series with its published posts in order.
+func (h *SeriesHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
+	s, err := h.repo.FindBySlug(r.Context(), chi.URLParam(r, "slug"))
+	if err != nil {
+		utils.JSONError(w, http.StatusNotFound, "series not found")
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, s)
+}
@@ -3,6 +3,8 @@ package public
 import (
 	"net/http"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/rapidtest/netpulse-api/internal/domain/posts"
 	"github.com/rapidtest/netpulse-api/internal/repository/postgres"
 	"github.com/rapidtest/netpulse-api/internal/utils"
 )
@@ -15,11 +17,32 @@ func NewTagsHandler(repo *postgres.TagsRepo) *TagsHandler {
 	return &TagsHandler{repo: repo}
 }
 
+// List returns all tags with published post counts.
 func (h *TagsHandler) List(w http.ResponseWriter, r *http.Request) {
-	tags, err := h.repo.FindAll(r.Context())
+	tags, err := h.repo.FindAll(r.Context(), true)
 	if err != nil {
 		utils.JSONError(w, http.StatusInternalServerError, "failed to fetch tags")
 		return
 	}
+	if tags == nil {
+		tags = make([]posts.Tag, 0)
+	}
 	utils.JSONResponse(w, http.StatusOK, tags)
 }
+
+// GetBySlug returns a tag. Slugs of renamed or merged tags 301 to the current tag.
+func (h *TagsHandler) GetBySlug(w http.ResponseWriter, r *http.Request) {
+	slug := chi.URLParam(r, "slug")
+
+	tag, err := h.repo.FindBySlug(r.Context(), slug)
+	if err != nil {
+		if current, rerr := h.repo.FindSlugRedirect(r.Context(), slug); rerr == nil && current != "" {
+			w.Header().Set("Location", "/tags/"+current)
+			utils.JSONResponse(w, http.StatusMovedPermanently, map[string]string{"redirect_to": current})
+			return
+		}
+		utils.JSONError(w, http.StatusNotFound, "tag not found")
+		return
+	}
+	utils.JSONResponse(w, http.StatusOK, tag)
+}
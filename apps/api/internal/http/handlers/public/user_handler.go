@@ -52,6 +52,7 @@ func (h *UserHandler) GetPublicProfile(w http.ResponseWriter, r *http.Request) {
 	profile := map[string]interface{}{
 		"id":               user.ID,
 		"name":             user.Name,
+		"username":         user.Username,
 		"avatar":           user.Avatar,
 		"bio":              user.Bio,
 		"website":          user.Website,
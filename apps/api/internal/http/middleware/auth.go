@@ -53,6 +53,22 @@ func (m *AuthMiddleware) Authenticate(next http.Handler) http.Handler {
 	})
 }
 
+// OptionalAuthenticate sets the user on the request context when a valid
+// access token is present, and otherwise serves the request as a guest.
+func (m *AuthMiddleware) OptionalAuthenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+		if len(parts) == 2 && strings.EqualFold(parts[0], "bearer") {
+			if claims, err := m.tokenSvc.ValidateAccessToken(parts[1]); err == nil {
+				ctx := context.WithValue(r.Context(), CtxUserID, claims.UserID)
+				ctx = context.WithValue(ctx, CtxUserRole, claims.Role)
+				r = r.WithContext(ctx)
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
 // RequireRole returns middleware that checks for a specific role.
 func RequireRole(roles ...string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
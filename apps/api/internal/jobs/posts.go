@@ -0,0 +1,61 @@
+package jobs
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+
+	"github.com/rapidtest/netpulse-api/internal/domain/posts"
+)
+
+// AuditLogger records system actions in audit_logs.
+type AuditLogger interface {
+	Log(ctx context.Context, userID, action, entity, entityID, details, ip string) error
+}
+
+// SearchTermsRefresher rebuilds the terms behind "did you mean" suggestions.
+type SearchTermsRefresher interface {
+	RefreshSearchTerms(ctx context.Context) error
+}
+
+// PublishScheduledPosts returns a job that publishes SCHEDULED posts whose
+// scheduled_at has passed. Due rows are claimed with FOR UPDATE SKIP LOCKED,
+// so concurrent replicas never publish the same post twice.
+func PublishScheduledPosts(svc *posts.Service, audit AuditLogger) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		published, err := svc.PublishDue(ctx)
+		if err != nil {
+			return err
+		}
+		for _, p := range published {
+			_ = audit.Log(ctx, "", "publish", "post", p.ID, "Scheduled post auto-published: "+p.Title, "")
+			log.Info().Str("post_id", p.ID).Msg("scheduled post published")
+		}
+		return nil
+	}
+}
+
+// ArchiveExpiredPosts returns a job that archives PUBLISHED posts whose
+// expires_at has passed. Like publishing, rows are claimed with FOR UPDATE
+// SKIP LOCKED.
+func ArchiveExpiredPosts(svc *posts.Service, audit AuditLogger) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		archived, err := svc.ArchiveExpired(ctx)
+		if err != nil {
+			return err
+		}
+		for _, p := range archived {
+			_ = audit.Log(ctx, "", "archive", "post", p.ID, "Expired post auto-archived: "+p.Title, "")
+			log.Info().Str("post_id", p.ID).Msg("expired post archived")
+		}
+		return nil
+	}
+}
+
+// RefreshSearchTerms returns a job that refreshes the search_terms view so
+// suggestions pick up newly published titles and new tags.
+func RefreshSearchTerms(repo SearchTermsRefresher) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		return repo.RefreshSearchTerms(ctx)
+	}
+}
@@ -0,0 +1,84 @@
+package jobs
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// Job is a unit of background work executed on a fixed interval.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) error
+}
+
+// Runner executes registered jobs in-process until its context is cancelled.
+// Jobs must be safe to run concurrently on several API replicas; the runner
+// itself does no cross-process coordination.
+type Runner struct {
+	jobs []Job
+	wg   sync.WaitGroup
+}
+
+func NewRunner() *Runner {
+	return &Runner{}
+}
+
+// Register adds a job. It must be called before Start. The interval must
+// be positive; otherwise the job is not registered.
+func (r *Runner) Register(name string, interval time.Duration, fn func(ctx context.Context) error) {
+	if interval <= 0 {
+		log.Error().Str("job", name).Dur("interval", interval).Msg("job interval must be positive, job not registered")
+		return
+	}
+	r.jobs = append(r.jobs, Job{Name: name, Interval: interval, Run: fn})
+}
+
+// Start launches one goroutine per job. Each job runs once immediately and
+// then on every tick until ctx is cancelled.
+func (r *Runner) Start(ctx context.Context) {
+	for _, job := range r.jobs {
+		r.wg.Add(1)
+		go r.loop(ctx, job)
+	}
+	log.Info().Int("jobs", len(r.jobs)).Msg("background jobs started")
+}
+
+// Wait blocks until all job goroutines have returned.
+func (r *Runner) Wait() {
+	r.wg.Wait()
+}
+
+func (r *Runner) loop(ctx context.Context, job Job) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		r.runOnce(ctx, job)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Runner) runOnce(ctx context.Context, job Job) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			log.Error().Str("job", job.Name).Interface("panic", rec).Msg("job panicked")
+		}
+	}()
+
+	start := time.Now()
+	if err := job.Run(ctx); err != nil && ctx.Err() == nil {
+		log.Error().Err(err).Str("job", job.Name).Msg("job failed")
+		return
+	}
+	log.Debug().Str("job", job.Name).Dur("took", time.Since(start)).Msg("job finished")
+}
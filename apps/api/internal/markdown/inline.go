@@ -0,0 +1,318 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	entityRe   = regexp.MustCompile(`^&(?:[A-Za-z][A-Za-z0-9]{1,31}|#[0-9]{1,7}|#[xX][0-9A-Fa-f]{1,6});`)
+	autolinkRe = regexp.MustCompile(`^<((?:https?://|mailto:)[^\s<>]+)>`)
+	inlineTag  = regexp.MustCompile(`^</?[A-Za-z][A-Za-z0-9-]*(?:\s+[A-Za-z_:][A-Za-z0-9_.:-]*(?:\s*=\s*(?:"[^"]*"|'[^']*'|[^\s"'=<>` + "`" + `]+))?)*\s*/?>`)
+)
+
+const punctuation = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+
+// inline renders span-level Markdown. Text is HTML-escaped; raw tags are
+// passed through for Sanitize to filter.
+func (r *renderer) inline(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch c {
+		case '\\':
+			if i+1 < len(s) && s[i+1] == '\n' {
+				b.WriteString("<br>\n")
+				i += 2
+				continue
+			}
+			if i+1 < len(s) && strings.IndexByte(punctuation, s[i+1]) >= 0 {
+				b.WriteString(html.EscapeString(s[i+1 : i+2]))
+				i += 2
+				continue
+			}
+			b.WriteByte('\\')
+			i++
+
+		case ' ':
+			// Two or more trailing spaces make a hard break.
+			n := runLength(s, i, ' ')
+			if i+n < len(s) && s[i+n] == '\n' {
+				if n >= 2 {
+					b.WriteString("<br>")
+				}
+				i += n
+				continue
+			}
+			b.WriteString(s[i : i+n])
+			i += n
+
+		case '`':
+			n := runLength(s, i, '`')
+			if end := findRun(s, i+n, '`', n); end >= 0 {
+				code := s[i+n : end]
+				if len(code) > 2 && code[0] == ' ' && code[len(code)-1] == ' ' && strings.Trim(code, " ") != "" {
+					code = code[1 : len(code)-1]
+				}
+				b.WriteString("<code>")
+				b.WriteString(html.EscapeString(strings.ReplaceAll(code, "\n", " ")))
+				b.WriteString("</code>")
+				i = end + n
+				continue
+			}
+			b.WriteString(s[i : i+n])
+			i += n
+
+		case '!':
+			if i+1 < len(s) && s[i+1] == '[' {
+				if text, dest, title, end, ok := parseLink(s, i+1); ok {
+					b.WriteString(`<img src="` + html.EscapeString(dest) + `" alt="` +
+						html.EscapeString(html.UnescapeString(StripTags(r.inline(text)))) + `"`)
+					if title != "" {
+						b.WriteString(` title="` + html.EscapeString(title) + `"`)
+					}
+					b.WriteString(` loading="lazy">`)
+					i = end
+					continue
+				}
+			}
+			b.WriteByte('!')
+			i++
+
+		case '[':
+			if text, dest, title, end, ok := parseLink(s, i); ok {
+				b.WriteString(`<a href="` + html.EscapeString(dest) + `"`)
+				if title != "" {
+					b.WriteString(` title="` + html.EscapeString(title) + `"`)
+				}
+				b.WriteString(">")
+				b.WriteString(r.inline(text))
+				b.WriteString("</a>")
+				i = end
+				continue
+			}
+			b.WriteByte('[')
+			i++
+
+		case '<':
+			if m := autolinkRe.FindStringSubmatch(s[i:]); m != nil {
+				label := strings.TrimPrefix(m[1], "mailto:")
+				b.WriteString(`<a href="` + html.EscapeString(m[1]) + `">` + html.EscapeString(label) + `</a>`)
+				i += len(m[0])
+				continue
+			}
+			if strings.HasPrefix(s[i:], "<!--") {
+				if end := strings.Index(s[i+4:], "-->"); end >= 0 {
+					i += 4 + end + 3
+					continue
+				}
+			}
+			if m := inlineTag.FindString(s[i:]); m != "" {
+				b.WriteString(m)
+				i += len(m)
+				continue
+			}
+			b.WriteString("&lt;")
+			i++
+
+		case '&':
+			if m := entityRe.FindString(s[i:]); m != "" {
+				b.WriteString(m)
+				i += len(m)
+				continue
+			}
+			b.WriteString("&amp;")
+			i++
+
+		case '*', '_':
+			n := runLength(s, i, c)
+			if out, end, ok := r.emphasis(s, i, c, n); ok {
+				b.WriteString(out)
+				i = end
+				continue
+			}
+			b.WriteString(s[i : i+n])
+			i += n
+
+		case '~':
+			if strings.HasPrefix(s[i:], "~~") && i+2 < len(s) && s[i+2] != ' ' {
+				if end := strings.Index(s[i+2:], "~~"); end > 0 {
+					b.WriteString("<del>")
+					b.WriteString(r.inline(s[i+2 : i+2+end]))
+					b.WriteString("</del>")
+					i += 2 + end + 2
+					continue
+				}
+			}
+			b.WriteByte('~')
+			i++
+
+		case '>':
+			b.WriteString("&gt;")
+			i++
+
+		case '"':
+			b.WriteString("&#34;")
+			i++
+
+		default:
+			b.WriteByte(c)
+			i++
+		}
+	}
+	return b.String()
+}
+
+// emphasis handles a run of n '*' or '_' at i. Runs of 1, 2 and 3 map to
+// <em>, <strong> and both; the closing run must have the same length.
+func (r *renderer) emphasis(s string, i int, c byte, n int) (string, int, bool) {
+	if n > 3 || i+n >= len(s) || isSpace(s[i+n]) {
+		return "", 0, false
+	}
+	// Underscores inside words are literal (snake_case).
+	if c == '_' && i > 0 && isWordByte(s[i-1]) {
+		return "", 0, false
+	}
+
+	for j := i + n; j < len(s); {
+		switch s[j] {
+		case '`':
+			// Delimiters inside code spans don't count.
+			m := runLength(s, j, '`')
+			if end := findRun(s, j+m, '`', m); end >= 0 {
+				j = end + m
+			} else {
+				j += m
+			}
+			continue
+		case '\\':
+			j += 2
+			continue
+		case c:
+			m := runLength(s, j, c)
+			closes := m == n && !isSpace(s[j-1])
+			if c == '_' && j+m < len(s) && isWordByte(s[j+m]) {
+				closes = false
+			}
+			if closes {
+				inner := r.inline(s[i+n : j])
+				switch n {
+				case 1:
+					inner = "<em>" + inner + "</em>"
+				case 2:
+					inner = "<strong>" + inner + "</strong>"
+				default:
+					inner = "<strong><em>" + inner + "</em></strong>"
+				}
+				return inner, j + m, true
+			}
+			j += m
+			continue
+		}
+		j++
+	}
+	return "", 0, false
+}
+
+// parseLink parses [text](dest "title") starting at the '[' at i.
+func parseLink(s string, i int) (text, dest, title string, end int, ok bool) {
+	depth := 0
+	j := i
+	for ; j < len(s); j++ {
+		switch s[j] {
+		case '\\':
+			j++
+		case '[':
+			depth++
+		case ']':
+			depth--
+		}
+		if depth == 0 {
+			break
+		}
+	}
+	if j >= len(s) || j+1 >= len(s) || s[j+1] != '(' {
+		return "", "", "", 0, false
+	}
+	text = s[i+1 : j]
+
+	k := j + 2
+	for k < len(s) && isSpace(s[k]) {
+		k++
+	}
+	var rest string
+	if k < len(s) && s[k] == '<' {
+		close := strings.IndexByte(s[k:], '>')
+		if close < 0 {
+			return "", "", "", 0, false
+		}
+		dest = s[k+1 : k+close]
+		k += close + 1
+	} else {
+		parens := 0
+		start := k
+		for ; k < len(s) && !isSpace(s[k]); k++ {
+			if s[k] == '(' {
+				parens++
+			} else if s[k] == ')' {
+				if parens == 0 {
+					break
+				}
+				parens--
+			}
+		}
+		dest = s[start:k]
+	}
+	for k < len(s) && isSpace(s[k]) {
+		k++
+	}
+	if k < len(s) && (s[k] == '"' || s[k] == '\'') {
+		q := s[k]
+		close := strings.IndexByte(s[k+1:], q)
+		if close < 0 {
+			return "", "", "", 0, false
+		}
+		rest = s[k+1 : k+1+close]
+		k += close + 2
+		for k < len(s) && isSpace(s[k]) {
+			k++
+		}
+	}
+	if k >= len(s) || s[k] != ')' {
+		return "", "", "", 0, false
+	}
+	return text, html.UnescapeString(dest), html.UnescapeString(rest), k + 1, true
+}
+
+func runLength(s string, i int, c byte) int {
+	n := 0
+	for i+n < len(s) && s[i+n] == c {
+		n++
+	}
+	return n
+}
+
+// findRun returns the index of the next run of exactly n c's at or after i.
+func findRun(s string, i int, c byte, n int) int {
+	for i < len(s) {
+		if s[i] != c {
+			i++
+			continue
+		}
+		m := runLength(s, i, c)
+		if m == n {
+			return i
+		}
+		i += m
+	}
+	return -1
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\n' || c == '\t'
+}
+
+func isWordByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c >= 0x80
+}
@@ -0,0 +1,415 @@
+// Package markdown renders post bodies to sanitized HTML.
+//
+// It implements the subset of CommonMark/GFM that the editor produces:
+// ATX headings, paragraphs, emphasis, strikethrough, code spans, fenced code
+// blocks, block quotes, ordered and unordered lists, pipe tables, thematic
+// breaks, links, images and inline HTML. All output passes through Sanitize.
+package markdown
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+// WordsPerMinute is the reading speed used for ReadingTime.
+const WordsPerMinute = 200
+
+// maxTOCLevel is the deepest heading level listed in the table of contents.
+const maxTOCLevel = 3
+
+// TOCEntry is one heading in a post's table of contents.
+type TOCEntry struct {
+	Level int    `json:"level"`
+	ID    string `json:"id"`
+	Text  string `json:"text"`
+}
+
+// Result is the rendered form of a Markdown document.
+type Result struct {
+	HTML        string     `json:"html"`
+	TOC         []TOCEntry `json:"toc"`
+	WordCount   int        `json:"word_count"`
+	ReadingTime int        `json:"reading_time"` // minutes, at least 1
+}
+
+// Render converts Markdown to sanitized HTML and collects the TOC and
+// reading-time estimate.
+func Render(src string) *Result {
+	src = strings.ReplaceAll(src, "\r\n", "\n")
+	src = strings.ReplaceAll(src, "\r", "\n")
+	src = strings.ReplaceAll(src, "\t", "    ")
+
+	r := &renderer{ids: map[string]int{}}
+	var b strings.Builder
+	r.blocks(&b, strings.Split(src, "\n"), false)
+
+	out := Sanitize(b.String())
+	words := len(strings.Fields(html.UnescapeString(StripTags(out))))
+	minutes := (words + WordsPerMinute - 1) / WordsPerMinute
+	if minutes < 1 {
+		minutes = 1
+	}
+
+	toc := r.toc
+	if toc == nil {
+		toc = make([]TOCEntry, 0)
+	}
+	return &Result{HTML: out, TOC: toc, WordCount: words, ReadingTime: minutes}
+}
+
+type renderer struct {
+	ids map[string]int
+	toc []TOCEntry
+}
+
+var (
+	atxRe      = regexp.MustCompile(`^ {0,3}(#{1,6})(?:[ ]+(.*?))?(?:[ ]+#+)?[ ]*$`)
+	fenceRe    = regexp.MustCompile("^( {0,3})(`{3,}|~{3,})[ ]*([^`]*)$")
+	hrRe       = regexp.MustCompile(`^ {0,3}(?:(?:\*[ ]*){3,}|(?:-[ ]*){3,}|(?:_[ ]*){3,})$`)
+	bulletRe   = regexp.MustCompile(`^( {0,3})([-*+])(?:[ ]+(.*))?$`)
+	orderedRe  = regexp.MustCompile(`^( {0,3})(\d{1,9})([.)])(?:[ ]+(.*))?$`)
+	quoteRe    = regexp.MustCompile(`^ {0,3}> ?(.*)$`)
+	htmlOpenRe = regexp.MustCompile(`^ {0,3}</?[A-Za-z][A-Za-z0-9-]*(?:[\s/>]|$)|^ {0,3}<!--`)
+	tableSepRe = regexp.MustCompile(`^ {0,3}\|?[ ]*:?-+:?[ ]*(?:\|[ ]*:?-+:?[ ]*)*\|?[ ]*$`)
+	langRe     = regexp.MustCompile(`[^A-Za-z0-9_+#.-]`)
+)
+
+func isBlank(s string) bool { return strings.TrimSpace(s) == "" }
+
+// blocks renders a sequence of lines. tight is set for list items without
+// blank lines, whose paragraphs are emitted without <p> wrappers.
+func (r *renderer) blocks(b *strings.Builder, lines []string, tight bool) {
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		switch {
+		case isBlank(line):
+			i++
+
+		case fenceRe.MatchString(line):
+			i = r.fencedCode(b, lines, i)
+
+		case atxRe.MatchString(line):
+			m := atxRe.FindStringSubmatch(line)
+			r.heading(b, len(m[1]), m[2])
+			i++
+
+		case hrRe.MatchString(line):
+			b.WriteString("<hr>\n")
+			i++
+
+		case quoteRe.MatchString(line):
+			var inner []string
+			for i < len(lines) && !isBlank(lines[i]) {
+				if m := quoteRe.FindStringSubmatch(lines[i]); m != nil {
+					inner = append(inner, m[1])
+				} else if len(inner) > 0 && !r.startsBlock(lines[i]) {
+					inner = append(inner, lines[i]) // lazy continuation
+				} else {
+					break
+				}
+				i++
+			}
+			b.WriteString("<blockquote>\n")
+			r.blocks(b, inner, false)
+			b.WriteString("</blockquote>\n")
+
+		case bulletRe.MatchString(line) || orderedRe.MatchString(line):
+			i = r.list(b, lines, i)
+
+		case i+1 < len(lines) && strings.Contains(line, "|") && tableSepRe.MatchString(lines[i+1]) &&
+			strings.Contains(lines[i+1], "-"):
+			i = r.table(b, lines, i)
+
+		case htmlOpenRe.MatchString(line):
+			start := i
+			for i < len(lines) && !isBlank(lines[i]) {
+				i++
+			}
+			b.WriteString(strings.Join(lines[start:i], "\n"))
+			b.WriteString("\n")
+
+		default:
+			start := i
+			i++
+			for i < len(lines) && !isBlank(lines[i]) && !r.startsBlock(lines[i]) {
+				i++
+			}
+			text := strings.TrimSpace(strings.Join(lines[start:i], "\n"))
+			if tight {
+				b.WriteString(r.inline(text))
+				b.WriteString("\n")
+			} else {
+				b.WriteString("<p>")
+				b.WriteString(r.inline(text))
+				b.WriteString("</p>\n")
+			}
+		}
+	}
+}
+
+// startsBlock reports whether line interrupts a paragraph.
+func (r *renderer) startsBlock(line string) bool {
+	return fenceRe.MatchString(line) || atxRe.MatchString(line) || hrRe.MatchString(line) ||
+		quoteRe.MatchString(line) || bulletRe.MatchString(line) || orderedRe.MatchString(line) ||
+		htmlOpenRe.MatchString(line)
+}
+
+func (r *renderer) fencedCode(b *strings.Builder, lines []string, i int) int {
+	m := fenceRe.FindStringSubmatch(lines[i])
+	indent, fence := len(m[1]), m[2]
+	lang := ""
+	if f := strings.Fields(m[3]); len(f) > 0 {
+		lang = langRe.ReplaceAllString(f[0], "")
+	}
+
+	var code []string
+	i++
+	for ; i < len(lines); i++ {
+		t := strings.TrimLeft(lines[i], " ")
+		if len(lines[i])-len(t) <= 3 && strings.HasPrefix(t, fence[:1]) &&
+			len(strings.TrimRight(t, " ")) >= len(fence) &&
+			strings.Trim(strings.TrimRight(t, " "), fence[:1]) == "" {
+			i++
+			break
+		}
+		// Drop up to the opening fence's indentation from content lines.
+		l := lines[i]
+		for n := 0; n < indent && strings.HasPrefix(l, " "); n++ {
+			l = l[1:]
+		}
+		code = append(code, l)
+	}
+
+	if lang != "" {
+		fmt.Fprintf(b, `<pre class="code-block" data-lang="%s"><code class="language-%s">`, lang, lang)
+	} else {
+		b.WriteString(`<pre class="code-block"><code>`)
+	}
+	for _, l := range code {
+		b.WriteString(html.EscapeString(l))
+		b.WriteString("\n")
+	}
+	b.WriteString("</code></pre>\n")
+	return i
+}
+
+func (r *renderer) heading(b *strings.Builder, level int, text string) {
+	inner := r.inline(strings.TrimSpace(text))
+	plain := strings.TrimSpace(html.UnescapeString(StripTags(inner)))
+
+	id := utils.Slugify(plain)
+	if id == "" {
+		id = "section"
+	}
+	// Repeats get -1, -2, ...; skip suffixes an earlier heading already
+	// produced (e.g. "A", "A 1", "A" gives a, a-1, a-2).
+	if n, taken := r.ids[id]; taken {
+		base := id
+		for taken {
+			n++
+			id = base + "-" + strconv.Itoa(n)
+			_, taken = r.ids[id]
+		}
+		r.ids[base] = n
+	}
+	r.ids[id] = 0
+
+	if level <= maxTOCLevel {
+		r.toc = append(r.toc, TOCEntry{Level: level, ID: id, Text: plain})
+	}
+	fmt.Fprintf(b, `<h%d id="%s">%s <a class="heading-anchor" href="#%s" aria-hidden="true">#</a></h%d>`+"\n",
+		level, id, inner, id, level)
+}
+
+// listItemStart parses a list marker and returns whether it is ordered, the
+// start number, the marker's delimiter, the content column and first-line text.
+func listItemStart(line string) (ok, ordered bool, start int, delim, content int, text string) {
+	if m := bulletRe.FindStringSubmatch(line); m != nil {
+		return true, false, 0, int(m[2][0]), contentColumn(line, len(m[1])+1), m[3]
+	}
+	if m := orderedRe.FindStringSubmatch(line); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		return true, true, n, int(m[3][0]), contentColumn(line, len(m[1])+len(m[2])+1), m[4]
+	}
+	return false, false, 0, 0, 0, ""
+}
+
+// contentColumn returns where item content begins after a marker ending at col.
+func contentColumn(line string, col int) int {
+	n := col
+	for n < len(line) && line[n] == ' ' && n-col < 4 {
+		n++
+	}
+	if n == col || n >= len(line) {
+		return col + 1
+	}
+	return n
+}
+
+func (r *renderer) list(b *strings.Builder, lines []string, i int) int {
+	_, ordered, start, delim, _, _ := listItemStart(lines[i])
+
+	type item struct{ lines []string }
+	var items []item
+	loose := false
+
+	for i < len(lines) {
+		ok, o, _, d, col, text := listItemStart(lines[i])
+		if !ok || o != ordered || d != delim {
+			break
+		}
+		it := item{lines: []string{text}}
+		i++
+		for i < len(lines) {
+			line := lines[i]
+			if isBlank(line) {
+				// A blank line continues the item only if indented content follows.
+				j := i
+				for j < len(lines) && isBlank(lines[j]) {
+					j++
+				}
+				if j < len(lines) && indentOf(lines[j]) >= col {
+					for ; i < j; i++ {
+						it.lines = append(it.lines, "")
+					}
+					loose = true
+					continue
+				}
+				if j < len(lines) {
+					if ok2, o2, _, d2, _, _ := listItemStart(lines[j]); ok2 && o2 == ordered && d2 == delim {
+						loose = true
+					}
+				}
+				i = j
+				break
+			}
+			if indentOf(line) >= col {
+				it.lines = append(it.lines, line[col:])
+			} else if ok2, _, _, _, _, _ := listItemStart(line); ok2 {
+				break
+			} else if r.startsBlock(line) {
+				break
+			} else {
+				it.lines = append(it.lines, strings.TrimLeft(line, " ")) // lazy continuation
+			}
+			i++
+		}
+		items = append(items, it)
+	}
+
+	tag := "ul"
+	if ordered {
+		tag = "ol"
+	}
+	if ordered && start != 1 {
+		fmt.Fprintf(b, "<ol start=\"%d\">\n", start)
+	} else {
+		fmt.Fprintf(b, "<%s>\n", tag)
+	}
+	for _, it := range items {
+		b.WriteString("<li>")
+		var inner strings.Builder
+		r.blocks(&inner, it.lines, !loose)
+		b.WriteString(strings.TrimSuffix(inner.String(), "\n"))
+		b.WriteString("</li>\n")
+	}
+	fmt.Fprintf(b, "</%s>\n", tag)
+	return i
+}
+
+func indentOf(s string) int {
+	return len(s) - len(strings.TrimLeft(s, " "))
+}
+
+func (r *renderer) table(b *strings.Builder, lines []string, i int) int {
+	header := splitRow(lines[i])
+	var aligns []string
+	for _, c := range splitRow(lines[i+1]) {
+		c = strings.TrimSpace(c)
+		switch {
+		case strings.HasPrefix(c, ":") && strings.HasSuffix(c, ":"):
+			aligns = append(aligns, "center")
+		case strings.HasSuffix(c, ":"):
+			aligns = append(aligns, "right")
+		case strings.HasPrefix(c, ":"):
+			aligns = append(aligns, "left")
+		default:
+			aligns = append(aligns, "")
+		}
+	}
+	i += 2
+
+	cell := func(tag string, col int, text string) {
+		if col < len(aligns) && aligns[col] != "" {
+			fmt.Fprintf(b, `<%s align="%s">`, tag, aligns[col])
+		} else {
+			fmt.Fprintf(b, "<%s>", tag)
+		}
+		b.WriteString(r.inline(strings.TrimSpace(text)))
+		fmt.Fprintf(b, "</%s>", tag)
+	}
+
+	b.WriteString("<table>\n<thead>\n<tr>")
+	for c, h := range header {
+		cell("th", c, h)
+	}
+	b.WriteString("</tr>\n</thead>\n")
+
+	rows := 0
+	for ; i < len(lines) && !isBlank(lines[i]) && strings.Contains(lines[i], "|"); i++ {
+		if rows == 0 {
+			b.WriteString("<tbody>\n")
+		}
+		rows++
+		b.WriteString("<tr>")
+		cells := splitRow(lines[i])
+		for c := range header {
+			text := ""
+			if c < len(cells) {
+				text = cells[c]
+			}
+			cell("td", c, text)
+		}
+		b.WriteString("</tr>\n")
+	}
+	if rows > 0 {
+		b.WriteString("</tbody>\n")
+	}
+	b.WriteString("</table>\n")
+	return i
+}
+
+// splitRow splits a pipe-table row, honouring escaped pipes and code spans.
+func splitRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells []string
+	var cur strings.Builder
+	inCode := false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cur.WriteByte('|')
+			i++
+		case c == '`':
+			inCode = !inCode
+			cur.WriteByte(c)
+		case c == '|' && !inCode:
+			cells = append(cells, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(c)
+		}
+	}
+	return append(cells, cur.String())
+}
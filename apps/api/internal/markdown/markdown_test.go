@@ -0,0 +1,111 @@
+package markdown
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// unsafeMarkupRe matches markup that would run script if the output were
+// inserted as HTML. Escaped text such as "&lt;img onerror=...&gt;" is fine.
+var unsafeMarkupRe = regexp.MustCompile(`(?i)<script|<[a-z][^>]*\son[a-z]+=|(?:href|src)="\s*javascript:`)
+
+func TestRenderTOCAnchors(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		want []string
+	}{
+		{"unique", "# One\n\n## Two", []string{"one", "two"}},
+		{"repeated", "# A\n\n# A\n\n# A", []string{"a", "a-1", "a-2"}},
+		{"suffix already taken", "# A\n\n# A 1\n\n# A", []string{"a", "a-1", "a-2"}},
+		{"repeat of a suffixed id", "# A\n\n# A\n\n# A 1", []string{"a", "a-1", "a-1-1"}},
+		{"punctuation only", "# !!!\n\n# ???", []string{"section", "section-1"}},
+		{"same slug", "## Hello, World!\n\n### Hello World", []string{"hello-world", "hello-world-1"}},
+		{"markup in heading", "# *Big* `code`", []string{"big-code"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res := Render(tt.src)
+			var ids []string
+			for _, e := range res.TOC {
+				ids = append(ids, e.ID)
+				if !strings.Contains(res.HTML, `id="`+e.ID+`"`) {
+					t.Errorf("heading with id %q missing from %q", e.ID, res.HTML)
+				}
+			}
+			if !reflect.DeepEqual(ids, tt.want) {
+				t.Errorf("TOC ids = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderTOCLevels(t *testing.T) {
+	res := Render("# One\n\n#### Four\n\n### Three")
+	want := []TOCEntry{{Level: 1, ID: "one", Text: "One"}, {Level: 3, ID: "three", Text: "Three"}}
+	if !reflect.DeepEqual(res.TOC, want) {
+		t.Errorf("TOC = %+v, want %+v", res.TOC, want)
+	}
+	if empty := Render("text"); empty.TOC == nil || len(empty.TOC) != 0 {
+		t.Errorf("TOC without headings = %#v, want empty slice", empty.TOC)
+	}
+}
+
+func TestRenderUnsafeInput(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+	}{
+		{"javascript link", "[x](javascript:alert(1))"},
+		{"reference link", "[x][r]\n\n[r]: javascript:alert(1)"},
+		{"entity-encoded link", "[x](&#106;avascript:alert(1))"},
+		{"javascript image", "![i](javascript:alert(1))"},
+		{"autolink", "<javascript:alert(1)>"},
+		{"script block", "<script>alert(1)</script>\n\ntext"},
+		{"inline handler", "text <img src=x onerror=alert(1)> more"},
+		{"unterminated tag", "text <img src=x' onerror=alert(1)>"},
+		{"link title", `[x](/a "t\" onmouseover=\"alert(1)")`},
+		{"code fence language", "```\" onclick=\"alert(1)\n1\n```"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if out := Render(tt.src).HTML; unsafeMarkupRe.MatchString(out) {
+				t.Errorf("Render(%q) = %q, contains unsafe markup", tt.src, out)
+			}
+		})
+	}
+}
+
+func TestRenderReadingTime(t *testing.T) {
+	tests := []struct {
+		words, minutes int
+	}{
+		{0, 1},
+		{1, 1},
+		{WordsPerMinute, 1},
+		{WordsPerMinute + 1, 2},
+	}
+	for _, tt := range tests {
+		res := Render(strings.TrimSpace(strings.Repeat("word ", tt.words)))
+		if res.WordCount != tt.words || res.ReadingTime != tt.minutes {
+			t.Errorf("%d words: got %d words, %d min; want %d min", tt.words, res.WordCount, res.ReadingTime, tt.minutes)
+		}
+	}
+}
+
+func TestRenderDropsUnsafeURLs(t *testing.T) {
+	tests := []struct {
+		src, want string
+	}{
+		{"[x](javascript:alert(1))", "<p><a>x</a></p>\n"},
+		{"![i](javascript:x)", "<p><img alt=\"i\" loading=\"lazy\"></p>\n"},
+		{"<script>alert(1)</script>\n\ntext", "\n<p>text</p>\n"},
+	}
+	for _, tt := range tests {
+		if got := Render(tt.src).HTML; got != tt.want {
+			t.Errorf("Render(%q) = %q, want %q", tt.src, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package markdown
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// allowedTags maps each permitted element to its permitted attributes.
+// Anything not listed is dropped; the text inside it is kept.
+var allowedTags = map[string][]string{
+	"a":          {"href", "title", "aria-hidden"},
+	"abbr":       {"title"},
+	"b":          nil,
+	"blockquote": {"cite"},
+	"br":         nil,
+	"caption":    nil,
+	"code":       nil,
+	"dd":         nil,
+	"del":        nil,
+	"details":    {"open"},
+	"div":        nil,
+	"dl":         nil,
+	"dt":         nil,
+	"em":         nil,
+	"figcaption": nil,
+	"figure":     nil,
+	"h1":         {"id"},
+	"h2":         {"id"},
+	"h3":         {"id"},
+	"h4":         {"id"},
+	"h5":         {"id"},
+	"h6":         {"id"},
+	"hr":         nil,
+	"i":          nil,
+	"img":        {"src", "alt", "title", "width", "height", "loading"},
+	"ins":        nil,
+	"kbd":        nil,
+	"li":         {"value"},
+	"mark":       nil,
+	"ol":         {"start", "reversed"},
+	"p":          nil,
+	"pre":        {"data-lang"},
+	"q":          {"cite"},
+	"s":          nil,
+	"small":      nil,
+	"span":       nil,
+	"strong":     nil,
+	"sub":        nil,
+	"summary":    nil,
+	"sup":        nil,
+	"table":      nil,
+	"tbody":      nil,
+	"td":         {"align", "colspan", "rowspan"},
+	"tfoot":      nil,
+	"th":         {"align", "colspan", "rowspan", "scope"},
+	"thead":      nil,
+	"tr":         nil,
+	"u":          nil,
+	"ul":         nil,
+}
+
+// globalAttrs are permitted on every allowed element.
+var globalAttrs = []string{"class", "title", "lang", "dir"}
+
+// dropContent elements are removed together with everything inside them.
+var dropContent = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true, "embed": true,
+	"noscript": true, "template": true, "textarea": true, "select": true,
+	"svg": true, "math": true, "frame": true, "frameset": true, "title": true,
+	"xmp": true, "noembed": true, "plaintext": true,
+}
+
+var voidTags = map[string]bool{"br": true, "hr": true, "img": true}
+
+var (
+	tagNameRe   = regexp.MustCompile(`^<(/?)([A-Za-z][A-Za-z0-9-]*)`)
+	attrRe      = regexp.MustCompile(`([A-Za-z_:][A-Za-z0-9_.:-]*)(?:\s*=\s*(?:"([^"]*)"|'([^']*)'|([^\s"'=<>` + "`" + `]+)))?`)
+	classRe     = regexp.MustCompile(`^[A-Za-z0-9_ -]*$`)
+	idRe        = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+	numberRe    = regexp.MustCompile(`^[0-9]{1,5}$`)
+	schemeRe    = regexp.MustCompile(`^([A-Za-z][A-Za-z0-9+.-]*):`)
+	ctrlSpaceRe = regexp.MustCompile(`[\x00-\x20\x7f]+`)
+)
+
+// Sanitize filters HTML against an allowlist of elements, attributes and URL
+// schemes, balances open tags, and adds rel attributes to external links.
+func Sanitize(s string) string {
+	var b strings.Builder
+	var stack []string
+
+	for i := 0; i < len(s); {
+		if s[i] != '<' {
+			j := strings.IndexByte(s[i:], '<')
+			if j < 0 {
+				j = len(s) - i
+			}
+			b.WriteString(strings.ReplaceAll(s[i:i+j], ">", "&gt;"))
+			i += j
+			continue
+		}
+
+		tok, name, closing, attrs := nextTag(s, i)
+		if tok == 0 {
+			b.WriteString("&lt;")
+			i++
+			continue
+		}
+		i += tok
+		if name == "" {
+			continue // comment, doctype or processing instruction
+		}
+
+		if dropContent[name] {
+			if !closing {
+				i = skipElement(s, i, name)
+			}
+			continue
+		}
+		allowed, ok := allowedTags[name]
+		if !ok {
+			continue
+		}
+
+		if closing {
+			// Close back to the matching open tag; ignore strays.
+			for k := len(stack) - 1; k >= 0; k-- {
+				if stack[k] == name {
+					for len(stack) > k {
+						b.WriteString("</" + stack[len(stack)-1] + ">")
+						stack = stack[:len(stack)-1]
+					}
+					break
+				}
+			}
+			continue
+		}
+
+		b.WriteString("<" + name)
+		b.WriteString(filterAttrs(name, allowed, attrs))
+		b.WriteString(">")
+		if !voidTags[name] {
+			stack = append(stack, name)
+		}
+	}
+
+	for k := len(stack) - 1; k >= 0; k-- {
+		b.WriteString("</" + stack[k] + ">")
+	}
+	return b.String()
+}
+
+// StripTags removes all markup, including the contents of script-like
+// elements, and returns the remaining text without decoding entities.
+// Removing a tag can join the text around it into a new one (as in
+// "<<b>img ...>"), so it strips until nothing changes.
+func StripTags(s string) string {
+	for {
+		out := stripTagsOnce(s)
+		if out == s {
+			return out
+		}
+		s = out
+	}
+}
+
+func stripTagsOnce(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); {
+		if s[i] != '<' {
+			j := strings.IndexByte(s[i:], '<')
+			if j < 0 {
+				j = len(s) - i
+			}
+			b.WriteString(s[i : i+j])
+			i += j
+			continue
+		}
+		tok, name, closing, _ := nextTag(s, i)
+		if tok == 0 {
+			// A tag without its closing '>' (e.g. an unbalanced quote)
+			// runs to the end of the input, as it would in a browser.
+			if tagNameRe.MatchString(s[i:]) {
+				break
+			}
+			b.WriteByte('<')
+			i++
+			continue
+		}
+		i += tok
+		if dropContent[name] && !closing {
+			i = skipElement(s, i, name)
+		}
+	}
+	return b.String()
+}
+
+// nextTag parses markup at s[i] (which is '<'). It returns the token length,
+// or 0 if s[i] does not start markup. Comments and declarations have no name.
+func nextTag(s string, i int) (n int, name string, closing bool, attrs string) {
+	rest := s[i:]
+	switch {
+	case strings.HasPrefix(rest, "<!--"):
+		end := strings.Index(rest[4:], "-->")
+		if end < 0 {
+			return len(rest), "", false, ""
+		}
+		return 4 + end + 3, "", false, ""
+	case strings.HasPrefix(rest, "<!") || strings.HasPrefix(rest, "<?"):
+		end := strings.IndexByte(rest, '>')
+		if end < 0 {
+			return len(rest), "", false, ""
+		}
+		return end + 1, "", false, ""
+	}
+
+	m := tagNameRe.FindStringSubmatch(rest)
+	if m == nil {
+		return 0, "", false, ""
+	}
+	end := tagEnd(rest, len(m[0]))
+	if end < 0 {
+		return 0, "", false, ""
+	}
+	return end + 1, strings.ToLower(m[2]), m[1] == "/", rest[len(m[0]):end]
+}
+
+// tagEnd finds the '>' closing a tag, skipping quoted attribute values.
+func tagEnd(s string, i int) int {
+	var quote byte
+	for ; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '>':
+			return i
+		}
+	}
+	return -1
+}
+
+// skipElement returns the index just past </name>, or len(s) if unclosed.
+func skipElement(s string, i int, name string) int {
+	lower := strings.ToLower(s[i:])
+	end := strings.Index(lower, "</"+name)
+	if end < 0 {
+		return len(s)
+	}
+	j := i + end
+	if k := strings.IndexByte(s[j:], '>'); k >= 0 {
+		return j + k + 1
+	}
+	return len(s)
+}
+
+func filterAttrs(tag string, allowed []string, raw string) string {
+	var b strings.Builder
+	seen := map[string]bool{}
+	var href string
+
+	for _, m := range attrRe.FindAllStringSubmatch(raw, -1) {
+		key := strings.ToLower(m[1])
+		if seen[key] || !(contains(allowed, key) || contains(globalAttrs, key)) {
+			continue
+		}
+		val := html.UnescapeString(m[2] + m[3] + m[4])
+
+		switch key {
+		case "href", "src", "cite":
+			if !safeURL(val, tag == "img") {
+				continue
+			}
+			val = strings.TrimSpace(val)
+			if key == "href" {
+				href = val
+			}
+		case "class":
+			if !classRe.MatchString(val) {
+				continue
+			}
+		case "id":
+			if !idRe.MatchString(val) {
+				continue
+			}
+		case "width", "height", "colspan", "rowspan", "start", "value":
+			if !numberRe.MatchString(val) {
+				continue
+			}
+		case "align":
+			if val != "left" && val != "right" && val != "center" {
+				continue
+			}
+		case "dir":
+			if val != "ltr" && val != "rtl" && val != "auto" {
+				continue
+			}
+		case "loading":
+			if val != "lazy" && val != "eager" {
+				continue
+			}
+		case "scope":
+			if val != "row" && val != "col" {
+				continue
+			}
+		}
+
+		seen[key] = true
+		b.WriteString(" " + key)
+		if m[2]+m[3]+m[4] != "" || (key != "open" && key != "reversed") {
+			b.WriteString(`="` + html.EscapeString(val) + `"`)
+		}
+	}
+
+	if tag == "a" && (strings.HasPrefix(strings.ToLower(href), "http://") ||
+		strings.HasPrefix(strings.ToLower(href), "https://") || strings.HasPrefix(href, "//")) {
+		b.WriteString(` rel="noopener noreferrer"`)
+	}
+	return b.String()
+}
+
+// safeURL permits relative URLs and http(s); links may also use mailto/tel.
+func safeURL(u string, image bool) bool {
+	u = ctrlSpaceRe.ReplaceAllString(u, "")
+	m := schemeRe.FindStringSubmatch(u)
+	if m == nil {
+		return true
+	}
+	switch strings.ToLower(m[1]) {
+	case "http", "https":
+		return true
+	case "mailto", "tel":
+		return !image
+	}
+	return false
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package markdown
+
+import (
+	"testing"
+)
+
+func TestSanitizeURLSchemes(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"javascript", `<a href="javascript:alert(1)">x</a>`, `<a>x</a>`},
+		{"mixed case", `<a href="JaVaScRiPt:alert(1)">x</a>`, `<a>x</a>`},
+		{"leading space", `<a href=" javascript:alert(1)">x</a>`, `<a>x</a>`},
+		{"decimal entity", `<a href="&#106;avascript:alert(1)">x</a>`, `<a>x</a>`},
+		{"hex entity", `<a href="&#x6A;avascript:alert(1)">x</a>`, `<a>x</a>`},
+		{"encoded tab", `<a href="java&#x09;script:alert(1)">x</a>`, `<a>x</a>`},
+		{"newline", "<a href=\"java\nscript:alert(1)\">x</a>", `<a>x</a>`},
+		{"nul byte", "<a href=\"java\x00script:alert(1)\">x</a>", `<a>x</a>`},
+		{"vbscript", `<a href="vbscript:msgbox(1)">x</a>`, `<a>x</a>`},
+		{"data link", `<a href="data:text/html,<script>alert(1)</script>">x</a>`, `<a>x</a>`},
+		{"data image", `<img src="data:image/svg+xml;base64,PHN2Zz4=">`, `<img>`},
+		{"mailto image", `<img src="mailto:a@example.com">`, `<img>`},
+		{"javascript cite", `<blockquote cite="javascript:alert(1)">q</blockquote>`, `<blockquote>q</blockquote>`},
+		{"https", `<a href="https://example.com">x</a>`, `<a href="https://example.com" rel="noopener noreferrer">x</a>`},
+		{"protocol relative", `<a href="//example.com">x</a>`, `<a href="//example.com" rel="noopener noreferrer">x</a>`},
+		{"relative", `<a href="/posts/x">x</a>`, `<a href="/posts/x">x</a>`},
+		{"mailto link", `<a href="mailto:a@example.com">x</a>`, `<a href="mailto:a@example.com">x</a>`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sanitize(tt.in); got != tt.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSanitizeMarkup(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"event handler", `<p onclick="alert(1)" class="lead">t</p>`, `<p class="lead">t</p>`},
+		{"unquoted handler", `<img src=x onerror=alert(1)>`, `<img src="x">`},
+		{"uppercase handler", `<b ONMOUSEOVER="alert(1)">t</b>`, `<b>t</b>`},
+		{"style attribute", `<span style="background:url(x)">t</span>`, `<span>t</span>`},
+		{"script", `<script>alert(1)</script>after`, `after`},
+		{"uppercase script", `<SCRIPT>alert(1)</SCRIPT>after`, `after`},
+		{"style", `<style>body{display:none}</style>after`, `after`},
+		{"iframe", `<iframe src="https://evil.example"></iframe>after`, `after`},
+		{"script in svg", `<svg><script>alert(1)</script></svg>ok`, `ok`},
+		{"unclosed script", `ok<script>alert(1)`, `ok`},
+		{"unterminated tag", `hi <img src=x' onerror=alert(1)>`, `hi &lt;img src=x' onerror=alert(1)&gt;`},
+		{"unknown element", `<blink>t</blink>`, `t`},
+		{"comment", `<!-- <script>alert(1)</script> -->text`, `text`},
+		{"unbalanced", `<b>bold`, `<b>bold</b>`},
+		{"stray close", `</b>stray<i>x</i>`, `stray<i>x</i>`},
+		{"misnested", `<b><i>x</b>y</i>`, `<b><i>x</i></b>y`},
+		{"bare angle brackets", `a < b > c`, `a &lt; b &gt; c`},
+		{"quoted attribute", `<a title='a "b" &lt;c&gt;'>x</a>`, `<a title="a &#34;b&#34; &lt;c&gt;">x</a>`},
+		{"bad class", `<span class="a;b">t</span>`, `<span>t</span>`},
+		{"bad width", `<img src="/a.png" width="100%">`, `<img src="/a.png">`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sanitize(tt.in); got != tt.want {
+				t.Errorf("Sanitize(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStripTags(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", `hello world`, `hello world`},
+		{"tags", `<p>hello <b>world</b></p>`, `hello world`},
+		{"script", `a<script>alert(1)</script>b`, `ab`},
+		{"style", `a<style>p{}</style>b`, `ab`},
+		{"unclosed script", `a<script>alert(1)`, `a`},
+		{"unterminated tag", `hi <img src=x' onerror=alert(1)>`, `hi `},
+		{"unterminated at end", `hi <b`, `hi `},
+		{"comment", `a<!-- x -->b`, `ab`},
+		{"unclosed comment", `a<!-- <img src=x onerror=alert(1)>`, `a`},
+		{"bare angle brackets", `a < b and c > d`, `a < b and c > d`},
+		{"entities kept", `a &amp; b`, `a &amp; b`},
+		{"tag split by a tag", `<<b>img src=x onerror=alert(1)>`, ``},
+		{"script split by tags", `<<script>script>alert(1)<</script>/script>`, ``},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StripTags(tt.in); got != tt.want {
+				t.Errorf("StripTags(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+// StripTags output is shown as text, so it must never contain a tag.
+func TestStripTagsLeavesNoMarkup(t *testing.T) {
+	inputs := []string{
+		`<img src=x onerror=alert(1)>`,
+		`<img src=x' onerror=alert(1)>`,
+		`<img src="x onerror=alert(1)>`,
+		`<<script>script>alert(1)<</script>/script>`,
+		`<<b>img src=x onerror=alert(1)>`,
+		`<a href="x">`,
+		`<svg/onload=alert(1)>`,
+	}
+	for _, in := range inputs {
+		got := StripTags(in)
+		for i := range got {
+			if got[i] == '<' && tagNameRe.MatchString(got[i:]) {
+				t.Errorf("StripTags(%q) = %q, still contains a tag", in, got)
+				break
+			}
+		}
+	}
+}
@@ -21,106 +21,171 @@ func NewCommentsRepo(db *pgxpool.Pool) *CommentsRepo {
 // Create inserts a new comment.
 func (r *CommentsRepo) Create(ctx context.Context, c *comments.Comment) error {
 	_, err := r.db.Exec(ctx, `
-		INSERT INTO comments (id, post_id, user_id, parent_id, guest_name, guest_email, content, status, ip_address, user_agent)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
-	`, c.ID, c.PostID, c.UserID, c.ParentID, c.GuestName, c.GuestEmail, c.Content, c.Status, c.IPAddress, c.UserAgent)
+		INSERT INTO comments (id, post_id, user_id, parent_id, guest_name, guest_email, content, status, ip_address, user_agent,
+		                      content_hash, spam_score)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+	`, c.ID, c.PostID, c.UserID, c.ParentID, c.GuestName, c.GuestEmail, c.Content, c.Status, c.IPAddress, c.UserAgent,
+		c.ContentHash, c.SpamScore)
 	return err
 }
 
 // FindByID returns a single comment.
 func (r *CommentsRepo) FindByID(ctx context.Context, id string) (*comments.Comment, error) {
+	return r.findByID(ctx, id, false)
+}
+
+// FindByIDWithDeleted returns a single comment, deleted or not.
+func (r *CommentsRepo) FindByIDWithDeleted(ctx context.Context, id string) (*comments.Comment, error) {
+	return r.findByID(ctx, id, true)
+}
+
+func (r *CommentsRepo) findByID(ctx context.Context, id string, withDeleted bool) (*comments.Comment, error) {
+	where := "c.id = $1 AND c.deleted_at IS NULL"
+	if withDeleted {
+		where = "c.id = $1"
+	}
 	var c comments.Comment
 	err := r.db.QueryRow(ctx, `
 		SELECT c.id, c.post_id, c.user_id, c.parent_id, c.guest_name, c.guest_email,
-		       c.content, c.status, c.ip_address, c.created_at, c.updated_at,
+		       c.content, c.status, c.ip_address, c.created_at, c.updated_at, c.edited_at, c.deleted_at,
 		       COALESCE(u.name, c.guest_name) AS author_name,
-		       COALESCE(u.avatar, '') AS author_avatar
+		       COALESCE(u.avatar, '') AS author_avatar, c.likes_count
 		FROM comments c
 		LEFT JOIN users u ON c.user_id = u.id
-		WHERE c.id = $1 AND c.deleted_at IS NULL
-	`, id).Scan(&c.ID, &c.PostID, &c.UserID, &c.ParentID, &c.GuestName, &c.GuestEmail,
-		&c.Content, &c.Status, &c.IPAddress, &c.CreatedAt, &c.UpdatedAt,
-		&c.AuthorName, &c.AuthorAvatar)
+		WHERE `+where, id).Scan(&c.ID, &c.PostID, &c.UserID, &c.ParentID, &c.GuestName, &c.GuestEmail,
+		&c.Content, &c.Status, &c.IPAddress, &c.CreatedAt, &c.UpdatedAt, &c.EditedAt, &c.DeletedAt,
+		&c.AuthorName, &c.AuthorAvatar, &c.LikesCount)
 	if err != nil {
 		return nil, err
 	}
 	return &c, nil
 }
 
-// FindByPost returns approved comments for a post (public).
-func (r *CommentsRepo) FindByPost(ctx context.Context, postID string, page, limit int) (*comments.CommentListResult, error) {
+// threadDescendants returns a query for the ids of the approved comments
+// below root, or with liveOnly of those not deleted. Replies under a hidden
+// comment are hidden with it; replies under a deleted one are not.
+func threadDescendants(root string, liveOnly bool) string {
+	sel := "SELECT id FROM thread"
+	if liveOnly {
+		sel += " WHERE deleted_at IS NULL"
+	}
+	return `WITH RECURSIVE thread AS (
+			SELECT dc.id, dc.deleted_at FROM comments dc
+			WHERE dc.parent_id = ` + root + ` AND dc.status = 'APPROVED'
+			UNION ALL
+			SELECT dc.id, dc.deleted_at FROM comments dc JOIN thread t ON dc.parent_id = t.id
+			WHERE dc.status = 'APPROVED'
+		) ` + sel
+}
+
+// visibleComment restricts alias a to comments shown publicly: approved
+// and not deleted, or deleted with replies still shown (a tombstone).
+func visibleComment(a string) string {
+	return a + ".status = 'APPROVED' AND (" + a + ".deleted_at IS NULL OR EXISTS (" +
+		threadDescendants(a+".id", true) + "))"
+}
+
+// FindThread returns one page of approved comments of a thread (public),
+// with deleted comments that still have replies as tombstones:
+// the top-level comments of a post, the direct replies to a comment, or at
+// f.MaxDepth all replies below it in one flat list. Comments whose replies
+// will be shown flat count all their descendants as replies.
+func (r *CommentsRepo) FindThread(ctx context.Context, f comments.ThreadFilter) (*comments.CommentListResult, error) {
+	where := "c.post_id = $1 AND c.parent_id IS NULL"
+	root := f.PostID
+	if f.ParentID != "" {
+		where = "c.parent_id = $1"
+		root = f.ParentID
+	}
+	replyCount := "(SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.id AND " + visibleComment("r") + ")"
+	if f.Depth+1 >= f.MaxDepth {
+		replyCount = "(SELECT COUNT(*) FROM comments r WHERE r.id IN (" + threadDescendants("c.id", false) + ") AND " + visibleComment("r") + ")"
+	}
+	replyTo := "''"
+	if f.Flat() {
+		where = "c.id IN (" + threadDescendants("$1", false) + ")"
+		replyCount = "0"
+		replyTo = `CASE WHEN c.parent_id = $1 THEN '' ELSE (
+			SELECT CASE WHEN pc.deleted_at IS NULL THEN COALESCE(pu.name, pc.guest_name) ELSE '' END
+			FROM comments pc
+			LEFT JOIN users pu ON pu.id = pc.user_id
+			WHERE pc.id = c.parent_id) END`
+	}
+	where += " AND " + visibleComment("c")
+
+	orderBy := "c.created_at DESC"
+	switch f.Sort {
+	case comments.SortOldest:
+		orderBy = "c.created_at ASC"
+	case comments.SortTop:
+		orderBy = "c.likes_count DESC, c.created_at ASC"
+	}
+
 	var total int
-	r.db.QueryRow(ctx, `
-		SELECT COUNT(*) FROM comments WHERE post_id = $1 AND status = 'APPROVED' AND deleted_at IS NULL AND parent_id IS NULL
-	`, postID).Scan(&total)
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM comments c WHERE "+where, root).Scan(&total); err != nil {
+		return nil, err
+	}
 
-	offset := (page - 1) * limit
-	rows, err := r.db.Query(ctx, `
-		SELECT c.id, c.post_id, c.user_id, c.parent_id, c.guest_name, c.guest_email,
-		       c.content, c.status, c.created_at, c.updated_at,
+	query := fmt.Sprintf(`
+		SELECT c.id, c.post_id, c.user_id, c.parent_id, c.guest_name,
+		       c.content, c.status, c.created_at, c.updated_at, c.edited_at, c.deleted_at,
 		       COALESCE(u.name, c.guest_name) AS author_name,
-		       COALESCE(u.avatar, '') AS author_avatar,
-		       (SELECT COUNT(*) FROM comments r WHERE r.parent_id = c.id AND r.status = 'APPROVED' AND r.deleted_at IS NULL) AS reply_count
+		       COALESCE(u.avatar, '') AS author_avatar, CASE WHEN c.deleted_at IS NULL THEN COALESCE(u.username, '') ELSE '' END AS author_username,
+		       %s AS reply_count, c.likes_count,
+		       EXISTS (SELECT 1 FROM comment_likes cl WHERE cl.comment_id = c.id
+		               AND (cl.user_id = $2 OR (cl.user_id IS NULL AND $3 != '' AND cl.guest_key = $3))) AS liked,
+		       %s AS reply_to
 		FROM comments c
 		LEFT JOIN users u ON c.user_id = u.id
-		WHERE c.post_id = $1 AND c.status = 'APPROVED' AND c.deleted_at IS NULL AND c.parent_id IS NULL
-		ORDER BY c.created_at DESC
-		LIMIT $2 OFFSET $3
-	`, postID, limit, offset)
+		WHERE %s
+		ORDER BY %s
+		LIMIT $4 OFFSET $5
+	`, replyCount, replyTo, where, orderBy)
+	rows, err := r.db.Query(ctx, query, root, f.Viewer.UserID, f.Viewer.GuestKey, f.Limit, (f.Page-1)*f.Limit)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	var items []comments.Comment
+	items := []comments.Comment{}
 	for rows.Next() {
 		var c comments.Comment
-		if err := rows.Scan(&c.ID, &c.PostID, &c.UserID, &c.ParentID, &c.GuestName, &c.GuestEmail,
-			&c.Content, &c.Status, &c.CreatedAt, &c.UpdatedAt,
-			&c.AuthorName, &c.AuthorAvatar, &c.ReplyCount); err != nil {
+		if err := rows.Scan(&c.ID, &c.PostID, &c.UserID, &c.ParentID, &c.GuestName,
+			&c.Content, &c.Status, &c.CreatedAt, &c.UpdatedAt, &c.EditedAt, &c.DeletedAt,
+			&c.AuthorName, &c.AuthorAvatar, &c.AuthorUsername, &c.ReplyCount, &c.LikesCount, &c.Liked, &c.ReplyTo); err != nil {
 			return nil, err
 		}
+		if c.DeletedAt != nil {
+			c.Tombstone()
+		}
+		c.Depth = f.Depth
 		items = append(items, c)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 
 	return &comments.CommentListResult{
 		Items:      items,
 		Total:      total,
-		Page:       page,
-		Limit:      limit,
-		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+		Page:       f.Page,
+		Limit:      f.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(f.Limit))),
 	}, nil
 }
 
-// FindReplies returns replies for a parent comment.
-func (r *CommentsRepo) FindReplies(ctx context.Context, parentID string, limit int) ([]comments.Comment, error) {
-	rows, err := r.db.Query(ctx, `
-		SELECT c.id, c.post_id, c.user_id, c.parent_id, c.guest_name, c.guest_email,
-		       c.content, c.status, c.created_at, c.updated_at,
-		       COALESCE(u.name, c.guest_name) AS author_name,
-		       COALESCE(u.avatar, '') AS author_avatar
-		FROM comments c
-		LEFT JOIN users u ON c.user_id = u.id
-		WHERE c.parent_id = $1 AND c.status = 'APPROVED' AND c.deleted_at IS NULL
-		ORDER BY c.created_at ASC
-		LIMIT $2
-	`, parentID, limit)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var items []comments.Comment
-	for rows.Next() {
-		var c comments.Comment
-		if err := rows.Scan(&c.ID, &c.PostID, &c.UserID, &c.ParentID, &c.GuestName, &c.GuestEmail,
-			&c.Content, &c.Status, &c.CreatedAt, &c.UpdatedAt,
-			&c.AuthorName, &c.AuthorAvatar); err != nil {
-			return nil, err
-		}
-		items = append(items, c)
-	}
-	return items, nil
+// Depth returns the nesting level of a comment, 0 for top-level comments.
+func (r *CommentsRepo) Depth(ctx context.Context, id string) (int, error) {
+	var depth int
+	err := r.db.QueryRow(ctx, `
+		WITH RECURSIVE up AS (
+			SELECT parent_id, 0 AS depth FROM comments WHERE id = $1
+			UNION ALL
+			SELECT c.parent_id, up.depth + 1 FROM comments c JOIN up ON c.id = up.parent_id
+		)
+		SELECT MAX(depth) FROM up
+	`, id).Scan(&depth)
+	return depth, err
 }
 
 // FindAll returns all comments with filters (admin).
@@ -161,7 +226,7 @@ func (r *CommentsRepo) FindAll(ctx context.Context, f comments.CommentFilter) (*
 
 	query := fmt.Sprintf(`
 		SELECT c.id, c.post_id, c.user_id, c.parent_id, c.guest_name, c.guest_email,
-		       c.content, c.status, c.ip_address, c.created_at, c.updated_at,
+		       c.content, c.status, c.ip_address, c.created_at, c.updated_at, c.edited_at, c.spam_score,
 		       COALESCE(u.name, c.guest_name) AS author_name,
 		       COALESCE(u.avatar, '') AS author_avatar,
 		       COALESCE(p.title, '') AS post_title
@@ -184,7 +249,7 @@ func (r *CommentsRepo) FindAll(ctx context.Context, f comments.CommentFilter) (*
 		var c comments.Comment
 		var postTitle string
 		if err := rows.Scan(&c.ID, &c.PostID, &c.UserID, &c.ParentID, &c.GuestName, &c.GuestEmail,
-			&c.Content, &c.Status, &c.IPAddress, &c.CreatedAt, &c.UpdatedAt,
+			&c.Content, &c.Status, &c.IPAddress, &c.CreatedAt, &c.UpdatedAt, &c.EditedAt, &c.SpamScore,
 			&c.AuthorName, &c.AuthorAvatar, &postTitle); err != nil {
 			return nil, err
 		}
@@ -224,6 +289,59 @@ func (r *CommentsRepo) SoftDelete(ctx context.Context, id string) error {
 	return err
 }
 
+// UpdateContent saves an edited comment's content, hash, status and spam
+// score, and keeps the previous content in comment_edits.
+func (r *CommentsRepo) UpdateContent(ctx context.Context, c *comments.Comment, editorID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO comment_edits (comment_id, content, edited_by)
+		SELECT id, content, NULLIF($2, '') FROM comments WHERE id = $1
+	`, c.ID, editorID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE comments SET content = $2, content_hash = $3, status = $4, spam_score = $5,
+			   edited_at = NOW(), updated_at = NOW()
+		WHERE id = $1
+	`, c.ID, c.Content, c.ContentHash, string(c.Status), c.SpamScore)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ListEdits returns the earlier versions of a comment, newest first.
+func (r *CommentsRepo) ListEdits(ctx context.Context, commentID string) ([]comments.Edit, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, comment_id, content, edited_by, created_at
+		FROM comment_edits
+		WHERE comment_id = $1
+		ORDER BY created_at DESC
+	`, commentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []comments.Edit{}
+	for rows.Next() {
+		var e comments.Edit
+		if err := rows.Scan(&e.ID, &e.CommentID, &e.Content, &e.EditedBy, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, e)
+	}
+	return items, rows.Err()
+}
+
 // CountByPost returns approved comment count for a post.
 func (r *CommentsRepo) CountByPost(ctx context.Context, postID string) (int, error) {
 	var count int
@@ -232,3 +350,57 @@ func (r *CommentsRepo) CountByPost(ctx context.Context, postID string) (int, err
 	`, postID).Scan(&count)
 	return count, err
 }
+
+// ── Comment likes ───────────────────────────────────
+
+// AddLike records a comment like by a user or guest (returns false if it
+// already exists).
+func (r *CommentsRepo) AddLike(ctx context.Context, commentID string, v comments.Viewer, ipHash string) (bool, error) {
+	var query string
+	var args []interface{}
+
+	if v.UserID != "" {
+		query = `INSERT INTO comment_likes (comment_id, user_id, ip_hash) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
+		args = []interface{}{commentID, v.UserID, ipHash}
+	} else {
+		query = `INSERT INTO comment_likes (comment_id, guest_key, ip_hash) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`
+		args = []interface{}{commentID, v.GuestKey, ipHash}
+	}
+
+	result, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// RemoveLike removes a comment like (returns false if there was none).
+func (r *CommentsRepo) RemoveLike(ctx context.Context, commentID string, v comments.Viewer) (bool, error) {
+	var query string
+	var args []interface{}
+
+	if v.UserID != "" {
+		query = `DELETE FROM comment_likes WHERE comment_id = $1 AND user_id = $2`
+		args = []interface{}{commentID, v.UserID}
+	} else {
+		query = `DELETE FROM comment_likes WHERE comment_id = $1 AND guest_key = $2 AND user_id IS NULL`
+		args = []interface{}{commentID, v.GuestKey}
+	}
+
+	result, err := r.db.Exec(ctx, query, args...)
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected() > 0, nil
+}
+
+// IncrementLikes atomically changes a comment's like count and returns the
+// new count.
+func (r *CommentsRepo) IncrementLikes(ctx context.Context, commentID string, delta int) (int, error) {
+	var count int
+	err := r.db.QueryRow(ctx, `
+		UPDATE comments SET likes_count = GREATEST(0, likes_count + $2) WHERE id = $1
+		RETURNING likes_count
+	`, commentID, delta).Scan(&count)
+	return count, err
+}
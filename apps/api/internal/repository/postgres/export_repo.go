@@ -0,0 +1,191 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rapidtest/netpulse-api/internal/domain/export"
+	"github.com/rapidtest/netpulse-api/internal/domain/posts"
+)
+
+// ExportRepo reads the data of content export archives.
+type ExportRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewExportRepo(db *pgxpool.Pool) *ExportRepo {
+	return &ExportRepo{db: db}
+}
+
+// FindPosts returns every non-deleted post matching f, oldest first, with
+// bylines and tags loaded. An author filter includes co-authored posts and a category
+// filter includes its subcategories.
+func (r *ExportRepo) FindPosts(ctx context.Context, f export.Filter) ([]posts.Post, error) {
+	conditions := []string{"p.deleted_at IS NULL"}
+	var args []interface{}
+	arg := func(v interface{}) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	if f.AuthorID != "" {
+		conditions = append(conditions, coAuthoredBy(arg(f.AuthorID)))
+	}
+	if f.CategorySlug != "" {
+		conditions = append(conditions, inCategoryTree(arg(f.CategorySlug)))
+	}
+	if f.From != nil {
+		conditions = append(conditions, "COALESCE(p.published_at, p.created_at) >= "+arg(*f.From))
+	}
+	if f.To != nil {
+		conditions = append(conditions, "COALESCE(p.published_at, p.created_at) <= "+arg(*f.To))
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT p.id, p.title, p.slug, p.excerpt, p.body, p.cover_url,
+			   p.status, p.author_id, p.category_id, p.published_at,
+			   p.meta_title, p.meta_description, p.created_at, p.updated_at, p.version, p.language,
+			   COALESCE(c.name, '') as category_name, COALESCE(c.slug, '') as category_slug,
+			   COALESCE(u.name, '') as author_name
+		FROM posts p
+		LEFT JOIN categories c ON p.category_id = c.id
+		LEFT JOIN users u ON p.author_id = u.id
+		WHERE `+strings.Join(conditions, " AND ")+`
+		ORDER BY COALESCE(p.published_at, p.created_at), p.id
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []posts.Post
+	index := make(map[string]int)
+	for rows.Next() {
+		var p posts.Post
+		var catName, catSlug, authorName string
+		err := rows.Scan(
+			&p.ID, &p.Title, &p.Slug, &p.Excerpt, &p.Body, &p.CoverURL,
+			&p.Status, &p.AuthorID, &p.CategoryID, &p.PublishedAt,
+			&p.MetaTitle, &p.MetaDesc, &p.CreatedAt, &p.UpdatedAt, &p.Version, &p.Language,
+			&catName, &catSlug, &authorName,
+		)
+		if err != nil {
+			return nil, err
+		}
+		if catName != "" {
+			p.Category = &posts.Category{Name: catName, Slug: catSlug}
+		}
+		if authorName != "" {
+			p.Author = &posts.Author{ID: p.AuthorID, Name: authorName}
+		}
+		index[p.ID] = len(items)
+		items = append(items, p)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return items, nil
+	}
+
+	if err := NewPostsRepo(r.db).loadContributors(ctx, items); err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, len(items))
+	for i, p := range items {
+		ids[i] = p.ID
+	}
+	tagRows, err := r.db.Query(ctx, `
+		SELECT pt.post_id, t.id, t.name, t.slug
+		FROM post_tags pt
+		JOIN tags t ON t.id = pt.tag_id
+		WHERE pt.post_id = ANY($1)
+		ORDER BY t.name
+	`, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer tagRows.Close()
+	for tagRows.Next() {
+		var postID string
+		var t posts.Tag
+		if err := tagRows.Scan(&postID, &t.ID, &t.Name, &t.Slug); err != nil {
+			return nil, err
+		}
+		if i, ok := index[postID]; ok {
+			items[i].Tags = append(items[i].Tags, t)
+		}
+	}
+	return items, tagRows.Err()
+}
+
+// FindComments returns the non-deleted comments of the given posts, oldest first.
+func (r *ExportRepo) FindComments(ctx context.Context, postIDs []string) ([]export.Comment, error) {
+	if len(postIDs) == 0 {
+		return nil, nil
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT c.id, c.post_id, c.parent_id, COALESCE(u.name, NULLIF(c.guest_name, ''), 'Anonymous'),
+		       c.content, c.status, c.created_at
+		FROM comments c
+		LEFT JOIN users u ON c.user_id = u.id
+		WHERE c.post_id = ANY($1) AND c.deleted_at IS NULL
+		ORDER BY c.created_at, c.id
+	`, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []export.Comment
+	for rows.Next() {
+		var c export.Comment
+		if err := rows.Scan(&c.ID, &c.PostID, &c.ParentID, &c.AuthorName, &c.Content, &c.Status, &c.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, c)
+	}
+	return items, rows.Err()
+}
+
+// FindRevisions returns the revisions of the given posts with their bodies,
+// oldest first.
+func (r *ExportRepo) FindRevisions(ctx context.Context, postIDs []string) ([]posts.Revision, error) {
+	if len(postIDs) == 0 {
+		return nil, nil
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT pr.id, pr.post_id, pr.title, pr.body, COALESCE(pr.excerpt, ''), pr.editor_id,
+		       COALESCE(u.name, '') AS editor_name, pr.created_at
+		FROM post_revisions pr
+		LEFT JOIN users u ON pr.editor_id = u.id
+		WHERE pr.post_id = ANY($1)
+		ORDER BY pr.created_at, pr.id
+	`, postIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []posts.Revision
+	for rows.Next() {
+		var rev posts.Revision
+		if err := rows.Scan(&rev.ID, &rev.PostID, &rev.Title, &rev.Body, &rev.Excerpt, &rev.EditorID,
+			&rev.EditorName, &rev.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, rev)
+	}
+	return items, rows.Err()
+}
+
+func (r *ExportRepo) FindCategories(ctx context.Context) ([]posts.Category, error) {
+	return NewCategoriesRepo(r.db).FindAll(ctx, false)
+}
+
+func (r *ExportRepo) FindTags(ctx context.Context) ([]posts.Tag, error) {
+	return NewTagsRepo(r.db).FindAll(ctx, false)
+}
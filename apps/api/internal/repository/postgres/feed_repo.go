@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rapidtest/netpulse-api/internal/domain/feed"
+)
+
+type FeedRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewFeedRepo(db *pgxpool.Pool) *FeedRepo {
+	return &FeedRepo{db: db}
+}
+
+// sitemapSQL lists every public URL source. Categories, tags and authors take
+// their lastmod from the newest published post they contain.
+const sitemapSQL = `
+	SELECT 'post' AS kind, p.slug AS key, p.updated_at AS lastmod
+	FROM posts p WHERE p.status = 'PUBLISHED'
+	UNION ALL
+	SELECT 'category', c.slug, MAX(p.updated_at)
+	FROM categories c JOIN posts p ON p.category_id = c.id AND p.status = 'PUBLISHED'
+	GROUP BY c.slug
+	UNION ALL
+	SELECT 'tag', t.slug, MAX(p.updated_at)
+	FROM tags t
+	JOIN post_tags pt ON pt.tag_id = t.id
+	JOIN posts p ON p.id = pt.post_id AND p.status = 'PUBLISHED'
+	GROUP BY t.slug
+	UNION ALL
+	SELECT 'author', u.id, MAX(p.updated_at)
+	FROM users u JOIN posts p ON p.author_id = u.id AND p.status = 'PUBLISHED'
+	GROUP BY u.id
+	UNION ALL
+	SELECT 'listing', l.slug, COALESCE(l.updated_at, l.created_at, NOW())
+	FROM listings l WHERE l.is_active = true`
+
+// CountSitemapURLs returns the total number of sitemap entries.
+func (r *FeedRepo) CountSitemapURLs(ctx context.Context) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM (`+sitemapSQL+`) s`).Scan(&n)
+	return n, err
+}
+
+// LastModified returns the newest lastmod across all sitemap entries.
+func (r *FeedRepo) LastModified(ctx context.Context) (time.Time, error) {
+	var t time.Time
+	err := r.db.QueryRow(ctx, `SELECT COALESCE(MAX(lastmod), NOW()) FROM (`+sitemapSQL+`) s`).Scan(&t)
+	return t, err
+}
+
+// StreamSitemap calls fn for each entry in a stable order, starting at offset.
+// Rows are handed over as they arrive so large sitemaps are never held in memory.
+func (r *FeedRepo) StreamSitemap(ctx context.Context, offset, limit int, fn func(feed.Entry) error) error {
+	rows, err := r.db.Query(ctx, `
+		SELECT kind, key, lastmod FROM (`+sitemapSQL+`) s
+		ORDER BY kind, key
+		OFFSET $1 LIMIT $2`, offset, limit)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e feed.Entry
+		var kind string
+		if err := rows.Scan(&kind, &e.Key, &e.LastMod); err != nil {
+			return err
+		}
+		e.Kind = feed.EntryKind(kind)
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// FindItems returns the newest published posts, optionally filtered by category or tag slug.
+// Link holds the post slug; callers turn it into an absolute URL.
+func (r *FeedRepo) FindItems(ctx context.Context, f feed.Filter) ([]feed.Item, error) {
+	if f.Limit <= 0 || f.Limit > 100 {
+		f.Limit = 20
+	}
+
+	where := "p.status = 'PUBLISHED'"
+	args := []interface{}{}
+	argIdx := 1
+	if f.CategorySlug != "" {
+		where += fmt.Sprintf(" AND c.slug = $%d", argIdx)
+		args = append(args, f.CategorySlug)
+		argIdx++
+	}
+	if f.TagSlug != "" {
+		where += fmt.Sprintf(` AND EXISTS (
+			SELECT 1 FROM post_tags pt JOIN tags t ON t.id = pt.tag_id
+			WHERE pt.post_id = p.id AND t.slug = $%d)`, argIdx)
+		args = append(args, f.TagSlug)
+		argIdx++
+	}
+	args = append(args, f.Limit)
+
+	rows, err := r.db.Query(ctx, fmt.Sprintf(`
+		SELECT p.id, p.title, p.slug, COALESCE(p.excerpt, ''), COALESCE(u.name, ''),
+			COALESCE(c.name, ''), COALESCE(p.published_at, p.created_at), p.updated_at
+		FROM posts p
+		LEFT JOIN users u ON u.id = p.author_id
+		LEFT JOIN categories c ON c.id = p.category_id
+		WHERE %s
+		ORDER BY p.published_at DESC NULLS LAST
+		LIMIT $%d`, where, argIdx), args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []feed.Item
+	for rows.Next() {
+		var it feed.Item
+		if err := rows.Scan(&it.ID, &it.Title, &it.Link, &it.Summary, &it.AuthorName,
+			&it.Category, &it.PublishedAt, &it.UpdatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, it)
+	}
+	return items, rows.Err()
+}
+
+// FindCategoryName returns the display name of a category by slug.
+func (r *FeedRepo) FindCategoryName(ctx context.Context, slug string) (string, error) {
+	var name string
+	err := r.db.QueryRow(ctx, `SELECT name FROM categories WHERE slug = $1`, slug).Scan(&name)
+	if err == pgx.ErrNoRows {
+		return "", fmt.Errorf("category not found")
+	}
+	return name, err
+}
+
+// FindTagName returns the display name of a tag by slug.
+func (r *FeedRepo) FindTagName(ctx context.Context, slug string) (string, error) {
+	var name string
+	err := r.db.QueryRow(ctx, `SELECT name FROM tags WHERE slug = $1`, slug).Scan(&name)
+	if err == pgx.ErrNoRows {
+		return "", fmt.Errorf("tag not found")
+	}
+	return name, err
+}
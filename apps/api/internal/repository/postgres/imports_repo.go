@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rapidtest/netpulse-api/internal/domain/imports"
+)
+
+type ImportsRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewImportsRepo(db *pgxpool.Pool) *ImportsRepo {
+	return &ImportsRepo{db: db}
+}
+
+func (r *ImportsRepo) CreateJob(ctx context.Context, j *imports.Job) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO import_jobs (id, format, filename, dry_run, author_id, status, total, created_by, created_at)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, NULLIF($8, ''), $9)
+	`, j.ID, string(j.Format), j.Filename, j.DryRun, j.AuthorID, string(j.Status), j.Total, j.CreatedBy, j.CreatedAt)
+	return err
+}
+
+// UpdateJob saves a job's progress counters and item results.
+func (r *ImportsRepo) UpdateJob(ctx context.Context, j *imports.Job) error {
+	items, err := json.Marshal(j.Items)
+	if err != nil {
+		return err
+	}
+	_, err = r.db.Exec(ctx, `
+		UPDATE import_jobs SET status = $2, processed = $3, created = $4, skipped = $5, failed = $6,
+			   error = $7, items = $8, started_at = $9, finished_at = $10
+		WHERE id = $1
+	`, j.ID, string(j.Status), j.Processed, j.Created, j.Skipped, j.Failed,
+		j.Error, items, j.StartedAt, j.FinishedAt)
+	return err
+}
+
+// FailUnfinished marks every pending or running job as failed.
+func (r *ImportsRepo) FailUnfinished(ctx context.Context, reason string) (int64, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE import_jobs SET status = 'FAILED', error = $1, finished_at = NOW()
+		WHERE status IN ('PENDING', 'RUNNING')
+	`, reason)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+const importJobColumns = `id, format, filename, dry_run, COALESCE(author_id, ''), status, total, processed,
+	created, skipped, failed, error, COALESCE(created_by, ''), created_at, started_at, finished_at`
+
+func scanImportJob(row pgx.Row, extra ...interface{}) (*imports.Job, error) {
+	var j imports.Job
+	dest := append([]interface{}{&j.ID, &j.Format, &j.Filename, &j.DryRun, &j.AuthorID, &j.Status,
+		&j.Total, &j.Processed, &j.Created, &j.Skipped, &j.Failed, &j.Error, &j.CreatedBy,
+		&j.CreatedAt, &j.StartedAt, &j.FinishedAt}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
+
+// FindJob returns a job with its item results.
+func (r *ImportsRepo) FindJob(ctx context.Context, id string) (*imports.Job, error) {
+	var items []byte
+	j, err := scanImportJob(r.db.QueryRow(ctx,
+		`SELECT `+importJobColumns+`, items FROM import_jobs WHERE id = $1`, id), &items)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("import job not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(items, &j.Items); err != nil {
+		return nil, err
+	}
+	return j, nil
+}
+
+// FindJobs returns the most recent jobs without their item results.
+func (r *ImportsRepo) FindJobs(ctx context.Context, limit int) ([]imports.Job, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT `+importJobColumns+` FROM import_jobs ORDER BY created_at DESC LIMIT $1`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []imports.Job
+	for rows.Next() {
+		j, err := scanImportJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, *j)
+	}
+	return jobs, nil
+}
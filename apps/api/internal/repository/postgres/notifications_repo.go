@@ -0,0 +1,213 @@
+package postgres
+
+import (
+	"context"
+	"math"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rapidtest/netpulse-api/internal/domain/notifications"
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+// NotificationsRepo stores comment notifications and the preferences
+// that decide who gets them.
+type NotificationsRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewNotificationsRepo(db *pgxpool.Pool) *NotificationsRepo {
+	return &NotificationsRepo{db: db}
+}
+
+// visibleNotification limits notifications to comments readers can see.
+const visibleNotification = `c.status = 'APPROVED' AND c.deleted_at IS NULL`
+
+// Authors returns the post's author and the parent comment's author.
+func (r *NotificationsRepo) Authors(ctx context.Context, postID string, parentID *string) (string, string, error) {
+	var postAuthor, parentAuthor string
+	err := r.db.QueryRow(ctx, `
+		SELECT p.author_id,
+		       COALESCE((SELECT pc.user_id FROM comments pc WHERE pc.id = $2 AND pc.deleted_at IS NULL), '')
+		FROM posts p WHERE p.id = $1
+	`, postID, parentID).Scan(&postAuthor, &parentAuthor)
+	if err == pgx.ErrNoRows {
+		return "", "", nil
+	}
+	return postAuthor, parentAuthor, err
+}
+
+// ResolveUsernames returns the IDs of active users with the given usernames.
+func (r *NotificationsRepo) ResolveUsernames(ctx context.Context, usernames []string) ([]string, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id FROM users
+		WHERE username = ANY($1) AND is_active = true AND disabled_at IS NULL
+	`, usernames)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+// PreferencesFor returns the stored preferences of each user. Users
+// without stored rows are missing from the map.
+func (r *NotificationsRepo) PreferencesFor(ctx context.Context, userIDs []string) (map[string]notifications.Preferences, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT user_id, type, enabled FROM notification_preferences WHERE user_id = ANY($1)
+	`, userIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	prefs := make(map[string]notifications.Preferences)
+	for rows.Next() {
+		var userID, typ string
+		var enabled bool
+		if err := rows.Scan(&userID, &typ, &enabled); err != nil {
+			return nil, err
+		}
+		if prefs[userID] == nil {
+			prefs[userID] = make(notifications.Preferences)
+		}
+		prefs[userID][typ] = enabled
+	}
+	return prefs, rows.Err()
+}
+
+// Insert saves notifications, ignoring users already notified of the
+// same comment.
+func (r *NotificationsRepo) Insert(ctx context.Context, items []notifications.Notification) error {
+	for _, n := range items {
+		if n.ID == "" {
+			n.ID = utils.NewID()
+		}
+		_, err := r.db.Exec(ctx, `
+			INSERT INTO notifications (id, user_id, type, actor_id, post_id, comment_id)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (user_id, comment_id) DO NOTHING
+		`, n.ID, n.UserID, n.Type, n.ActorID, n.PostID, n.CommentID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// List returns one page of a user's notifications, newest first.
+func (r *NotificationsRepo) List(ctx context.Context, f notifications.Filter) (*notifications.List, error) {
+	where := "n.user_id = $1 AND " + visibleNotification
+	if f.UnreadOnly {
+		where += " AND n.read_at IS NULL"
+	}
+
+	result := &notifications.List{Items: []notifications.Notification{}, Page: f.Page, Limit: f.Limit}
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE n.read_at IS NULL)
+		FROM notifications n
+		JOIN comments c ON c.id = n.comment_id
+		WHERE `+where, f.UserID).Scan(&result.Total, &result.Unread)
+	if err != nil {
+		return nil, err
+	}
+	result.TotalPages = int(math.Ceil(float64(result.Total) / float64(f.Limit)))
+
+	rows, err := r.db.Query(ctx, `
+		SELECT n.id, n.user_id, n.type, n.actor_id, n.post_id, n.comment_id, n.read_at, n.created_at,
+		       COALESCE(u.name, c.guest_name, '') AS actor_name, COALESCE(u.avatar, '') AS actor_avatar,
+		       p.title, p.slug, LEFT(c.content, 200) AS excerpt
+		FROM notifications n
+		JOIN comments c ON c.id = n.comment_id
+		JOIN posts p ON p.id = n.post_id
+		LEFT JOIN users u ON u.id = n.actor_id
+		WHERE `+where+`
+		ORDER BY n.created_at DESC
+		LIMIT $2 OFFSET $3
+	`, f.UserID, f.Limit, (f.Page-1)*f.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var n notifications.Notification
+		if err := rows.Scan(&n.ID, &n.UserID, &n.Type, &n.ActorID, &n.PostID, &n.CommentID, &n.ReadAt, &n.CreatedAt,
+			&n.ActorName, &n.ActorAvatar, &n.PostTitle, &n.PostSlug, &n.Excerpt); err != nil {
+			return nil, err
+		}
+		result.Items = append(result.Items, n)
+	}
+	return result, rows.Err()
+}
+
+// UnreadCount counts a user's unread notifications.
+func (r *NotificationsRepo) UnreadCount(ctx context.Context, userID string) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM notifications n
+		JOIN comments c ON c.id = n.comment_id
+		WHERE n.user_id = $1 AND n.read_at IS NULL AND `+visibleNotification, userID).Scan(&n)
+	return n, err
+}
+
+// MarkRead marks one of a user's notifications read. It returns false
+// when the user has no such notification.
+func (r *NotificationsRepo) MarkRead(ctx context.Context, userID, id string) (bool, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE notifications SET read_at = COALESCE(read_at, NOW())
+		WHERE id = $1 AND user_id = $2
+	`, id, userID)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+// MarkAllRead marks all of a user's notifications read.
+func (r *NotificationsRepo) MarkAllRead(ctx context.Context, userID string) error {
+	_, err := r.db.Exec(ctx, `UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL`, userID)
+	return err
+}
+
+// Preferences returns a user's preferences for every type.
+func (r *NotificationsRepo) Preferences(ctx context.Context, userID string) (notifications.Preferences, error) {
+	stored, err := r.PreferencesFor(ctx, []string{userID})
+	if err != nil {
+		return nil, err
+	}
+	prefs := notifications.DefaultPreferences()
+	for t, enabled := range stored[userID] {
+		prefs[t] = enabled
+	}
+	return prefs, nil
+}
+
+// SetPreferences stores the given types' settings for a user.
+func (r *NotificationsRepo) SetPreferences(ctx context.Context, userID string, prefs notifications.Preferences) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	for t, enabled := range prefs {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO notification_preferences (user_id, type, enabled) VALUES ($1, $2, $3)
+			ON CONFLICT (user_id, type) DO UPDATE SET enabled = EXCLUDED.enabled, updated_at = NOW()
+		`, userID, t, enabled)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
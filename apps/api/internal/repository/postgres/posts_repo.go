@@ -2,11 +2,14 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/rapidtest/netpulse-api/internal/domain/posts"
 )
@@ -41,7 +44,7 @@ func (r *PostsRepo) FindAll(ctx context.Context, filter posts.PostListFilter) (*
 	}
 
 	if filter.AuthorID != "" {
-		conditions = append(conditions, fmt.Sprintf("p.author_id = $%d", argIdx))
+		conditions = append(conditions, coAuthoredBy(fmt.Sprintf("$%d", argIdx)))
 		args = append(args, filter.AuthorID)
 		argIdx++
 	}
@@ -68,8 +71,8 @@ func (r *PostsRepo) FindAll(ctx context.Context, filter posts.PostListFilter) (*
 	offset := (filter.Page - 1) * filter.Limit
 	query := fmt.Sprintf(`
 		SELECT p.id, p.title, p.slug, p.excerpt, p.body, p.cover_url, 
-			   p.status, p.author_id, p.category_id, p.published_at,
-			   p.meta_title, p.meta_description, p.created_at, p.updated_at,
+			   p.status, p.author_id, p.category_id, p.published_at, p.scheduled_at, p.expires_at,
+			   p.meta_title, p.meta_description, p.created_at, p.updated_at, p.version,
 			   COALESCE(c.name, '') as category_name, COALESCE(c.slug, '') as category_slug,
 			   COALESCE(u.name, '') as author_name
 		FROM posts p
@@ -94,8 +97,8 @@ func (r *PostsRepo) FindAll(ctx context.Context, filter posts.PostListFilter) (*
 		var catName, catSlug, authorName string
 		err := rows.Scan(
 			&p.ID, &p.Title, &p.Slug, &p.Excerpt, &p.Body, &p.CoverURL,
-			&p.Status, &p.AuthorID, &p.CategoryID, &p.PublishedAt,
-			&p.MetaTitle, &p.MetaDesc, &p.CreatedAt, &p.UpdatedAt,
+			&p.Status, &p.AuthorID, &p.CategoryID, &p.PublishedAt, &p.ScheduledAt, &p.ExpiresAt,
+			&p.MetaTitle, &p.MetaDesc, &p.CreatedAt, &p.UpdatedAt, &p.Version,
 			&catName, &catSlug, &authorName,
 		)
 		if err != nil {
@@ -109,6 +112,9 @@ func (r *PostsRepo) FindAll(ctx context.Context, filter posts.PostListFilter) (*
 		}
 		items = append(items, p)
 	}
+	if err := r.loadContributors(ctx, items); err != nil {
+		return nil, err
+	}
 
 	return &posts.PostListResult{
 		Items:      items,
@@ -122,8 +128,8 @@ func (r *PostsRepo) FindAll(ctx context.Context, filter posts.PostListFilter) (*
 func (r *PostsRepo) FindBySlug(ctx context.Context, slug string) (*posts.Post, error) {
 	query := `
 		SELECT p.id, p.title, p.slug, p.excerpt, p.body, p.cover_url,
-			   p.status, p.author_id, p.category_id, p.published_at,
-			   p.meta_title, p.meta_description, p.created_at, p.updated_at,
+			   p.status, p.author_id, p.category_id, p.published_at, p.scheduled_at, p.expires_at,
+			   p.meta_title, p.meta_description, p.created_at, p.updated_at, p.version, p.language,
 			   COALESCE(c.name, '') as category_name, COALESCE(c.slug, '') as category_slug,
 			   COALESCE(u.name, '') as author_name
 		FROM posts p
@@ -137,8 +143,8 @@ func (r *PostsRepo) FindBySlug(ctx context.Context, slug string) (*posts.Post, e
 func (r *PostsRepo) FindByID(ctx context.Context, id string) (*posts.Post, error) {
 	query := `
 		SELECT p.id, p.title, p.slug, p.excerpt, p.body, p.cover_url,
-			   p.status, p.author_id, p.category_id, p.published_at,
-			   p.meta_title, p.meta_description, p.created_at, p.updated_at,
+			   p.status, p.author_id, p.category_id, p.published_at, p.scheduled_at, p.expires_at,
+			   p.meta_title, p.meta_description, p.created_at, p.updated_at, p.version, p.language,
 			   COALESCE(c.name, '') as category_name, COALESCE(c.slug, '') as category_slug,
 			   COALESCE(u.name, '') as author_name
 		FROM posts p
@@ -155,8 +161,8 @@ func (r *PostsRepo) scanSinglePost(ctx context.Context, query string, arg interf
 
 	err := r.db.QueryRow(ctx, query, arg).Scan(
 		&p.ID, &p.Title, &p.Slug, &p.Excerpt, &p.Body, &p.CoverURL,
-		&p.Status, &p.AuthorID, &p.CategoryID, &p.PublishedAt,
-		&p.MetaTitle, &p.MetaDesc, &p.CreatedAt, &p.UpdatedAt,
+		&p.Status, &p.AuthorID, &p.CategoryID, &p.PublishedAt, &p.ScheduledAt, &p.ExpiresAt,
+		&p.MetaTitle, &p.MetaDesc, &p.CreatedAt, &p.UpdatedAt, &p.Version, &p.Language,
 		&catName, &catSlug, &authorName,
 	)
 	if err == pgx.ErrNoRows {
@@ -173,6 +179,12 @@ func (r *PostsRepo) scanSinglePost(ctx context.Context, query string, arg interf
 		p.Author = &posts.Author{ID: p.AuthorID, Name: authorName}
 	}
 
+	single := []posts.Post{p}
+	if err := r.loadContributors(ctx, single); err != nil {
+		return nil, err
+	}
+	p.Authors = single[0].Authors
+
 	// Load tags
 	tagRows, err := r.db.Query(ctx, `
 		SELECT t.id, t.name, t.slug
@@ -193,30 +205,318 @@ func (r *PostsRepo) scanSinglePost(ctx context.Context, query string, arg interf
 	return &p, nil
 }
 
-func (r *PostsRepo) Create(ctx context.Context, post *posts.Post) error {
-	_, err := r.db.Exec(ctx, `
+// Create inserts the post, credits its author and stores rev, its first
+// revision, in one transaction.
+func (r *PostsRepo) Create(ctx context.Context, post *posts.Post, rev *posts.Revision) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
 		INSERT INTO posts (id, title, slug, excerpt, body, cover_url, status, author_id, category_id,
-						   meta_title, meta_description, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+						   meta_title, meta_description, created_at, updated_at, language)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`, post.ID, post.Title, post.Slug, post.Excerpt, post.Body, post.CoverURL,
 		post.Status, post.AuthorID, post.CategoryID,
-		post.MetaTitle, post.MetaDesc, post.CreatedAt, post.UpdatedAt)
+		post.MetaTitle, post.MetaDesc, post.CreatedAt, post.UpdatedAt, post.Language)
+	if err != nil {
+		return mapSlugConflict(err)
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO post_authors (post_id, user_id, role, position) VALUES ($1, $2, 'author', 0)
+		ON CONFLICT DO NOTHING
+	`, post.ID, post.AuthorID)
+	if err != nil {
+		return err
+	}
+	if err := insertRevision(ctx, tx, rev); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// SetContributors replaces the byline of a post.
+func (r *PostsRepo) SetContributors(ctx context.Context, postID string, list []posts.Contributor) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM post_authors WHERE post_id = $1`, postID); err != nil {
+		return err
+	}
+	for _, c := range list {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO post_authors (post_id, user_id, role, position) VALUES ($1, $2, $3, $4)
+		`, postID, c.UserID, string(c.Role), c.Position)
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return fmt.Errorf("%w: %s", posts.ErrUnknownContributor, c.UserID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// loadContributors fills the byline of each post.
+func (r *PostsRepo) loadContributors(ctx context.Context, items []posts.Post) error {
+	if len(items) == 0 {
+		return nil
+	}
+	ids := make([]string, len(items))
+	index := make(map[string]int, len(items))
+	for i, p := range items {
+		ids[i] = p.ID
+		index[p.ID] = i
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT pa.post_id, pa.user_id, u.name, COALESCE(u.avatar, ''), pa.role, pa.position
+		FROM post_authors pa
+		JOIN users u ON u.id = pa.user_id
+		WHERE pa.post_id = ANY($1)
+		ORDER BY pa.post_id, pa.position, pa.created_at
+	`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var postID string
+		var c posts.Contributor
+		if err := rows.Scan(&postID, &c.UserID, &c.Name, &c.Avatar, &c.Role, &c.Position); err != nil {
+			return err
+		}
+		if i, ok := index[postID]; ok {
+			items[i].Authors = append(items[i].Authors, c)
+		}
+	}
+	return rows.Err()
+}
+
+// inCategoryTree is a condition matching posts in the category whose slug
+// is bound to placeholder or in any of its subcategories.
+func inCategoryTree(placeholder string) string {
+	return `p.category_id IN (
+			WITH RECURSIVE tree AS (
+				SELECT id FROM categories WHERE slug = ` + placeholder + `
+				UNION ALL
+				SELECT c.id FROM categories c JOIN tree ON c.parent_id = tree.id
+			)
+			SELECT id FROM tree
+		)`
+}
+
+// coAuthoredBy is a condition matching posts owned by or crediting the user
+// bound to placeholder.
+func coAuthoredBy(placeholder string) string {
+	return "(p.author_id = " + placeholder +
+		" OR EXISTS (SELECT 1 FROM post_authors pa WHERE pa.post_id = p.id AND pa.user_id = " + placeholder + "))"
+}
+
+// SetTags replaces the tags of a post.
+func (r *PostsRepo) SetTags(ctx context.Context, postID string, tagIDs []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM post_tags WHERE post_id = $1`, postID); err != nil {
+		return err
+	}
+	if len(tagIDs) > 0 {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO post_tags (post_id, tag_id)
+			SELECT $1, t.id FROM tags t WHERE t.id = ANY($2)
+			ON CONFLICT DO NOTHING
+		`, postID, tagIDs); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ArchiveExpired moves up to limit PUBLISHED posts whose expires_at has
+// passed to ARCHIVED and returns them. Rows are claimed with FOR UPDATE SKIP
+// LOCKED so concurrent replicas never archive the same post twice.
+func (r *PostsRepo) ArchiveExpired(ctx context.Context, limit int) ([]posts.Post, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH due AS (
+			SELECT id FROM posts
+			WHERE status = 'PUBLISHED' AND expires_at <= NOW() AND deleted_at IS NULL
+			ORDER BY expires_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE posts p SET status = 'ARCHIVED', updated_at = NOW()
+		FROM due
+		WHERE p.id = due.id
+		RETURNING p.id, p.title, p.slug, p.expires_at
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []posts.Post
+	for rows.Next() {
+		var p posts.Post
+		if err := rows.Scan(&p.ID, &p.Title, &p.Slug, &p.ExpiresAt); err != nil {
+			return nil, err
+		}
+		p.Status = posts.StatusArchived
+		items = append(items, p)
+	}
+	return items, rows.Err()
+}
+
+// MarkPublished sets a post PUBLISHED with the given published_at.
+func (r *PostsRepo) MarkPublished(ctx context.Context, id string, at time.Time) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE posts SET status = 'PUBLISHED', published_at = $2, scheduled_at = NULL, updated_at = NOW()
+		WHERE id = $1
+	`, id, at)
 	return err
 }
 
+// Update writes the post if it is still at post.Version, then bumps the
+// version. A concurrent change yields posts.ErrVersionConflict.
 func (r *PostsRepo) Update(ctx context.Context, post *posts.Post) error {
-	_, err := r.db.Exec(ctx, `
+	version, err := updatePost(ctx, r.db, post)
+	if err != nil {
+		return err
+	}
+	post.Version = version
+	return nil
+}
+
+// UpdateWithRevision writes the post like Update and stores revs in the
+// same transaction, so neither is saved without the other.
+func (r *PostsRepo) UpdateWithRevision(ctx context.Context, post *posts.Post, revs ...*posts.Revision) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	version, err := updatePost(ctx, tx, post)
+	if err != nil {
+		return err
+	}
+	for _, rev := range revs {
+		if err := insertRevision(ctx, tx, rev); err != nil {
+			return err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	post.Version = version
+	return nil
+}
+
+// updatePost writes the post if it is still at post.Version and returns the
+// new version.
+func updatePost(ctx context.Context, q queryRower, post *posts.Post) (int, error) {
+	var version int
+	err := q.QueryRow(ctx, `
 		UPDATE posts SET title=$2, slug=$3, excerpt=$4, body=$5, cover_url=$6,
 			   status=$7, category_id=$8, published_at=$9, scheduled_at=$10,
-			   meta_title=$11, meta_description=$12, updated_at=$13,
-			   search_vector = to_tsvector('english', COALESCE($2,'') || ' ' || COALESCE($4,'') || ' ' || COALESCE($5,''))
-		WHERE id=$1
+			   meta_title=$11, meta_description=$12, updated_at=$13, expires_at=$15, language=$16,
+			   version = version + 1
+		WHERE id=$1 AND version=$14
+		RETURNING version
 	`, post.ID, post.Title, post.Slug, post.Excerpt, post.Body, post.CoverURL,
 		post.Status, post.CategoryID, post.PublishedAt, post.ScheduledAt,
-		post.MetaTitle, post.MetaDesc, post.UpdatedAt)
+		post.MetaTitle, post.MetaDesc, post.UpdatedAt, post.Version, post.ExpiresAt, post.Language).Scan(&version)
+	if err == pgx.ErrNoRows {
+		return 0, posts.ErrVersionConflict
+	}
+	if err != nil {
+		return 0, mapSlugConflict(err)
+	}
+	return version, nil
+}
+
+// mapSlugConflict turns a unique violation on posts.slug into posts.ErrSlugConflict.
+func mapSlugConflict(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "posts_slug_key" {
+		return posts.ErrSlugConflict
+	}
 	return err
 }
 
+// SlugTaken reports whether slug is the current or a former slug of a post other than excludePostID.
+func (r *PostsRepo) SlugTaken(ctx context.Context, slug, excludePostID string) (bool, error) {
+	var taken bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM posts WHERE slug = $1 AND id != $2)
+		    OR EXISTS(SELECT 1 FROM post_slug_history WHERE slug = $1 AND post_id != $2)
+	`, slug, excludePostID).Scan(&taken)
+	return taken, err
+}
+
+// RecordSlugChange stores oldSlug as a redirect to the post and drops newSlug
+// from its history (a post renamed back to an earlier slug).
+func (r *PostsRepo) RecordSlugChange(ctx context.Context, postID, oldSlug, newSlug string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO post_slug_history (slug, post_id, created_at) VALUES ($1, $2, NOW())
+		ON CONFLICT (slug) DO UPDATE SET post_id = EXCLUDED.post_id, created_at = NOW()
+	`, oldSlug, postID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx,
+		`DELETE FROM post_slug_history WHERE slug = $1 AND post_id = $2`, newSlug, postID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// SlugArchived reports whether slug is the current or a former slug of an
+// ARCHIVED post.
+func (r *PostsRepo) SlugArchived(ctx context.Context, slug string) (bool, error) {
+	var archived bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS(
+			SELECT 1 FROM posts p
+			LEFT JOIN post_slug_history h ON h.post_id = p.id AND h.slug = $1
+			WHERE (p.slug = $1 OR h.slug IS NOT NULL) AND p.status = 'ARCHIVED' AND p.deleted_at IS NULL
+		)
+	`, slug).Scan(&archived)
+	return archived, err
+}
+
+// FindSlugRedirect returns the current slug of the published post that used to live at oldSlug.
+func (r *PostsRepo) FindSlugRedirect(ctx context.Context, oldSlug string) (string, error) {
+	var slug string
+	err := r.db.QueryRow(ctx, `
+		SELECT p.slug FROM post_slug_history h
+		JOIN posts p ON p.id = h.post_id
+		WHERE h.slug = $1 AND p.status = 'PUBLISHED' AND p.deleted_at IS NULL
+	`, oldSlug).Scan(&slug)
+	if err == pgx.ErrNoRows {
+		return "", nil
+	}
+	return slug, err
+}
+
 func (r *PostsRepo) Delete(ctx context.Context, id string) error {
 	_, err := r.db.Exec(ctx, `DELETE FROM posts WHERE id = $1`, id)
 	return err
@@ -232,36 +532,206 @@ func (r *PostsRepo) UpdateStatus(ctx context.Context, id string, status posts.Po
 	return err
 }
 
-// Search performs Postgres full-text search.
-func (r *PostsRepo) Search(ctx context.Context, query string, page, limit int, sort string) (*posts.PostListResult, error) {
-	offset := (page - 1) * limit
+// PublishDue atomically publishes up to limit SCHEDULED posts that are due.
+// Rows are claimed with FOR UPDATE SKIP LOCKED so that concurrent callers
+// (e.g. several API replicas) never publish the same post twice.
+func (r *PostsRepo) PublishDue(ctx context.Context, limit int) ([]posts.Post, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH due AS (
+			SELECT id FROM posts
+			WHERE status = 'SCHEDULED' AND scheduled_at <= NOW() AND deleted_at IS NULL
+			ORDER BY scheduled_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE posts p SET status = 'PUBLISHED', published_at = NOW(), updated_at = NOW()
+		FROM due
+		WHERE p.id = due.id
+		RETURNING p.id, p.title, p.slug, p.published_at
+	`, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	orderBy := "ts_rank_cd(p.search_vector, websearch_to_tsquery('english', $1)) DESC"
-	if sort == "newest" {
-		orderBy = "p.published_at DESC NULLS LAST"
+	var items []posts.Post
+	for rows.Next() {
+		var p posts.Post
+		if err := rows.Scan(&p.ID, &p.Title, &p.Slug, &p.PublishedAt); err != nil {
+			return nil, err
+		}
+		p.Status = posts.StatusPublished
+		items = append(items, p)
 	}
+	return items, rows.Err()
+}
 
-	// Count
-	var total int
-	err := r.db.QueryRow(ctx, `
-		SELECT COUNT(*) FROM posts p
-		WHERE p.status = 'PUBLISHED'
-		  AND p.search_vector @@ websearch_to_tsquery('english', $1)
-	`, query).Scan(&total)
+// FindSeriesNav returns the series a post belongs to, with its published
+// position and the previous/next published posts in each series.
+func (r *PostsRepo) FindSeriesNav(ctx context.Context, postID string) ([]posts.SeriesNav, error) {
+	rows, err := r.db.Query(ctx, `
+		WITH entries AS (
+			SELECT ps.series_id, ps.post_id, p.title, p.slug,
+			       ROW_NUMBER() OVER (PARTITION BY ps.series_id ORDER BY ps.position, p.published_at) AS idx,
+			       COUNT(*) OVER (PARTITION BY ps.series_id) AS total
+			FROM post_series ps
+			JOIN posts p ON p.id = ps.post_id
+			WHERE p.status = 'PUBLISHED' AND p.deleted_at IS NULL
+			  AND ps.series_id IN (SELECT series_id FROM post_series WHERE post_id = $1)
+		)
+		SELECT s.id, s.title, s.slug, cur.idx, cur.total,
+		       COALESCE(prev.title, ''), COALESCE(prev.slug, ''),
+		       COALESCE(nxt.title, ''), COALESCE(nxt.slug, '')
+		FROM entries cur
+		JOIN series s ON s.id = cur.series_id
+		LEFT JOIN entries prev ON prev.series_id = cur.series_id AND prev.idx = cur.idx - 1
+		LEFT JOIN entries nxt ON nxt.series_id = cur.series_id AND nxt.idx = cur.idx + 1
+		WHERE cur.post_id = $1
+		ORDER BY s.title
+	`, postID)
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	rows, err := r.db.Query(ctx, fmt.Sprintf(`
-		SELECT p.id, p.title, p.slug, p.excerpt, p.cover_url, p.published_at,
-			   COALESCE(c.name, '') as category
+	var items []posts.SeriesNav
+	for rows.Next() {
+		var n posts.SeriesNav
+		var prevTitle, prevSlug, nextTitle, nextSlug string
+		if err := rows.Scan(&n.ID, &n.Title, &n.Slug, &n.Position, &n.Total,
+			&prevTitle, &prevSlug, &nextTitle, &nextSlug); err != nil {
+			return nil, err
+		}
+		if prevSlug != "" {
+			n.Previous = &posts.SeriesEntry{Title: prevTitle, Slug: prevSlug}
+		}
+		if nextSlug != "" {
+			n.Next = &posts.SeriesEntry{Title: nextTitle, Slug: nextSlug}
+		}
+		items = append(items, n)
+	}
+	return items, nil
+}
+
+// searchMatch matches the tsquery $1 against posts, parsing it with each
+// post's own text search configuration so stemming agrees with the index.
+// Every language gets its own indexable predicate.
+func searchMatch(prefix bool) string {
+	q := "websearch_to_tsquery('%[1]s', $1)"
+	if prefix {
+		q = "to_tsquery('%[1]s', $1 || ':*')"
+	}
+	var parts []string
+	for _, lang := range []posts.Language{posts.LanguageIndonesian, posts.LanguageEnglish} {
+		parts = append(parts, fmt.Sprintf("(p.language = '%s' AND p.search_vector @@ "+q+")", lang, lang.SearchConfig()))
+	}
+	return "(" + strings.Join(parts, " OR ") + ")"
+}
+
+// searchRank ranks a post against $1 with its own configuration; posts in
+// the query language (bound to langParam) come first at equal relevance.
+func searchRank(prefix bool, langParam string) string {
+	q := "websearch_to_tsquery(netpulse_ts_config(p.language), $1)"
+	if prefix {
+		q = "to_tsquery(netpulse_ts_config(p.language), $1 || ':*')"
+	}
+	return "ts_rank_cd(p.search_vector, " + q + ") * CASE WHEN p.language = " + langParam + " THEN 1.0 ELSE 0.5 END::float4"
+}
+
+// searchFuzzyMinHits is the number of full-text hits below which Search
+// also returns posts whose title or tags are trigram-similar to the query.
+const searchFuzzyMinHits = 3
+
+// searchCompact is the query without spaces, so "ipv 6" matches "IPv6".
+const searchCompact = `regexp_replace($1, '\s+', '', 'g')`
+
+// searchFuzzy matches titles and tag names word-similar (pg_trgm) to $1 or
+// to searchCompact, so "mikrotic" finds "MikroTik".
+const searchFuzzy = `($1 <% p.title OR ` + searchCompact + ` <% p.title OR EXISTS (
+			SELECT 1 FROM post_tags pt JOIN tags t ON t.id = pt.tag_id
+			WHERE pt.post_id = p.id AND ($1 <% t.name OR ` + searchCompact + ` <% t.name)
+		))`
+
+// searchArgs collects positional query arguments; $1 is always the query.
+type searchArgs []interface{}
+
+func (a *searchArgs) add(v interface{}) string {
+	*a = append(*a, v)
+	return fmt.Sprintf("$%d", len(*a))
+}
+
+// searchWhere builds the WHERE clause of a search. skip names a facet
+// dimension whose filter is left out.
+func searchWhere(f posts.SearchFilter, fuzzy bool, skip string) (string, searchArgs) {
+	args := searchArgs{f.Query}
+	match := searchMatch(false)
+	if fuzzy {
+		match = "(" + match + " OR " + searchFuzzy + ")"
+	}
+	conditions := []string{"p.status = 'PUBLISHED'", match}
+
+	if f.Category != "" && skip != posts.FacetCategory {
+		conditions = append(conditions, inCategoryTree(args.add(f.Category)))
+	}
+	for _, slug := range f.Tags {
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM post_tags pt JOIN tags t ON t.id = pt.tag_id
+			WHERE pt.post_id = p.id AND t.slug = `+args.add(slug)+`
+		)`)
+	}
+	if f.AuthorID != "" && skip != posts.FacetAuthor {
+		conditions = append(conditions, coAuthoredBy(args.add(f.AuthorID)))
+	}
+	if skip != posts.FacetYear {
+		if f.From != nil {
+			conditions = append(conditions, "p.published_at >= "+args.add(*f.From))
+		}
+		if f.To != nil {
+			conditions = append(conditions, "p.published_at <= "+args.add(*f.To))
+		}
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+// Search performs Postgres full-text search with filters and facet counts.
+// With fewer than searchFuzzyMinHits full-text hits, trigram matches on
+// titles and tags are added after them and the result is marked fuzzy.
+// Items carry ts_headline snippets of their title and body.
+func (r *PostsRepo) Search(ctx context.Context, f posts.SearchFilter) (*posts.PostListResult, error) {
+	offset := (f.Page - 1) * f.Limit
+
+	total, fuzzy, err := r.searchTotal(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+
+	where, args := searchWhere(f, fuzzy, "")
+	lang := args.add(f.Language)
+	orderBy := searchRank(false, lang) + " DESC"
+	if fuzzy {
+		orderBy = searchMatch(false) + " DESC, " + searchRank(false, lang) +
+			" + GREATEST(word_similarity($1, p.title), word_similarity(" + searchCompact + ", p.title)) DESC"
+	}
+	if f.Sort == "newest" {
+		orderBy = "p.published_at DESC NULLS LAST, p.language = " + lang + " DESC"
+	}
+
+	headline := func(field, opts string) string {
+		return "ts_headline(netpulse_ts_config(p.language), " + field +
+			", websearch_to_tsquery(netpulse_ts_config(p.language), $1), " + opts + ")"
+	}
+	query := fmt.Sprintf(`
+		SELECT p.id, p.title, p.slug, p.excerpt, p.cover_url, p.published_at, p.language,
+			   COALESCE(c.name, '') as category, COALESCE(c.slug, '') as category_slug,
+			   %s, %s
 		FROM posts p
 		LEFT JOIN categories c ON p.category_id = c.id
-		WHERE p.status = 'PUBLISHED'
-		  AND p.search_vector @@ websearch_to_tsquery('english', $1)
+		WHERE %s
 		ORDER BY %s
-		LIMIT $2 OFFSET $3
-	`, orderBy), query, limit, offset)
+		LIMIT %s OFFSET %s
+	`, headline("p.title", args.add(posts.TitleHeadlineOptions)), headline("p.body", args.add(posts.HeadlineOptions)),
+		where, orderBy, args.add(f.Limit), args.add(offset))
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -270,36 +740,117 @@ func (r *PostsRepo) Search(ctx context.Context, query string, page, limit int, s
 	var items []posts.Post
 	for rows.Next() {
 		var p posts.Post
-		var cat string
-		if err := rows.Scan(&p.ID, &p.Title, &p.Slug, &p.Excerpt, &p.CoverURL, &p.PublishedAt, &cat); err != nil {
+		var cat, catSlug, hlTitle, hlBody string
+		if err := rows.Scan(&p.ID, &p.Title, &p.Slug, &p.Excerpt, &p.CoverURL, &p.PublishedAt, &p.Language,
+			&cat, &catSlug, &hlTitle, &hlBody); err != nil {
 			return nil, err
 		}
 		if cat != "" {
-			p.Category = &posts.Category{Name: cat}
+			p.Category = &posts.Category{Name: cat, Slug: catSlug}
 		}
+		p.Highlight = &posts.Highlight{Title: posts.FormatHighlight(hlTitle), Body: posts.FormatHighlight(hlBody)}
 		items = append(items, p)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	facets, err := r.searchFacets(ctx, f, fuzzy)
+	if err != nil {
+		return nil, err
+	}
 
 	return &posts.PostListResult{
 		Items:      items,
-		Page:       page,
-		Limit:      limit,
+		Page:       f.Page,
+		Limit:      f.Limit,
 		Total:      total,
-		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
+		TotalPages: int(math.Ceil(float64(total) / float64(f.Limit))),
+		Language:   f.Language,
+		Fuzzy:      fuzzy,
+		Facets:     facets,
 	}, nil
 }
 
+// searchTotal counts the full-text hits, falling back to fuzzy matching
+// (reported by the second result) when there are too few.
+func (r *PostsRepo) searchTotal(ctx context.Context, f posts.SearchFilter) (int, bool, error) {
+	count := func(fuzzy bool) (int, error) {
+		where, args := searchWhere(f, fuzzy, "")
+		var n int
+		err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM posts p WHERE `+where, args...).Scan(&n)
+		return n, err
+	}
+	total, err := count(false)
+	if err != nil || total >= searchFuzzyMinHits {
+		return total, false, err
+	}
+	total, err = count(true)
+	return total, true, err
+}
+
+// searchFacets counts the search results per category, tag, author (byline)
+// and publication year.
+func (r *PostsRepo) searchFacets(ctx context.Context, f posts.SearchFilter, fuzzy bool) (*posts.SearchFacets, error) {
+	facet := func(dim, selectSQL, joinSQL, groupSQL, orderSQL string) ([]posts.FacetValue, error) {
+		where, args := searchWhere(f, fuzzy, dim)
+		limit := args.add(posts.MaxFacetValues)
+		rows, err := r.db.Query(ctx, `
+			SELECT `+selectSQL+`, COUNT(DISTINCT p.id) AS n
+			FROM posts p
+			`+joinSQL+`
+			WHERE `+where+`
+			GROUP BY `+groupSQL+`
+			ORDER BY `+orderSQL+`
+			LIMIT `+limit, args...)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		values := make([]posts.FacetValue, 0)
+		for rows.Next() {
+			var v posts.FacetValue
+			if err := rows.Scan(&v.Value, &v.Label, &v.Count); err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+		}
+		return values, rows.Err()
+	}
+
+	var out posts.SearchFacets
+	var err error
+	if out.Category, err = facet(posts.FacetCategory, "c.slug, c.name",
+		"JOIN categories c ON c.id = p.category_id", "c.slug, c.name", "n DESC, c.name"); err != nil {
+		return nil, err
+	}
+	if out.Tag, err = facet(posts.FacetTag, "t.slug, t.name",
+		"JOIN post_tags ft ON ft.post_id = p.id JOIN tags t ON t.id = ft.tag_id", "t.slug, t.name", "n DESC, t.name"); err != nil {
+		return nil, err
+	}
+	if out.Author, err = facet(posts.FacetAuthor, "u.id, u.name",
+		"JOIN post_authors fa ON fa.post_id = p.id JOIN users u ON u.id = fa.user_id", "u.id, u.name", "n DESC, u.name"); err != nil {
+		return nil, err
+	}
+	if out.Year, err = facet(posts.FacetYear, "y::text, ''",
+		"CROSS JOIN LATERAL (SELECT EXTRACT(YEAR FROM p.published_at)::int AS y WHERE p.published_at IS NOT NULL) yr", "y", "y DESC"); err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
 // Suggest returns autocomplete suggestions using FTS prefix matching.
-func (r *PostsRepo) Suggest(ctx context.Context, query string, limit int) ([]posts.SuggestResult, error) {
+func (r *PostsRepo) Suggest(ctx context.Context, query string, lang posts.Language, limit int) ([]posts.SuggestResult, error) {
 	rows, err := r.db.Query(ctx, `
 		SELECT p.title, p.slug, COALESCE(c.name, '') as category
 		FROM posts p
 		LEFT JOIN categories c ON p.category_id = c.id
 		WHERE p.status = 'PUBLISHED'
-		  AND p.search_vector @@ to_tsquery('english', $1 || ':*')
-		ORDER BY ts_rank_cd(p.search_vector, to_tsquery('english', $1 || ':*')) DESC
-		LIMIT $2
-	`, query, limit)
+		  AND `+searchMatch(true)+`
+		ORDER BY `+searchRank(true, "$2")+` DESC
+		LIMIT $3
+	`, query, lang, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -317,6 +868,120 @@ func (r *PostsRepo) Suggest(ctx context.Context, query string, limit int) ([]pos
 	return results, nil
 }
 
+// RefreshSearchTerms rebuilds the search_terms view from the current
+// titles and tags without blocking suggestions that read it.
+func (r *PostsRepo) RefreshSearchTerms(ctx context.Context) error {
+	_, err := r.db.Exec(ctx, `REFRESH MATERIALIZED VIEW CONCURRENTLY search_terms`)
+	return err
+}
+
+// SuggestCorrections returns up to limit "did you mean" alternatives for
+// query: the query with each word replaced by the most similar word of a
+// published title or a tag name, followed by similar tag names. Title words
+// come from the search_terms view, so new titles show up once it is
+// refreshed.
+func (r *PostsRepo) SuggestCorrections(ctx context.Context, query string, limit int) ([]string, error) {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return nil, nil
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT q.word, COALESCE(best.term, q.word)
+		FROM unnest($1::text[]) WITH ORDINALITY AS q(word, n)
+		LEFT JOIN LATERAL (
+			SELECT term FROM search_terms
+			WHERE length(q.word) >= 3 AND term % q.word
+			ORDER BY similarity(term, q.word) DESC, term
+			LIMIT 1
+		) best ON true
+		ORDER BY q.n
+	`, words)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	corrected := make([]string, 0, len(words))
+	for rows.Next() {
+		var word, term string
+		if err := rows.Scan(&word, &term); err != nil {
+			return nil, err
+		}
+		corrected = append(corrected, term)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	original := strings.Join(words, " ")
+	seen := map[string]bool{original: true}
+	var results []string
+	add := func(s string) {
+		if key := strings.ToLower(s); !seen[key] && len(results) < limit {
+			seen[key] = true
+			results = append(results, s)
+		}
+	}
+	add(strings.Join(corrected, " "))
+
+	tagRows, err := r.db.Query(ctx, `
+		SELECT name FROM tags
+		WHERE name % $1
+		ORDER BY similarity(name, $1) DESC, name
+		LIMIT $2
+	`, query, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer tagRows.Close()
+	for tagRows.Next() {
+		var name string
+		if err := tagRows.Scan(&name); err != nil {
+			return nil, err
+		}
+		add(name)
+	}
+	return results, tagRows.Err()
+}
+
+// FindForReindex returns up to limit posts (including deleted ones) with
+// IDs after afterID, in ID order, with the fields their search vector is
+// built from.
+func (r *PostsRepo) FindForReindex(ctx context.Context, afterID string, limit int) ([]posts.Post, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, title, excerpt, body, language FROM posts
+		WHERE id > $1
+		ORDER BY id
+		LIMIT $2
+	`, afterID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []posts.Post
+	for rows.Next() {
+		var p posts.Post
+		if err := rows.Scan(&p.ID, &p.Title, &p.Excerpt, &p.Body, &p.Language); err != nil {
+			return nil, err
+		}
+		items = append(items, p)
+	}
+	return items, rows.Err()
+}
+
+// Reindex sets the post's language and rebuilds its search vector without
+// touching updated_at or version.
+func (r *PostsRepo) Reindex(ctx context.Context, id string, lang posts.Language) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE posts SET language = $2,
+			   search_vector = posts_search_vector(title, excerpt, body, $2)
+		WHERE id = $1
+	`, id, lang)
+	return err
+}
+
 // FindPublishedByAuthor returns paginated published posts by an author.
 func (r *PostsRepo) FindPublishedByAuthor(ctx context.Context, authorID string, page, limit int) (*posts.PostListResult, error) {
 	if page < 1 {
@@ -328,20 +993,20 @@ func (r *PostsRepo) FindPublishedByAuthor(ctx context.Context, authorID string,
 
 	var total int
 	r.db.QueryRow(ctx,
-		`SELECT COUNT(*) FROM posts WHERE author_id = $1 AND status = 'PUBLISHED' AND deleted_at IS NULL`,
+		`SELECT COUNT(*) FROM posts p WHERE `+coAuthoredBy("$1")+` AND p.status = 'PUBLISHED' AND p.deleted_at IS NULL`,
 		authorID).Scan(&total)
 
 	offset := (page - 1) * limit
 	rows, err := r.db.Query(ctx, `
 		SELECT p.id, p.title, p.slug, p.excerpt, p.body, p.cover_url,
-		       p.status, p.author_id, p.category_id, p.published_at,
-		       p.meta_title, p.meta_description, p.created_at, p.updated_at,
+		       p.status, p.author_id, p.category_id, p.published_at, p.scheduled_at, p.expires_at,
+		       p.meta_title, p.meta_description, p.created_at, p.updated_at, p.version,
 		       COALESCE(c.name, '') as category_name, COALESCE(c.slug, '') as category_slug,
 		       COALESCE(u.name, '') as author_name
 		FROM posts p
 		LEFT JOIN categories c ON p.category_id = c.id
 		LEFT JOIN users u ON p.author_id = u.id
-		WHERE p.author_id = $1 AND p.status = 'PUBLISHED' AND p.deleted_at IS NULL
+		WHERE `+coAuthoredBy("$1")+` AND p.status = 'PUBLISHED' AND p.deleted_at IS NULL
 		ORDER BY p.published_at DESC NULLS LAST
 		LIMIT $2 OFFSET $3
 	`, authorID, limit, offset)
@@ -356,8 +1021,8 @@ func (r *PostsRepo) FindPublishedByAuthor(ctx context.Context, authorID string,
 		var catName, catSlug, authorName string
 		err := rows.Scan(
 			&p.ID, &p.Title, &p.Slug, &p.Excerpt, &p.Body, &p.CoverURL,
-			&p.Status, &p.AuthorID, &p.CategoryID, &p.PublishedAt,
-			&p.MetaTitle, &p.MetaDesc, &p.CreatedAt, &p.UpdatedAt,
+			&p.Status, &p.AuthorID, &p.CategoryID, &p.PublishedAt, &p.ScheduledAt, &p.ExpiresAt,
+			&p.MetaTitle, &p.MetaDesc, &p.CreatedAt, &p.UpdatedAt, &p.Version,
 			&catName, &catSlug, &authorName,
 		)
 		if err != nil {
@@ -371,6 +1036,9 @@ func (r *PostsRepo) FindPublishedByAuthor(ctx context.Context, authorID string,
 		}
 		items = append(items, p)
 	}
+	if err := r.loadContributors(ctx, items); err != nil {
+		return nil, err
+	}
 
 	return &posts.PostListResult{
 		Items:      items,
@@ -379,4 +1047,271 @@ func (r *PostsRepo) FindPublishedByAuthor(ctx context.Context, authorID string,
 		Total:      total,
 		TotalPages: int(math.Ceil(float64(total) / float64(limit))),
 	}, nil
-}
\ No newline at end of file
+}
+
+// insertRevision stores a content snapshot of a post.
+func insertRevision(ctx context.Context, q queryRower, rev *posts.Revision) error {
+	return q.QueryRow(ctx, `
+		INSERT INTO post_revisions (post_id, title, body, excerpt, editor_id, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING id, created_at
+	`, rev.PostID, rev.Title, rev.Body, rev.Excerpt, rev.EditorID).Scan(&rev.ID, &rev.CreatedAt)
+}
+
+// FindRevisions returns a post's revisions (without bodies), newest first.
+func (r *PostsRepo) FindRevisions(ctx context.Context, postID string) ([]posts.Revision, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT pr.id, pr.post_id, pr.title, COALESCE(pr.excerpt, ''), pr.editor_id,
+		       COALESCE(u.name, '') AS editor_name, pr.created_at
+		FROM post_revisions pr
+		LEFT JOIN users u ON pr.editor_id = u.id
+		WHERE pr.post_id = $1
+		ORDER BY pr.created_at DESC, pr.id DESC
+	`, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []posts.Revision
+	for rows.Next() {
+		var rev posts.Revision
+		if err := rows.Scan(&rev.ID, &rev.PostID, &rev.Title, &rev.Excerpt, &rev.EditorID,
+			&rev.EditorName, &rev.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, rev)
+	}
+	return items, nil
+}
+
+// FindRevision returns a single revision belonging to a post.
+func (r *PostsRepo) FindRevision(ctx context.Context, postID string, revisionID int64) (*posts.Revision, error) {
+	var rev posts.Revision
+	err := r.db.QueryRow(ctx, `
+		SELECT pr.id, pr.post_id, pr.title, pr.body, COALESCE(pr.excerpt, ''), pr.editor_id,
+		       COALESCE(u.name, '') AS editor_name, pr.created_at
+		FROM post_revisions pr
+		LEFT JOIN users u ON pr.editor_id = u.id
+		WHERE pr.post_id = $1 AND pr.id = $2
+	`, postID, revisionID).Scan(&rev.ID, &rev.PostID, &rev.Title, &rev.Body, &rev.Excerpt,
+		&rev.EditorID, &rev.EditorName, &rev.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, posts.ErrRevisionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rev, nil
+}
+
+// CountRevisions returns how many revisions a post has.
+func (r *PostsRepo) CountRevisions(ctx context.Context, postID string) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM post_revisions WHERE post_id = $1`, postID).Scan(&n)
+	return n, err
+}
+
+// CreatePreviewToken stores a preview link record.
+func (r *PostsRepo) CreatePreviewToken(ctx context.Context, p *posts.PreviewToken) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO post_preview_tokens (id, post_id, created_by, note, expires_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, p.ID, p.PostID, p.CreatedBy, p.Note, p.ExpiresAt, p.CreatedAt)
+	return err
+}
+
+// FindPreviewTokens returns a post's preview links, newest first.
+func (r *PostsRepo) FindPreviewTokens(ctx context.Context, postID string) ([]posts.PreviewToken, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT t.id, t.post_id, t.created_by, COALESCE(u.name, ''), t.note, t.expires_at,
+		       t.revoked_at, t.view_count, t.last_viewed_at, t.created_at
+		FROM post_preview_tokens t
+		LEFT JOIN users u ON u.id = t.created_by
+		WHERE t.post_id = $1
+		ORDER BY t.created_at DESC
+	`, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []posts.PreviewToken
+	for rows.Next() {
+		var t posts.PreviewToken
+		if err := rows.Scan(&t.ID, &t.PostID, &t.CreatedBy, &t.CreatorName, &t.Note, &t.ExpiresAt,
+			&t.RevokedAt, &t.ViewCount, &t.LastViewedAt, &t.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, t)
+	}
+	return items, nil
+}
+
+// RevokePreviewToken marks a post's preview link as revoked.
+func (r *PostsRepo) RevokePreviewToken(ctx context.Context, postID, id string) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE post_preview_tokens SET revoked_at = NOW()
+		WHERE id = $1 AND post_id = $2 AND revoked_at IS NULL
+	`, id, postID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("preview link not found")
+	}
+	return nil
+}
+
+// RecordPreviewView counts a view on an active token and returns its post ID.
+func (r *PostsRepo) RecordPreviewView(ctx context.Context, id string) (string, error) {
+	var postID string
+	err := r.db.QueryRow(ctx, `
+		UPDATE post_preview_tokens
+		SET view_count = view_count + 1, last_viewed_at = NOW()
+		WHERE id = $1 AND revoked_at IS NULL AND expires_at > NOW()
+		RETURNING post_id
+	`, id).Scan(&postID)
+	if err == pgx.ErrNoRows {
+		return "", fmt.Errorf("preview link not found")
+	}
+	return postID, err
+}
+
+// RequestChanges stores a change request's notes and moves the post from
+// IN_REVIEW to CHANGES_REQUESTED in one transaction.
+func (r *PostsRepo) RequestChanges(ctx context.Context, postID string, notes []posts.ReviewNote) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE posts SET status = 'CHANGES_REQUESTED', updated_at = NOW()
+		WHERE id = $1 AND status = 'IN_REVIEW'
+	`, postID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("post is no longer in review")
+	}
+
+	for i := range notes {
+		if err := insertReviewNote(ctx, tx, &notes[i]); err != nil {
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// CreateReviewNote stores a single review note.
+func (r *PostsRepo) CreateReviewNote(ctx context.Context, note *posts.ReviewNote) error {
+	return insertReviewNote(ctx, r.db, note)
+}
+
+type queryRower interface {
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+func insertReviewNote(ctx context.Context, q queryRower, n *posts.ReviewNote) error {
+	var start, end *int
+	var quote *string
+	if n.Anchor != nil {
+		start, end, quote = &n.Anchor.Start, &n.Anchor.End, &n.Anchor.Quote
+	}
+	return q.QueryRow(ctx, `
+		INSERT INTO post_review_notes (post_id, parent_id, author_id, kind, body,
+			anchor_start, anchor_end, anchor_quote, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING id
+	`, n.PostID, n.ParentID, n.AuthorID, string(n.Kind), n.Body, start, end, quote, n.CreatedAt).Scan(&n.ID)
+}
+
+const reviewNoteColumns = `
+	n.id, n.post_id, n.parent_id, n.author_id, COALESCE(u.name, ''), n.kind, n.body,
+	n.anchor_start, n.anchor_end, n.anchor_quote, n.resolved_at, n.resolved_by, n.created_at`
+
+func scanReviewNote(row pgx.Row) (*posts.ReviewNote, error) {
+	var n posts.ReviewNote
+	var start, end *int
+	var quote *string
+	if err := row.Scan(&n.ID, &n.PostID, &n.ParentID, &n.AuthorID, &n.AuthorName, &n.Kind, &n.Body,
+		&start, &end, &quote, &n.ResolvedAt, &n.ResolvedBy, &n.CreatedAt); err != nil {
+		return nil, err
+	}
+	if start != nil && end != nil {
+		n.Anchor = &posts.NoteAnchor{Start: *start, End: *end}
+		if quote != nil {
+			n.Anchor.Quote = *quote
+		}
+	}
+	return &n, nil
+}
+
+// FindReviewNotes returns all notes and replies of a post, oldest first.
+func (r *PostsRepo) FindReviewNotes(ctx context.Context, postID string) ([]posts.ReviewNote, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+reviewNoteColumns+`
+		FROM post_review_notes n
+		LEFT JOIN users u ON u.id = n.author_id
+		WHERE n.post_id = $1
+		ORDER BY n.created_at, n.id
+	`, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []posts.ReviewNote
+	for rows.Next() {
+		n, err := scanReviewNote(rows)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, *n)
+	}
+	return items, nil
+}
+
+// FindReviewNote returns a single note belonging to a post.
+func (r *PostsRepo) FindReviewNote(ctx context.Context, postID string, id int64) (*posts.ReviewNote, error) {
+	n, err := scanReviewNote(r.db.QueryRow(ctx, `
+		SELECT `+reviewNoteColumns+`
+		FROM post_review_notes n
+		LEFT JOIN users u ON u.id = n.author_id
+		WHERE n.post_id = $1 AND n.id = $2
+	`, postID, id))
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("review note not found")
+	}
+	return n, err
+}
+
+// SetReviewNoteResolved resolves or reopens a note.
+func (r *PostsRepo) SetReviewNoteResolved(ctx context.Context, postID string, id int64, userID string, resolved bool) error {
+	var err error
+	if resolved {
+		_, err = r.db.Exec(ctx, `
+			UPDATE post_review_notes SET resolved_at = NOW(), resolved_by = $3
+			WHERE post_id = $1 AND id = $2 AND resolved_at IS NULL
+		`, postID, id, userID)
+	} else {
+		_, err = r.db.Exec(ctx, `
+			UPDATE post_review_notes SET resolved_at = NULL, resolved_by = NULL
+			WHERE post_id = $1 AND id = $2
+		`, postID, id)
+	}
+	return err
+}
+
+// CountOpenReviewNotes counts unresolved top-level notes of a post.
+func (r *PostsRepo) CountOpenReviewNotes(ctx context.Context, postID string) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FROM post_review_notes
+		WHERE post_id = $1 AND parent_id IS NULL AND resolved_at IS NULL
+	`, postID).Scan(&n)
+	return n, err
+}
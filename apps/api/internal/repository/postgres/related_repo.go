@@ -0,0 +1,125 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rapidtest/netpulse-api/internal/domain/posts"
+	"github.com/rapidtest/netpulse-api/internal/domain/related"
+)
+
+// RelatedRepo ranks "read next" suggestions.
+type RelatedRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewRelatedRepo(db *pgxpool.Pool) *RelatedRepo {
+	return &RelatedRepo{db: db}
+}
+
+// relatedCoViewWindow limits co-view signals to recent reading behaviour.
+const relatedCoViewWindow = "30 days"
+
+// FindRelated scores published posts against the source post. Candidates
+// share a tag, the category, search terms or readers with it; each signal is
+// normalized to 0..1 and weighted:
+//
+//   - tags: shared tags / tags of the source post
+//   - category: 1 if in the same category
+//   - text: ts_rank of the candidate against the source's most frequent lexemes
+//   - coviews: distinct recent co-viewers / the best candidate's co-viewers
+//   - recency: 1 / (1 + age in days / 30)
+func (r *RelatedRepo) FindRelated(ctx context.Context, idOrSlug string, w related.Weights, limit int) ([]related.Post, error) {
+	var srcID string
+	err := r.db.QueryRow(ctx, `
+		SELECT id FROM posts
+		WHERE (id = $1 OR slug = $1) AND status = 'PUBLISHED' AND deleted_at IS NULL
+		ORDER BY (id = $1) DESC
+		LIMIT 1
+	`, idOrSlug).Scan(&srcID)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("post not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		WITH src AS (
+			SELECT id, category_id, search_vector FROM posts WHERE id = $1
+		),
+		terms AS (
+			SELECT to_tsquery('simple', string_agg(quote_literal(t.lexeme), ' | ')) AS q
+			FROM (
+				SELECT lexeme FROM src, unnest(src.search_vector)
+				ORDER BY COALESCE(array_length(positions, 1), 1) DESC, lexeme
+				LIMIT 16
+			) t
+		),
+		shared AS (
+			SELECT pt.post_id, COUNT(*)::float8 / GREATEST((SELECT COUNT(*) FROM post_tags WHERE post_id = $1), 1) AS score
+			FROM post_tags pt
+			JOIN post_tags st ON st.tag_id = pt.tag_id AND st.post_id = $1
+			WHERE pt.post_id <> $1
+			GROUP BY pt.post_id
+		),
+		viewers AS (
+			SELECT DISTINCT ip_hash FROM post_views
+			WHERE post_id = $1 AND ip_hash <> '' AND created_at > NOW() - INTERVAL '`+relatedCoViewWindow+`'
+		),
+		coviews AS (
+			SELECT v.post_id, COUNT(DISTINCT v.ip_hash)::float8 AS n
+			FROM post_views v
+			JOIN viewers ON viewers.ip_hash = v.ip_hash
+			WHERE v.post_id <> $1 AND v.created_at > NOW() - INTERVAL '`+relatedCoViewWindow+`'
+			GROUP BY v.post_id
+		),
+		scored AS (
+			SELECT p.id,
+			       COALESCE(sh.score, 0) AS tags,
+			       CASE WHEN p.category_id IS NOT NULL AND p.category_id = src.category_id THEN 1.0 ELSE 0.0 END::float8 AS category,
+			       COALESCE(ts_rank(p.search_vector, terms.q, 32), 0)::float8 AS text,
+			       COALESCE(cv.n / NULLIF(MAX(cv.n) OVER (), 0), 0) AS coviews,
+			       (1 / (1 + EXTRACT(EPOCH FROM NOW() - COALESCE(p.published_at, p.created_at)) / 86400 / 30))::float8 AS recency
+			FROM posts p
+			CROSS JOIN src
+			CROSS JOIN terms
+			LEFT JOIN shared sh ON sh.post_id = p.id
+			LEFT JOIN coviews cv ON cv.post_id = p.id
+			WHERE p.id <> $1 AND p.status = 'PUBLISHED' AND p.deleted_at IS NULL
+			  AND (sh.post_id IS NOT NULL OR cv.post_id IS NOT NULL
+			       OR (p.category_id IS NOT NULL AND p.category_id = src.category_id)
+			       OR p.search_vector @@ terms.q)
+		)
+		SELECT p.id, p.title, p.slug, p.excerpt, p.cover_url, p.published_at,
+		       COALESCE(c.name, ''), COALESCE(c.slug, ''),
+		       $2::float8 * s.tags + $3::float8 * s.category + $4::float8 * s.text
+		       + $5::float8 * s.coviews + $6::float8 * s.recency AS score
+		FROM scored s
+		JOIN posts p ON p.id = s.id
+		LEFT JOIN categories c ON c.id = p.category_id
+		ORDER BY score DESC, p.published_at DESC NULLS LAST, p.id
+		LIMIT $7
+	`, srcID, w.Tags, w.Category, w.Text, w.CoViews, w.Recency, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []related.Post
+	for rows.Next() {
+		var p related.Post
+		var catName, catSlug string
+		if err := rows.Scan(&p.ID, &p.Title, &p.Slug, &p.Excerpt, &p.CoverURL, &p.PublishedAt,
+			&catName, &catSlug, &p.Score); err != nil {
+			return nil, err
+		}
+		if catName != "" {
+			p.Category = &posts.Category{Name: catName, Slug: catSlug}
+		}
+		items = append(items, p)
+	}
+	return items, rows.Err()
+}
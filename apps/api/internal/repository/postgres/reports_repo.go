@@ -0,0 +1,294 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rapidtest/netpulse-api/internal/domain/reports"
+)
+
+// ReportsRepo stores reader reports and the moderation queue built on them.
+type ReportsRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewReportsRepo(db *pgxpool.Pool) *ReportsRepo {
+	return &ReportsRepo{db: db}
+}
+
+const reportItemColumns = `i.id, i.target_type, i.target_id, i.post_id, i.status, i.open_reports, i.hidden_at,
+	i.prior_status, i.resolved_by, i.resolved_at, i.resolution_note, i.created_at, i.updated_at`
+
+func scanReportItem(row pgx.Row, it *reports.Item, extra ...interface{}) error {
+	dest := []interface{}{&it.ID, &it.TargetType, &it.TargetID, &it.PostID, &it.Status, &it.OpenReports, &it.HiddenAt,
+		&it.PriorStatus, &it.ResolvedBy, &it.ResolvedAt, &it.ResolutionNote, &it.CreatedAt, &it.UpdatedAt}
+	return row.Scan(append(dest, extra...)...)
+}
+
+// PublishedPostID returns the ID of a published post, or "" when there is
+// none.
+func (r *ReportsRepo) PublishedPostID(ctx context.Context, id string) (string, error) {
+	var postID string
+	err := r.db.QueryRow(ctx, `SELECT id FROM posts WHERE id = $1 AND status = 'PUBLISHED'`, id).Scan(&postID)
+	if err == pgx.ErrNoRows {
+		return "", nil
+	}
+	return postID, err
+}
+
+// Add files a report against a post or comment. It returns the report item
+// and false when the reporter had already reported the target.
+func (r *ReportsRepo) Add(ctx context.Context, targetType, targetID, postID string, rep *reports.Report) (*reports.Item, bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx, `
+		INSERT INTO report_items (target_type, target_id, post_id) VALUES ($1, $2, $3)
+		ON CONFLICT (target_type, target_id) DO UPDATE SET target_id = EXCLUDED.target_id
+		RETURNING id
+	`, targetType, targetID, postID).Scan(&rep.ItemID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	result, err := tx.Exec(ctx, `
+		INSERT INTO reports (item_id, reason, details, reporter_id, reporter_key, ip_hash)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (item_id, reporter_key) DO NOTHING
+	`, rep.ItemID, rep.Reason, rep.Details, rep.ReporterID, rep.ReporterKey, rep.IPHash)
+	if err != nil {
+		return nil, false, err
+	}
+	added := result.RowsAffected() > 0
+
+	// A new report reopens a resolved item.
+	query := `SELECT ` + reportItemColumns + ` FROM report_items i WHERE i.id = $1`
+	if added {
+		query = `UPDATE report_items i SET open_reports = open_reports + 1, status = 'open', updated_at = NOW()
+			WHERE i.id = $1 RETURNING ` + reportItemColumns
+	}
+	var it reports.Item
+	if err := scanReportItem(tx.QueryRow(ctx, query, rep.ItemID), &it); err != nil {
+		return nil, false, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, false, err
+	}
+	return &it, added, nil
+}
+
+// DistinctReporters counts the distinct IP addresses behind an item's open
+// reports, so one client cannot reach the hide threshold on its own.
+func (r *ReportsRepo) DistinctReporters(ctx context.Context, it *reports.Item) (int, error) {
+	var n int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(DISTINCT rp.ip_hash) FROM reports rp
+		WHERE rp.item_id = $1 AND ($2::timestamptz IS NULL OR rp.created_at > $2)
+	`, it.ID, it.ResolvedAt).Scan(&n)
+	return n, err
+}
+
+// Hide takes a reported target out of public view: an approved comment
+// goes back to PENDING, a published post to IN_REVIEW. It returns false
+// when the item is already hidden or the target not public.
+func (r *ReportsRepo) Hide(ctx context.Context, it *reports.Item) (bool, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback(ctx)
+
+	var hidden bool
+	err = tx.QueryRow(ctx, `SELECT hidden_at IS NOT NULL FROM report_items WHERE id = $1 FOR UPDATE`, it.ID).Scan(&hidden)
+	if err != nil || hidden {
+		return false, err
+	}
+
+	var prior string
+	switch it.TargetType {
+	case reports.TargetComment:
+		err = tx.QueryRow(ctx, `
+			UPDATE comments SET status = 'PENDING', updated_at = NOW()
+			WHERE id = $1 AND status = 'APPROVED' AND deleted_at IS NULL
+			RETURNING 'APPROVED'
+		`, it.TargetID).Scan(&prior)
+	case reports.TargetPost:
+		err = tx.QueryRow(ctx, `
+			UPDATE posts SET status = 'IN_REVIEW', updated_at = NOW()
+			WHERE id = $1 AND status = 'PUBLISHED'
+			RETURNING 'PUBLISHED'
+		`, it.TargetID).Scan(&prior)
+	default:
+		return false, fmt.Errorf("unknown report target type: %s", it.TargetType)
+	}
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE report_items SET hidden_at = NOW(), prior_status = $2, updated_at = NOW() WHERE id = $1
+	`, it.ID, prior)
+	if err != nil {
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+// Queue lists report items with their target and open reports per reason,
+// hidden items and the most reported first.
+func (r *ReportsRepo) Queue(ctx context.Context, f reports.QueueFilter) (*reports.ItemList, error) {
+	where := []string{"TRUE"}
+	args := []interface{}{}
+	if f.Status != "" {
+		args = append(args, f.Status)
+		where = append(where, fmt.Sprintf("i.status = $%d", len(args)))
+	}
+	if f.TargetType != "" {
+		args = append(args, f.TargetType)
+		where = append(where, fmt.Sprintf("i.target_type = $%d", len(args)))
+	}
+	whereClause := strings.Join(where, " AND ")
+
+	var total int
+	if err := r.db.QueryRow(ctx, "SELECT COUNT(*) FROM report_items i WHERE "+whereClause, args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	args = append(args, f.Limit, (f.Page-1)*f.Limit)
+	query := fmt.Sprintf(`
+		SELECT %s,
+		       COALESCE(p.title, '') AS post_title,
+		       CASE i.target_type WHEN 'comment' THEN LEFT(COALESCE(c.content, ''), 300)
+		                          ELSE COALESCE(p.excerpt, '') END AS excerpt,
+		       CASE i.target_type WHEN 'comment' THEN COALESCE(cu.name, c.guest_name, '')
+		                          ELSE COALESCE(pu.name, '') END AS author,
+		       COALESCE((
+		           SELECT jsonb_object_agg(reason, n) FROM (
+		               SELECT rp.reason, COUNT(*) AS n FROM reports rp
+		               WHERE rp.item_id = i.id AND (i.resolved_at IS NULL OR rp.created_at > i.resolved_at)
+		               GROUP BY rp.reason
+		           ) x
+		       ), '{}'::jsonb) AS reasons
+		FROM report_items i
+		LEFT JOIN posts p ON p.id = i.post_id
+		LEFT JOIN users pu ON pu.id = p.author_id
+		LEFT JOIN comments c ON i.target_type = 'comment' AND c.id = i.target_id
+		LEFT JOIN users cu ON cu.id = c.user_id
+		WHERE %s
+		ORDER BY (i.status = 'open' AND i.hidden_at IS NOT NULL) DESC, i.open_reports DESC, i.updated_at DESC
+		LIMIT $%d OFFSET $%d
+	`, reportItemColumns, whereClause, len(args)-1, len(args))
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := []reports.Item{}
+	for rows.Next() {
+		var it reports.Item
+		if err := scanReportItem(rows, &it, &it.PostTitle, &it.Excerpt, &it.Author, &it.Reasons); err != nil {
+			return nil, err
+		}
+		items = append(items, it)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return &reports.ItemList{
+		Items:      items,
+		Total:      total,
+		Page:       f.Page,
+		Limit:      f.Limit,
+		TotalPages: int(math.Ceil(float64(total) / float64(f.Limit))),
+	}, nil
+}
+
+// FindItem returns a report item with all its reports, newest first.
+func (r *ReportsRepo) FindItem(ctx context.Context, id string) (*reports.Item, error) {
+	var it reports.Item
+	err := scanReportItem(r.db.QueryRow(ctx, `SELECT `+reportItemColumns+` FROM report_items i WHERE i.id = $1`, id), &it)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT r.id, r.item_id, r.reason, r.details, r.reporter_id, r.created_at, COALESCE(u.name, '')
+		FROM reports r
+		LEFT JOIN users u ON u.id = r.reporter_id
+		WHERE r.item_id = $1
+		ORDER BY r.created_at DESC
+	`, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	it.Reports = []reports.Report{}
+	it.Reasons = make(map[string]int)
+	for rows.Next() {
+		var rep reports.Report
+		if err := rows.Scan(&rep.ID, &rep.ItemID, &rep.Reason, &rep.Details, &rep.ReporterID, &rep.CreatedAt, &rep.ReporterName); err != nil {
+			return nil, err
+		}
+		if it.ResolvedAt == nil || rep.CreatedAt.After(*it.ResolvedAt) {
+			it.Reasons[rep.Reason]++
+		}
+		it.Reports = append(it.Reports, rep)
+	}
+	return &it, rows.Err()
+}
+
+// Resolve closes a report item. Dismissing restores a hidden target to its
+// status before hiding; removing rejects a comment or archives a post.
+func (r *ReportsRepo) Resolve(ctx context.Context, it *reports.Item, action, note, userID string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	status := reports.StatusDismissed
+	switch {
+	case action == reports.ActionRemove && it.TargetType == reports.TargetComment:
+		status = reports.StatusRemoved
+		_, err = tx.Exec(ctx, `UPDATE comments SET status = 'REJECTED', updated_at = NOW() WHERE id = $1`, it.TargetID)
+	case action == reports.ActionRemove:
+		status = reports.StatusRemoved
+		_, err = tx.Exec(ctx, `UPDATE posts SET status = 'ARCHIVED', updated_at = NOW() WHERE id = $1`, it.TargetID)
+	case it.HiddenAt != nil && it.TargetType == reports.TargetComment:
+		_, err = tx.Exec(ctx, `
+			UPDATE comments SET status = $2, updated_at = NOW() WHERE id = $1 AND status = 'PENDING'
+		`, it.TargetID, it.PriorStatus)
+	case it.HiddenAt != nil:
+		_, err = tx.Exec(ctx, `
+			UPDATE posts SET status = $2, updated_at = NOW() WHERE id = $1 AND status = 'IN_REVIEW'
+		`, it.TargetID, it.PriorStatus)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE report_items
+		SET status = $2, open_reports = 0, hidden_at = NULL, prior_status = '',
+		    resolved_by = NULLIF($3, ''), resolved_at = NOW(), resolution_note = $4, updated_at = NOW()
+		WHERE id = $1
+	`, it.ID, status, userID, note)
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
@@ -2,71 +2,16 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"strings"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
-	"github.com/rapidtest/netpulse-api/internal/domain/posts"
 	"github.com/rapidtest/netpulse-api/internal/domain/users"
 )
 
-// ── Categories ──────────────────────────────────────
-
-type CategoriesRepo struct {
-	db *pgxpool.Pool
-}
-
-func NewCategoriesRepo(db *pgxpool.Pool) *CategoriesRepo {
-	return &CategoriesRepo{db: db}
-}
-
-func (r *CategoriesRepo) FindAll(ctx context.Context) ([]posts.Category, error) {
-	rows, err := r.db.Query(ctx, `SELECT id, name, slug, COALESCE(description, '') FROM categories ORDER BY name`)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var cats []posts.Category
-	for rows.Next() {
-		var c posts.Category
-		if err := rows.Scan(&c.ID, &c.Name, &c.Slug, &c.Description); err != nil {
-			return nil, err
-		}
-		cats = append(cats, c)
-	}
-	return cats, nil
-}
-
-// ── Tags ────────────────────────────────────────────
-
-type TagsRepo struct {
-	db *pgxpool.Pool
-}
-
-func NewTagsRepo(db *pgxpool.Pool) *TagsRepo {
-	return &TagsRepo{db: db}
-}
-
-func (r *TagsRepo) FindAll(ctx context.Context) ([]posts.Tag, error) {
-	rows, err := r.db.Query(ctx, `SELECT id, name, slug FROM tags ORDER BY name`)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	var tags []posts.Tag
-	for rows.Next() {
-		var t posts.Tag
-		if err := rows.Scan(&t.ID, &t.Name, &t.Slug); err != nil {
-			return nil, err
-		}
-		tags = append(tags, t)
-	}
-	return tags, nil
-}
-
 // ── Users ───────────────────────────────────────────
 
 type UsersRepo struct {
@@ -80,7 +25,7 @@ func NewUsersRepo(db *pgxpool.Pool) *UsersRepo {
 func (r *UsersRepo) FindByEmail(ctx context.Context, email string) (*users.User, error) {
 	var u users.User
 	err := r.db.QueryRow(ctx, `
-		SELECT id, email, name, COALESCE(password_hash,''), COALESCE(avatar,''), COALESCE(bio,''),
+		SELECT id, email, name, COALESCE(username,''), COALESCE(password_hash,''), COALESCE(avatar,''), COALESCE(bio,''),
 		       is_active, email_verified_at, COALESCE(referral_code,''), referred_by,
 		       disabled_at, COALESCE(auth_provider,'local'), google_sub,
 		       COALESCE(website,''), COALESCE(location,''),
@@ -89,7 +34,7 @@ func (r *UsersRepo) FindByEmail(ctx context.Context, email string) (*users.User,
 		       COALESCE(social_instagram,''), COALESCE(social_youtube,''),
 		       created_at, updated_at
 		FROM users WHERE email = $1
-	`, email).Scan(&u.ID, &u.Email, &u.Name, &u.PasswordHash, &u.Avatar, &u.Bio,
+	`, email).Scan(&u.ID, &u.Email, &u.Name, &u.Username, &u.PasswordHash, &u.Avatar, &u.Bio,
 		&u.IsActive, &u.EmailVerifiedAt, &u.ReferralCode, &u.ReferredBy,
 		&u.DisabledAt, &u.AuthProvider, &u.GoogleSub,
 		&u.Website, &u.Location,
@@ -126,7 +71,7 @@ func (r *UsersRepo) FindByEmail(ctx context.Context, email string) (*users.User,
 func (r *UsersRepo) FindByID(ctx context.Context, id string) (*users.User, error) {
 	var u users.User
 	err := r.db.QueryRow(ctx, `
-		SELECT id, email, name, COALESCE(avatar,''), COALESCE(bio,''),
+		SELECT id, email, name, COALESCE(username,''), COALESCE(avatar,''), COALESCE(bio,''),
 		       is_active, email_verified_at, COALESCE(referral_code,''), referred_by,
 		       disabled_at, COALESCE(auth_provider,'local'), google_sub,
 		       COALESCE(website,''), COALESCE(location,''),
@@ -135,7 +80,7 @@ func (r *UsersRepo) FindByID(ctx context.Context, id string) (*users.User, error
 		       COALESCE(social_instagram,''), COALESCE(social_youtube,''),
 		       created_at, updated_at
 		FROM users WHERE id = $1
-	`, id).Scan(&u.ID, &u.Email, &u.Name, &u.Avatar, &u.Bio,
+	`, id).Scan(&u.ID, &u.Email, &u.Name, &u.Username, &u.Avatar, &u.Bio,
 		&u.IsActive, &u.EmailVerifiedAt, &u.ReferralCode, &u.ReferredBy,
 		&u.DisabledAt, &u.AuthProvider, &u.GoogleSub,
 		&u.Website, &u.Location,
@@ -173,7 +118,7 @@ func (r *UsersRepo) FindAll(ctx context.Context, page, limit int) ([]users.User,
 
 	offset := (page - 1) * limit
 	rows, err := r.db.Query(ctx, `
-		SELECT id, email, name, COALESCE(avatar,''), is_active, email_verified_at,
+		SELECT id, email, name, COALESCE(username,''), COALESCE(avatar,''), is_active, email_verified_at,
 		       COALESCE(referral_code,''), disabled_at, created_at, updated_at
 		FROM users ORDER BY created_at DESC LIMIT $1 OFFSET $2
 	`, limit, offset)
@@ -185,7 +130,7 @@ func (r *UsersRepo) FindAll(ctx context.Context, page, limit int) ([]users.User,
 	var result []users.User
 	for rows.Next() {
 		var u users.User
-		if err := rows.Scan(&u.ID, &u.Email, &u.Name, &u.Avatar, &u.IsActive,
+		if err := rows.Scan(&u.ID, &u.Email, &u.Name, &u.Username, &u.Avatar, &u.IsActive,
 			&u.EmailVerifiedAt, &u.ReferralCode, &u.DisabledAt,
 			&u.CreatedAt, &u.UpdatedAt); err != nil {
 			return nil, 0, err
@@ -214,7 +159,7 @@ func (r *UsersRepo) FindAllFiltered(ctx context.Context, f users.UserFilter) (*u
 	argIdx := 1
 
 	if f.Search != "" {
-		where = append(where, fmt.Sprintf("(u.name ILIKE $%d OR u.email ILIKE $%d)", argIdx, argIdx))
+		where = append(where, fmt.Sprintf("(u.name ILIKE $%d OR u.email ILIKE $%d OR u.username ILIKE $%d)", argIdx, argIdx, argIdx))
 		args = append(args, "%"+f.Search+"%")
 		argIdx++
 	}
@@ -247,7 +192,7 @@ func (r *UsersRepo) FindAllFiltered(ctx context.Context, f users.UserFilter) (*u
 	args = append(args, f.Limit, offset)
 
 	rows, err := r.db.Query(ctx, fmt.Sprintf(`
-		SELECT u.id, u.email, u.name, COALESCE(u.avatar,''), u.is_active,
+		SELECT u.id, u.email, u.name, COALESCE(u.username,''), COALESCE(u.avatar,''), u.is_active,
 		       u.email_verified_at, COALESCE(u.referral_code,''), u.disabled_at,
 		       u.created_at, u.updated_at
 		FROM users u
@@ -263,7 +208,7 @@ func (r *UsersRepo) FindAllFiltered(ctx context.Context, f users.UserFilter) (*u
 	var items []users.User
 	for rows.Next() {
 		var u users.User
-		if err := rows.Scan(&u.ID, &u.Email, &u.Name, &u.Avatar, &u.IsActive,
+		if err := rows.Scan(&u.ID, &u.Email, &u.Name, &u.Username, &u.Avatar, &u.IsActive,
 			&u.EmailVerifiedAt, &u.ReferralCode, &u.DisabledAt,
 			&u.CreatedAt, &u.UpdatedAt); err != nil {
 			return nil, err
@@ -292,19 +237,38 @@ func (r *UsersRepo) FindAllFiltered(ctx context.Context, f users.UserFilter) (*u
 	}, nil
 }
 
+// Create inserts a user. Without a username the database generates one from
+// the name and a random suffix, which is set on u. A username taken by a
+// concurrent sign-up fails with users.ErrUsernameTaken when it was given and
+// is regenerated when it was not.
 func (r *UsersRepo) Create(ctx context.Context, u *users.User) error {
-	_, err := r.db.Exec(ctx, `
-		INSERT INTO users (id, email, name, password_hash, is_active, referral_code, referred_by, auth_provider, google_sub, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
-	`, u.ID, u.Email, u.Name, u.PasswordHash, u.IsActive, u.ReferralCode, u.ReferredBy, u.AuthProvider, u.GoogleSub, u.CreatedAt, u.UpdatedAt)
-	return err
+	chosen := u.Username
+	for attempt := 0; ; attempt++ {
+		err := r.db.QueryRow(ctx, `
+			INSERT INTO users (id, email, name, username, password_hash, is_active, referral_code, referred_by, auth_provider, google_sub, created_at, updated_at)
+			VALUES ($1, $2, $3, NULLIF($4, ''), $5, $6, $7, $8, $9, $10, $11, $12)
+			RETURNING username
+		`, u.ID, u.Email, u.Name, chosen, u.PasswordHash, u.IsActive, u.ReferralCode, u.ReferredBy, u.AuthProvider, u.GoogleSub, u.CreatedAt, u.UpdatedAt).Scan(&u.Username)
+		if !isUsernameConflict(err) {
+			return err
+		}
+		if chosen != "" || attempt == 2 {
+			return users.ErrUsernameTaken
+		}
+	}
+}
+
+// isUsernameConflict reports whether err is a unique violation on users.username.
+func isUsernameConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "idx_users_username"
 }
 
 // FindByGoogleSub finds a user by Google subject ID.
 func (r *UsersRepo) FindByGoogleSub(ctx context.Context, googleSub string) (*users.User, error) {
 	var u users.User
 	err := r.db.QueryRow(ctx, `
-		SELECT id, email, name, COALESCE(avatar,''), COALESCE(bio,''),
+		SELECT id, email, name, COALESCE(username,''), COALESCE(avatar,''), COALESCE(bio,''),
 		       is_active, email_verified_at, COALESCE(referral_code,''), referred_by,
 		       disabled_at, COALESCE(auth_provider,'local'), google_sub,
 		       COALESCE(website,''), COALESCE(location,''),
@@ -313,7 +277,7 @@ func (r *UsersRepo) FindByGoogleSub(ctx context.Context, googleSub string) (*use
 		       COALESCE(social_instagram,''), COALESCE(social_youtube,''),
 		       created_at, updated_at
 		FROM users WHERE google_sub = $1
-	`, googleSub).Scan(&u.ID, &u.Email, &u.Name, &u.Avatar, &u.Bio,
+	`, googleSub).Scan(&u.ID, &u.Email, &u.Name, &u.Username, &u.Avatar, &u.Bio,
 		&u.IsActive, &u.EmailVerifiedAt, &u.ReferralCode, &u.ReferredBy,
 		&u.DisabledAt, &u.AuthProvider, &u.GoogleSub,
 		&u.Website, &u.Location,
@@ -405,6 +369,26 @@ func (r *UsersRepo) UpdateEmail(ctx context.Context, userID, newEmail string) er
 	return err
 }
 
+// UsernameTaken reports whether another user has the username.
+func (r *UsersRepo) UsernameTaken(ctx context.Context, username, exceptUserID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `SELECT EXISTS(SELECT 1 FROM users WHERE username = $1 AND id != $2)`,
+		username, exceptUserID).Scan(&exists)
+	return exists, err
+}
+
+// UpdateUsername changes the user's username, returning
+// users.ErrUsernameTaken when another user has it.
+func (r *UsersRepo) UpdateUsername(ctx context.Context, userID, username string) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE users SET username = $1, updated_at = NOW() WHERE id = $2
+	`, username, userID)
+	if isUsernameConflict(err) {
+		return users.ErrUsernameTaken
+	}
+	return err
+}
+
 // UpdateProfile updates user's name, bio, and avatar.
 func (r *UsersRepo) UpdateProfile(ctx context.Context, u *users.User) error {
 	_, err := r.db.Exec(ctx, `
@@ -429,25 +413,25 @@ func (r *UsersRepo) GetAuthorPublicStats(ctx context.Context, userID string) map
 	var publishedPosts, totalViews, totalLikes, totalComments int
 
 	r.db.QueryRow(ctx,
-		`SELECT COUNT(*) FROM posts WHERE author_id = $1 AND status = 'PUBLISHED' AND deleted_at IS NULL`,
+		`SELECT COUNT(*) FROM posts p WHERE `+coAuthoredBy("$1")+` AND p.status = 'PUBLISHED' AND p.deleted_at IS NULL`,
 		userID).Scan(&publishedPosts)
 
 	r.db.QueryRow(ctx,
 		`SELECT COALESCE(SUM(ps.views_count), 0) FROM post_stats ps
 		 JOIN posts p ON p.id = ps.post_id
-		 WHERE p.author_id = $1 AND p.status = 'PUBLISHED' AND p.deleted_at IS NULL`,
+		 WHERE `+coAuthoredBy("$1")+` AND p.status = 'PUBLISHED' AND p.deleted_at IS NULL`,
 		userID).Scan(&totalViews)
 
 	r.db.QueryRow(ctx,
 		`SELECT COALESCE(SUM(ps.likes_count), 0) FROM post_stats ps
 		 JOIN posts p ON p.id = ps.post_id
-		 WHERE p.author_id = $1 AND p.status = 'PUBLISHED' AND p.deleted_at IS NULL`,
+		 WHERE `+coAuthoredBy("$1")+` AND p.status = 'PUBLISHED' AND p.deleted_at IS NULL`,
 		userID).Scan(&totalLikes)
 
 	r.db.QueryRow(ctx,
 		`SELECT COUNT(*) FROM comments c
 		 JOIN posts p ON p.id = c.post_id
-		 WHERE p.author_id = $1 AND c.status = 'APPROVED' AND c.deleted_at IS NULL AND p.deleted_at IS NULL`,
+		 WHERE `+coAuthoredBy("$1")+` AND c.status = 'APPROVED' AND c.deleted_at IS NULL AND p.deleted_at IS NULL`,
 		userID).Scan(&totalComments)
 
 	return map[string]int{
@@ -0,0 +1,136 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rapidtest/netpulse-api/internal/domain/searchlog"
+)
+
+// SearchLogRepo stores the search query log and result clicks.
+type SearchLogRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewSearchLogRepo(db *pgxpool.Pool) *SearchLogRepo {
+	return &SearchLogRepo{db: db}
+}
+
+// InsertSearch logs a search request.
+func (r *SearchLogRepo) InsertSearch(ctx context.Context, s *searchlog.Search) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO search_queries (id, query, source, content_type, language, results, first_position, shown, ip_hash)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`, s.ID, s.Query, s.Source, s.ContentType, s.Language, s.Results, s.FirstPosition, s.Shown, s.IPHash)
+	return err
+}
+
+// InsertClick logs a result click. Clicks for unknown searches and repeated
+// clicks on the same position are ignored.
+func (r *SearchLogRepo) InsertClick(ctx context.Context, c *searchlog.Click) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO search_clicks (search_id, position, result_type, result_id)
+		SELECT $1, $2, $3, $4
+		WHERE EXISTS (SELECT 1 FROM search_queries WHERE id = $1)
+		ON CONFLICT (search_id, position) DO NOTHING
+	`, c.SearchID, c.Position, c.ResultType, c.ResultID)
+	return err
+}
+
+// Report aggregates the searches of one source since f.Since.
+func (r *SearchLogRepo) Report(ctx context.Context, f searchlog.ReportFilter) (*searchlog.Report, error) {
+	rep := &searchlog.Report{Source: f.Source}
+
+	var zero, clicked int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*), COUNT(DISTINCT q.query),
+		       COUNT(*) FILTER (WHERE q.results = 0),
+		       COUNT(*) FILTER (WHERE EXISTS (SELECT 1 FROM search_clicks c WHERE c.search_id = q.id))
+		FROM search_queries q
+		WHERE q.source = $1 AND q.created_at >= $2
+	`, f.Source, f.Since).Scan(&rep.TotalSearches, &rep.UniqueQueries, &zero, &clicked)
+	if err != nil {
+		return nil, err
+	}
+	rep.ZeroResultRate = searchlog.Rate(zero, rep.TotalSearches)
+	rep.CTR = searchlog.Rate(clicked, rep.TotalSearches)
+
+	if rep.TopQueries, err = r.queryStats(ctx, f, false); err != nil {
+		return nil, err
+	}
+	if rep.ZeroResultQueries, err = r.queryStats(ctx, f, true); err != nil {
+		return nil, err
+	}
+	if rep.CTRByPosition, err = r.positionStats(ctx, f); err != nil {
+		return nil, err
+	}
+	return rep, nil
+}
+
+// queryStats returns the most frequent queries, or with zeroOnly the most
+// frequent queries that found nothing.
+func (r *SearchLogRepo) queryStats(ctx context.Context, f searchlog.ReportFilter, zeroOnly bool) ([]searchlog.QueryStat, error) {
+	where := ""
+	if zeroOnly {
+		where = " AND q.results = 0"
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT q.query, COUNT(*),
+		       COUNT(*) FILTER (WHERE q.results = 0),
+		       COUNT(*) FILTER (WHERE EXISTS (SELECT 1 FROM search_clicks c WHERE c.search_id = q.id)),
+		       MAX(q.created_at)
+		FROM search_queries q
+		WHERE q.source = $1 AND q.created_at >= $2`+where+`
+		GROUP BY q.query
+		ORDER BY COUNT(*) DESC, MAX(q.created_at) DESC
+		LIMIT $3
+	`, f.Source, f.Since, f.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]searchlog.QueryStat, 0)
+	for rows.Next() {
+		var s searchlog.QueryStat
+		if err := rows.Scan(&s.Query, &s.Searches, &s.ZeroResults, &s.Clicked, &s.LastSearched); err != nil {
+			return nil, err
+		}
+		s.CTR = searchlog.Rate(s.Clicked, s.Searches)
+		items = append(items, s)
+	}
+	return items, rows.Err()
+}
+
+// positionStats returns the click-through rate of the first f.Positions
+// result positions. A position counts as an impression when it was on the
+// returned page.
+func (r *SearchLogRepo) positionStats(ctx context.Context, f searchlog.ReportFilter) ([]searchlog.PositionStat, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT p.pos,
+		       COUNT(q.id),
+		       COUNT(c.id)
+		FROM generate_series(1, $3::int) AS p(pos)
+		LEFT JOIN search_queries q
+		       ON q.source = $1 AND q.created_at >= $2
+		      AND p.pos >= q.first_position AND p.pos < q.first_position + q.shown
+		LEFT JOIN search_clicks c ON c.search_id = q.id AND c.position = p.pos
+		GROUP BY p.pos
+		ORDER BY p.pos
+	`, f.Source, f.Since, f.Positions)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]searchlog.PositionStat, 0)
+	for rows.Next() {
+		var s searchlog.PositionStat
+		if err := rows.Scan(&s.Position, &s.Impressions, &s.Clicks); err != nil {
+			return nil, err
+		}
+		s.CTR = searchlog.Rate(s.Clicks, s.Impressions)
+		items = append(items, s)
+	}
+	return items, rows.Err()
+}
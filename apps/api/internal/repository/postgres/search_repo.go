@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rapidtest/netpulse-api/internal/domain/posts"
+	"github.com/rapidtest/netpulse-api/internal/domain/search"
+)
+
+// SearchRepo ranks posts, store listings and portfolio items together.
+type SearchRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewSearchRepo(db *pgxpool.Pool) *SearchRepo {
+	return &SearchRepo{db: db}
+}
+
+// storeTSQuery parses $1 for store content, which is indexed in Indonesian.
+const storeTSQuery = "websearch_to_tsquery(netpulse_ts_config('id'), $1)"
+
+// searchHits builds a UNION of the full-text hits of the given types with
+// the columns kind, id, title, slug, excerpt, cover_url, category,
+// sort_date, price, listing_slug, preview_url, lang, body and rank.
+//
+// Ranks use ts_rank_cd normalization 32 (rank / (rank + 1)), so they fall
+// in 0..1 for every type. Items in another language than the query rank at
+// half, as in post search.
+func searchHits(f search.Filter, types []string) (string, searchArgs) {
+	args := searchArgs{f.Posts.Query}
+	postWhere := ""
+	for _, t := range types {
+		if t == search.TypePost {
+			postWhere, args = searchWhere(f.Posts, false, "")
+		}
+	}
+	lang := args.add(f.Posts.Language)
+	boost := func(langSQL string) string {
+		return "CASE WHEN " + langSQL + " = " + lang + " THEN 1.0 ELSE 0.5 END::float4"
+	}
+
+	var parts []string
+	for _, t := range types {
+		switch t {
+		case search.TypePost:
+			parts = append(parts, `
+			SELECT 'post' AS kind, p.id, p.title, p.slug, p.excerpt, p.cover_url,
+			       COALESCE(c.name, '') AS category, p.published_at AS sort_date, 0::bigint AS price,
+			       '' AS listing_slug, '' AS preview_url, p.language AS lang, p.body,
+			       ts_rank_cd(p.search_vector, websearch_to_tsquery(netpulse_ts_config(p.language), $1), 32) * `+boost("p.language")+` AS rank
+			FROM posts p
+			LEFT JOIN categories c ON c.id = p.category_id
+			WHERE `+postWhere)
+		case search.TypeListing:
+			parts = append(parts, `
+			SELECT 'listing' AS kind, l.id, l.title, l.slug, COALESCE(l.short_desc, '') AS excerpt,
+			       COALESCE(l.cover_url, '') AS cover_url, COALESCE(lc.name, '') AS category,
+			       l.created_at AS sort_date, l.base_price AS price, '' AS listing_slug, '' AS preview_url,
+			       'id' AS lang, COALESCE(l.description, '') AS body,
+			       ts_rank_cd(l.search_vector, `+storeTSQuery+`, 32) * `+boost("'id'")+` AS rank
+			FROM listings l
+			LEFT JOIN listing_categories lc ON lc.id = l.category_id
+			WHERE l.is_active = true AND l.search_vector @@ `+storeTSQuery)
+		case search.TypePortfolio:
+			parts = append(parts, `
+			SELECT 'portfolio' AS kind, pi.id, pi.title, '' AS slug, LEFT(COALESCE(pi.description, ''), 300) AS excerpt,
+			       COALESCE(pi.desktop_screenshot, '') AS cover_url, COALESCE(pl.title, '') AS category,
+			       pi.created_at AS sort_date, 0::bigint AS price, COALESCE(pl.slug, '') AS listing_slug,
+			       COALESCE(pi.preview_url, '') AS preview_url, 'id' AS lang, COALESCE(pi.description, '') AS body,
+			       ts_rank_cd(pi.search_vector, `+storeTSQuery+`, 32) * `+boost("'id'")+` AS rank
+			FROM portfolio_items pi
+			LEFT JOIN listings pl ON pl.id = pi.listing_id
+			WHERE pi.is_active = true AND pi.search_vector @@ `+storeTSQuery)
+		}
+	}
+	return strings.Join(parts, "\n\t\t\tUNION ALL"), args
+}
+
+// CountByType counts the hits of every type f can find.
+func (r *SearchRepo) CountByType(ctx context.Context, f search.Filter) (map[string]int, error) {
+	hits, args := searchHits(f, f.Searchable())
+	rows, err := r.db.Query(ctx, `SELECT kind, COUNT(*) FROM (`+hits+`) h GROUP BY kind`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]int)
+	for rows.Next() {
+		var kind string
+		var n int
+		if err := rows.Scan(&kind, &n); err != nil {
+			return nil, err
+		}
+		counts[kind] = n
+	}
+	return counts, rows.Err()
+}
+
+// Search returns one page of the selected types ranked together, with
+// ts_headline snippets and result counts per type.
+func (r *SearchRepo) Search(ctx context.Context, f search.Filter) (*search.ResultList, error) {
+	p := f.Posts
+	result := &search.ResultList{
+		Items:    []search.Result{},
+		Page:     p.Page,
+		Limit:    p.Limit,
+		Language: p.Language,
+	}
+
+	counts, err := r.CountByType(ctx, f)
+	if err != nil {
+		return nil, err
+	}
+	result.Facets = &posts.SearchFacets{Type: search.TypeFacet(counts)}
+
+	types := f.Selected()
+	for _, t := range types {
+		result.Total += counts[t]
+	}
+	result.TotalPages = int(math.Ceil(float64(result.Total) / float64(p.Limit)))
+	if result.Total == 0 {
+		return result, nil
+	}
+
+	orderBy := "rank DESC, sort_date DESC NULLS LAST"
+	if p.Sort == "newest" {
+		orderBy = "sort_date DESC NULLS LAST, rank DESC"
+	}
+	headline := func(field, opts string) string {
+		return "ts_headline(netpulse_ts_config(lang), " + field +
+			", websearch_to_tsquery(netpulse_ts_config(lang), $1), " + opts + ")"
+	}
+
+	hits, args := searchHits(f, types)
+	query := fmt.Sprintf(`
+		SELECT kind, id, title, slug, excerpt, cover_url, category, sort_date, price,
+		       listing_slug, preview_url, rank::float8, %s, %s
+		FROM (
+			SELECT * FROM (%s) h
+			ORDER BY %s
+			LIMIT %s OFFSET %s
+		) page
+		ORDER BY %s
+	`, headline("title", args.add(posts.TitleHeadlineOptions)), headline("body", args.add(posts.HeadlineOptions)),
+		hits, orderBy, args.add(p.Limit), args.add((p.Page-1)*p.Limit), orderBy)
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var it search.Result
+		var hlTitle, hlBody string
+		if err := rows.Scan(&it.Type, &it.ID, &it.Title, &it.Slug, &it.Excerpt, &it.CoverURL, &it.Category,
+			&it.Date, &it.Price, &it.ListingSlug, &it.PreviewURL, &it.Rank, &hlTitle, &hlBody); err != nil {
+			return nil, err
+		}
+		it.Highlight = &posts.Highlight{Title: posts.FormatHighlight(hlTitle), Body: posts.FormatHighlight(hlBody)}
+		result.Items = append(result.Items, it)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,177 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rapidtest/netpulse-api/internal/domain/series"
+)
+
+type SeriesRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewSeriesRepo(db *pgxpool.Pool) *SeriesRepo {
+	return &SeriesRepo{db: db}
+}
+
+// FindAll returns all series with their post counts.
+// When publishedOnly is set, only published posts are counted and empty series are skipped.
+func (r *SeriesRepo) FindAll(ctx context.Context, publishedOnly bool) ([]series.Series, error) {
+	postFilter := ""
+	having := ""
+	if publishedOnly {
+		postFilter = "AND p.status = 'PUBLISHED' AND p.deleted_at IS NULL"
+		having = "HAVING COUNT(p.id) > 0"
+	}
+
+	rows, err := r.db.Query(ctx, fmt.Sprintf(`
+		SELECT s.id, s.title, s.slug, COALESCE(s.description, ''), s.created_at, COUNT(p.id)
+		FROM series s
+		LEFT JOIN post_series ps ON ps.series_id = s.id
+		LEFT JOIN posts p ON p.id = ps.post_id %s
+		GROUP BY s.id
+		%s
+		ORDER BY s.title
+	`, postFilter, having))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []series.Series
+	for rows.Next() {
+		var s series.Series
+		if err := rows.Scan(&s.ID, &s.Title, &s.Slug, &s.Description, &s.CreatedAt, &s.PostCount); err != nil {
+			return nil, err
+		}
+		items = append(items, s)
+	}
+	return items, nil
+}
+
+// FindByID returns a series with all of its posts in order (admin).
+func (r *SeriesRepo) FindByID(ctx context.Context, id string) (*series.Series, error) {
+	return r.findOne(ctx, "s.id = $1", id, false)
+}
+
+// FindBySlug returns a series with its published posts in order (public).
+func (r *SeriesRepo) FindBySlug(ctx context.Context, slug string) (*series.Series, error) {
+	return r.findOne(ctx, "s.slug = $1", slug, true)
+}
+
+func (r *SeriesRepo) findOne(ctx context.Context, where string, arg interface{}, publishedOnly bool) (*series.Series, error) {
+	var s series.Series
+	err := r.db.QueryRow(ctx, `
+		SELECT s.id, s.title, s.slug, COALESCE(s.description, ''), s.created_at
+		FROM series s WHERE `+where, arg,
+	).Scan(&s.ID, &s.Title, &s.Slug, &s.Description, &s.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("series not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	postFilter := "AND p.deleted_at IS NULL"
+	if publishedOnly {
+		postFilter += " AND p.status = 'PUBLISHED'"
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT p.id, p.title, p.slug, COALESCE(p.excerpt, ''), COALESCE(p.cover_url, ''),
+		       p.status, ps.position, p.published_at
+		FROM post_series ps
+		JOIN posts p ON p.id = ps.post_id
+		WHERE ps.series_id = $1 `+postFilter+`
+		ORDER BY ps.position, p.published_at
+	`, s.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sp series.SeriesPost
+		if err := rows.Scan(&sp.PostID, &sp.Title, &sp.Slug, &sp.Excerpt, &sp.CoverURL,
+			&sp.Status, &sp.Position, &sp.PublishedAt); err != nil {
+			return nil, err
+		}
+		if publishedOnly {
+			sp.Status = ""
+		}
+		s.Posts = append(s.Posts, sp)
+	}
+	s.PostCount = len(s.Posts)
+	return &s, nil
+}
+
+// SlugExists checks whether a slug is taken by a series other than excludeID.
+func (r *SeriesRepo) SlugExists(ctx context.Context, slug, excludeID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx,
+		`SELECT EXISTS(SELECT 1 FROM series WHERE slug = $1 AND id != $2)`,
+		slug, excludeID).Scan(&exists)
+	return exists, err
+}
+
+func (r *SeriesRepo) Create(ctx context.Context, s *series.Series) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO series (title, slug, description) VALUES ($1, $2, $3)
+		RETURNING id, created_at
+	`, s.Title, s.Slug, s.Description).Scan(&s.ID, &s.CreatedAt)
+}
+
+func (r *SeriesRepo) Update(ctx context.Context, s *series.Series) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE series SET title = $2, slug = $3, description = $4 WHERE id = $1
+	`, s.ID, s.Title, s.Slug, s.Description)
+	return err
+}
+
+func (r *SeriesRepo) Delete(ctx context.Context, id string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM series WHERE id = $1`, id)
+	return err
+}
+
+// SetPosts replaces all posts of a series; the slice order becomes the position.
+func (r *SeriesRepo) SetPosts(ctx context.Context, seriesID string, postIDs []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM post_series WHERE series_id = $1`, seriesID); err != nil {
+		return err
+	}
+	for i, postID := range postIDs {
+		if _, err := tx.Exec(ctx,
+			`INSERT INTO post_series (post_id, series_id, position) VALUES ($1, $2, $3)`,
+			postID, seriesID, i+1); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// AddPost attaches a post at position, or at the end when position is nil.
+// Re-adding an attached post moves it to the new position.
+func (r *SeriesRepo) AddPost(ctx context.Context, seriesID, postID string, position *int) error {
+	_, err := r.db.Exec(ctx, `
+		INSERT INTO post_series (post_id, series_id, position)
+		VALUES ($1, $2, COALESCE($3, (SELECT COALESCE(MAX(position), 0) + 1 FROM post_series WHERE series_id = $2)))
+		ON CONFLICT (post_id, series_id) DO UPDATE SET position = EXCLUDED.position
+	`, postID, seriesID, position)
+	return err
+}
+
+// RemovePost detaches a post from a series.
+func (r *SeriesRepo) RemovePost(ctx context.Context, seriesID, postID string) error {
+	_, err := r.db.Exec(ctx,
+		`DELETE FROM post_series WHERE series_id = $1 AND post_id = $2`, seriesID, postID)
+	return err
+}
@@ -0,0 +1,144 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rapidtest/netpulse-api/internal/domain/comments"
+	"github.com/rapidtest/netpulse-api/internal/domain/spam"
+)
+
+// SpamRepo backs comment spam scoring: duplicates, the Bayes model and
+// commenter trust.
+type SpamRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewSpamRepo(db *pgxpool.Pool) *SpamRepo {
+	return &SpamRepo{db: db}
+}
+
+// CountDuplicates counts comments with the given content hash since a time,
+// and how many of them are spam.
+func (r *SpamRepo) CountDuplicates(ctx context.Context, hash string, since time.Time) (int, int, error) {
+	var total, spamCount int
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*), COUNT(*) FILTER (WHERE status = 'SPAM')
+		FROM comments
+		WHERE content_hash = $1 AND created_at >= $2
+	`, hash, since).Scan(&total, &spamCount)
+	return total, spamCount, err
+}
+
+// Counts returns the learned counts of tokens and the trained documents per
+// class.
+func (r *SpamRepo) Counts(ctx context.Context, tokens []string) (map[string]spam.TokenCount, spam.TokenCount, error) {
+	var docs spam.TokenCount
+	err := r.db.QueryRow(ctx, `
+		SELECT COUNT(*) FILTER (WHERE label = 'SPAM'), COUNT(*) FILTER (WHERE label = 'APPROVED')
+		FROM spam_training
+	`).Scan(&docs.Spam, &docs.Ham)
+	if err != nil {
+		return nil, docs, err
+	}
+
+	rows, err := r.db.Query(ctx, `SELECT token, spam, ham FROM spam_tokens WHERE token = ANY($1)`, tokens)
+	if err != nil {
+		return nil, docs, err
+	}
+	defer rows.Close()
+
+	counts := make(map[string]spam.TokenCount)
+	for rows.Next() {
+		var token string
+		var tc spam.TokenCount
+		if err := rows.Scan(&token, &tc.Spam, &tc.Ham); err != nil {
+			return nil, docs, err
+		}
+		counts[token] = tc
+	}
+	return counts, docs, rows.Err()
+}
+
+// Learn counts tokens under label for a comment. A comment trained before
+// is first unlearned with the tokens it was counted with; relearning the
+// same label is a no-op.
+func (r *SpamRepo) Learn(ctx context.Context, commentID string, label comments.CommentStatus, tokens []string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	var oldLabel string
+	var oldTokens []string
+	err = tx.QueryRow(ctx, `
+		SELECT label, tokens FROM spam_training WHERE comment_id = $1 FOR UPDATE
+	`, commentID).Scan(&oldLabel, &oldTokens)
+	switch {
+	case err == pgx.ErrNoRows:
+	case err != nil:
+		return err
+	case oldLabel == string(label):
+		return nil
+	default:
+		if err := addTokenCounts(ctx, tx, oldTokens, comments.CommentStatus(oldLabel), -1); err != nil {
+			return err
+		}
+	}
+
+	if err := addTokenCounts(ctx, tx, tokens, label, 1); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO spam_training (comment_id, label, tokens) VALUES ($1, $2, $3)
+		ON CONFLICT (comment_id) DO UPDATE SET label = $2, tokens = $3, trained_at = NOW()
+	`, commentID, string(label), tokens)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// addTokenCounts adds delta to the spam or ham count of each token.
+func addTokenCounts(ctx context.Context, tx pgx.Tx, tokens []string, label comments.CommentStatus, delta int) error {
+	if len(tokens) == 0 {
+		return nil
+	}
+	spamDelta, hamDelta := 0, 0
+	if label == comments.StatusSpam {
+		spamDelta = delta
+	} else {
+		hamDelta = delta
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO spam_tokens (token, spam, ham)
+		SELECT t, GREATEST(0, $2), GREATEST(0, $3) FROM unnest($1::text[]) AS t
+		ON CONFLICT (token) DO UPDATE
+		SET spam = GREATEST(0, spam_tokens.spam + $2), ham = GREATEST(0, spam_tokens.ham + $3)
+	`, tokens, spamDelta, hamDelta)
+	return err
+}
+
+// IsTrusted reports whether a user's clean comments are approved without
+// moderation: moderators, and users with at least minApproved approved
+// comments and none marked as spam.
+func (r *SpamRepo) IsTrusted(ctx context.Context, userID string, minApproved int) (bool, error) {
+	var trusted bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM user_roles ur
+			JOIN role_permissions rp ON rp.role_id = ur.role_id
+			JOIN permissions p ON p.id = rp.permission_id
+			WHERE ur.user_id = $1 AND p.name = 'comments.moderate'
+		) OR (
+			SELECT COUNT(*) FILTER (WHERE status = 'APPROVED') >= $2
+			   AND COUNT(*) FILTER (WHERE status = 'SPAM') = 0
+			FROM comments WHERE user_id = $1
+		)
+	`, userID, minApproved).Scan(&trusted)
+	return trusted, err
+}
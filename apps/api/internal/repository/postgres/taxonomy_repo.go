@@ -0,0 +1,383 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rapidtest/netpulse-api/internal/domain/posts"
+	"github.com/rapidtest/netpulse-api/internal/utils"
+)
+
+// ── Categories ──────────────────────────────────────
+
+type CategoriesRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewCategoriesRepo(db *pgxpool.Pool) *CategoriesRepo {
+	return &CategoriesRepo{db: db}
+}
+
+// FindAll returns all categories with their post counts, ordered by name.
+// When publishedOnly is set, only published posts are counted.
+func (r *CategoriesRepo) FindAll(ctx context.Context, publishedOnly bool) ([]posts.Category, error) {
+	postFilter := "AND p.deleted_at IS NULL"
+	if publishedOnly {
+		postFilter += " AND p.status = 'PUBLISHED'"
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT c.id, c.name, c.slug, COALESCE(c.description, ''), c.parent_id, COUNT(p.id)
+		FROM categories c
+		LEFT JOIN posts p ON p.category_id = c.id `+postFilter+`
+		GROUP BY c.id
+		ORDER BY c.name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cats []posts.Category
+	for rows.Next() {
+		var c posts.Category
+		if err := rows.Scan(&c.ID, &c.Name, &c.Slug, &c.Description, &c.ParentID, &c.PostCount); err != nil {
+			return nil, err
+		}
+		cats = append(cats, c)
+	}
+	return cats, nil
+}
+
+func (r *CategoriesRepo) FindByID(ctx context.Context, id string) (*posts.Category, error) {
+	return r.findOne(ctx, "id = $1", id)
+}
+
+func (r *CategoriesRepo) FindBySlug(ctx context.Context, slug string) (*posts.Category, error) {
+	return r.findOne(ctx, "slug = $1", slug)
+}
+
+func (r *CategoriesRepo) findOne(ctx context.Context, where string, arg interface{}) (*posts.Category, error) {
+	var c posts.Category
+	err := r.db.QueryRow(ctx, `
+		SELECT id, name, slug, COALESCE(description, ''), parent_id
+		FROM categories WHERE `+where, arg,
+	).Scan(&c.ID, &c.Name, &c.Slug, &c.Description, &c.ParentID)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("category not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// Conflicts reports whether name or slug is used by a category other than excludeID.
+func (r *CategoriesRepo) Conflicts(ctx context.Context, name, slug, excludeID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM categories WHERE (LOWER(name) = LOWER($1) OR slug = $2) AND id != $3)
+	`, name, slug, excludeID).Scan(&exists)
+	return exists, err
+}
+
+// IsDescendant reports whether candidateID is id itself or one of its
+// descendants, i.e. whether making it the parent of id would form a cycle.
+func (r *CategoriesRepo) IsDescendant(ctx context.Context, id, candidateID string) (bool, error) {
+	var found bool
+	err := r.db.QueryRow(ctx, `
+		WITH RECURSIVE sub AS (
+			SELECT id FROM categories WHERE id = $1
+			UNION
+			SELECT c.id FROM categories c JOIN sub ON c.parent_id = sub.id
+		)
+		SELECT EXISTS(SELECT 1 FROM sub WHERE id = $2)
+	`, id, candidateID).Scan(&found)
+	return found, err
+}
+
+// FindIDByName returns the id of the category named name (case-insensitive)
+// or with its slug, or "" if there is none.
+func (r *CategoriesRepo) FindIDByName(ctx context.Context, name string) (string, error) {
+	return findTermID(ctx, r.db, "categories", name)
+}
+
+// EnsureByName returns the id of the category named name, creating it if needed.
+func (r *CategoriesRepo) EnsureByName(ctx context.Context, name string) (string, error) {
+	return ensureTerm(ctx, r.db, "categories", name)
+}
+
+func (r *CategoriesRepo) Create(ctx context.Context, c *posts.Category) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO categories (name, slug, description, parent_id) VALUES ($1, $2, $3, $4)
+		RETURNING id
+	`, c.Name, c.Slug, c.Description, c.ParentID).Scan(&c.ID)
+}
+
+func (r *CategoriesRepo) Update(ctx context.Context, c *posts.Category) error {
+	_, err := r.db.Exec(ctx, `
+		UPDATE categories SET name = $2, slug = $3, description = $4, parent_id = $5 WHERE id = $1
+	`, c.ID, c.Name, c.Slug, c.Description, c.ParentID)
+	return err
+}
+
+// Delete removes a category. Its children move up to its parent and its
+// posts become uncategorized.
+func (r *CategoriesRepo) Delete(ctx context.Context, id string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `
+		UPDATE categories SET parent_id = (SELECT parent_id FROM categories WHERE id = $1)
+		WHERE parent_id = $1
+	`, id); err != nil {
+		return err
+	}
+	tag, err := tx.Exec(ctx, `DELETE FROM categories WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("category not found")
+	}
+
+	return tx.Commit(ctx)
+}
+
+// ── Tags ────────────────────────────────────────────
+
+type TagsRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewTagsRepo(db *pgxpool.Pool) *TagsRepo {
+	return &TagsRepo{db: db}
+}
+
+// FindAll returns all tags with their post counts, ordered by name.
+// When publishedOnly is set, only published posts are counted.
+func (r *TagsRepo) FindAll(ctx context.Context, publishedOnly bool) ([]posts.Tag, error) {
+	postFilter := "AND p.deleted_at IS NULL"
+	if publishedOnly {
+		postFilter += " AND p.status = 'PUBLISHED'"
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT t.id, t.name, t.slug, COUNT(p.id)
+		FROM tags t
+		LEFT JOIN post_tags pt ON pt.tag_id = t.id
+		LEFT JOIN posts p ON p.id = pt.post_id `+postFilter+`
+		GROUP BY t.id
+		ORDER BY t.name
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []posts.Tag
+	for rows.Next() {
+		var t posts.Tag
+		if err := rows.Scan(&t.ID, &t.Name, &t.Slug, &t.PostCount); err != nil {
+			return nil, err
+		}
+		tags = append(tags, t)
+	}
+	return tags, nil
+}
+
+func (r *TagsRepo) FindByID(ctx context.Context, id string) (*posts.Tag, error) {
+	return r.findOne(ctx, "t.id = $1", id)
+}
+
+// FindBySlug returns a tag with its published post count.
+func (r *TagsRepo) FindBySlug(ctx context.Context, slug string) (*posts.Tag, error) {
+	return r.findOne(ctx, "t.slug = $1", slug)
+}
+
+func (r *TagsRepo) findOne(ctx context.Context, where string, arg interface{}) (*posts.Tag, error) {
+	var t posts.Tag
+	err := r.db.QueryRow(ctx, `
+		SELECT t.id, t.name, t.slug,
+		       (SELECT COUNT(*) FROM post_tags pt JOIN posts p ON p.id = pt.post_id
+		        WHERE pt.tag_id = t.id AND p.status = 'PUBLISHED' AND p.deleted_at IS NULL)
+		FROM tags t WHERE `+where, arg,
+	).Scan(&t.ID, &t.Name, &t.Slug, &t.PostCount)
+	if err == pgx.ErrNoRows {
+		return nil, fmt.Errorf("tag not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Conflicts reports whether name or slug is used by a tag other than excludeID.
+// Former slugs of other tags count as taken so their redirects keep working.
+func (r *TagsRepo) Conflicts(ctx context.Context, name, slug, excludeID string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(ctx, `
+		SELECT EXISTS(SELECT 1 FROM tags WHERE (LOWER(name) = LOWER($1) OR slug = $2) AND id != $3)
+		    OR EXISTS(SELECT 1 FROM tag_slug_history WHERE slug = $2 AND tag_id != $3)
+	`, name, slug, excludeID).Scan(&exists)
+	return exists, err
+}
+
+// FindIDByName returns the id of the tag named name (case-insensitive) or
+// with its slug, or "" if there is none.
+func (r *TagsRepo) FindIDByName(ctx context.Context, name string) (string, error) {
+	return findTermID(ctx, r.db, "tags", name)
+}
+
+// EnsureByName returns the id of the tag named name, creating it if needed.
+func (r *TagsRepo) EnsureByName(ctx context.Context, name string) (string, error) {
+	return ensureTerm(ctx, r.db, "tags", name)
+}
+
+func (r *TagsRepo) Create(ctx context.Context, t *posts.Tag) error {
+	return r.db.QueryRow(ctx, `
+		INSERT INTO tags (name, slug) VALUES ($1, $2) RETURNING id
+	`, t.Name, t.Slug).Scan(&t.ID)
+}
+
+// Update saves a tag. A changed slug is kept in tag_slug_history as a
+// redirect to the tag.
+func (r *TagsRepo) Update(ctx context.Context, t *posts.Tag, oldSlug string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `UPDATE tags SET name = $2, slug = $3 WHERE id = $1`, t.ID, t.Name, t.Slug); err != nil {
+		return err
+	}
+	if oldSlug != t.Slug {
+		if _, err := tx.Exec(ctx, `
+			INSERT INTO tag_slug_history (slug, tag_id, created_at) VALUES ($1, $2, NOW())
+			ON CONFLICT (slug) DO UPDATE SET tag_id = EXCLUDED.tag_id, created_at = NOW()
+		`, oldSlug, t.ID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx,
+			`DELETE FROM tag_slug_history WHERE slug = $1 AND tag_id = $2`, t.Slug, t.ID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *TagsRepo) Delete(ctx context.Context, id string) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM tags WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return fmt.Errorf("tag not found")
+	}
+	return nil
+}
+
+// Merge folds the source tags into targetID: their posts are retagged, their
+// current and former slugs redirect to the target, and the sources are deleted.
+// It returns the number of posts that gained the target tag, or
+// posts.ErrTagNotFound if a source tag does not exist.
+func (r *TagsRepo) Merge(ctx context.Context, targetID string, sourceIDs []string) (int64, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	var found int
+	if err := tx.QueryRow(ctx, `SELECT COUNT(*) FROM tags WHERE id = ANY($1)`, sourceIDs).Scan(&found); err != nil {
+		return 0, err
+	}
+	if found != len(sourceIDs) {
+		return 0, posts.ErrTagNotFound
+	}
+
+	res, err := tx.Exec(ctx, `
+		INSERT INTO post_tags (post_id, tag_id)
+		SELECT DISTINCT post_id, $1 FROM post_tags WHERE tag_id = ANY($2)
+		ON CONFLICT DO NOTHING
+	`, targetID, sourceIDs)
+	if err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx,
+		`UPDATE tag_slug_history SET tag_id = $1 WHERE tag_id = ANY($2)`, targetID, sourceIDs); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO tag_slug_history (slug, tag_id, created_at)
+		SELECT slug, $1, NOW() FROM tags WHERE id = ANY($2)
+		ON CONFLICT (slug) DO UPDATE SET tag_id = EXCLUDED.tag_id, created_at = NOW()
+	`, targetID, sourceIDs); err != nil {
+		return 0, err
+	}
+	if _, err := tx.Exec(ctx, `DELETE FROM tags WHERE id = ANY($1)`, sourceIDs); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	return res.RowsAffected(), nil
+}
+
+// FindSlugRedirect returns the current slug of the tag that used to live at
+// oldSlug, or "" if there is none.
+func (r *TagsRepo) FindSlugRedirect(ctx context.Context, oldSlug string) (string, error) {
+	var slug string
+	err := r.db.QueryRow(ctx, `
+		SELECT t.slug FROM tag_slug_history h
+		JOIN tags t ON t.id = h.tag_id
+		WHERE h.slug = $1
+	`, oldSlug).Scan(&slug)
+	if err == pgx.ErrNoRows {
+		return "", nil
+	}
+	return slug, err
+}
+
+// findTermID looks up a category or tag by name or slug.
+func findTermID(ctx context.Context, db *pgxpool.Pool, table, name string) (string, error) {
+	var id string
+	err := db.QueryRow(ctx, `
+		SELECT id FROM `+table+` WHERE LOWER(name) = LOWER($1) OR slug = $2
+		ORDER BY (LOWER(name) = LOWER($1)) DESC LIMIT 1
+	`, name, utils.Slugify(name)).Scan(&id)
+	if err == pgx.ErrNoRows {
+		return "", nil
+	}
+	return id, err
+}
+
+// ensureTerm returns the id of a category or tag, inserting it when missing.
+func ensureTerm(ctx context.Context, db *pgxpool.Pool, table, name string) (string, error) {
+	id, err := findTermID(ctx, db, table, name)
+	if err != nil || id != "" {
+		return id, err
+	}
+	slug := utils.Slugify(name)
+	if slug == "" {
+		return "", fmt.Errorf("invalid name: %q", name)
+	}
+	err = db.QueryRow(ctx, `
+		INSERT INTO `+table+` (name, slug) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+		RETURNING id
+	`, name, slug).Scan(&id)
+	if err == pgx.ErrNoRows {
+		// Created concurrently.
+		return findTermID(ctx, db, table, name)
+	}
+	return id, err
+}
@@ -0,0 +1,29 @@
+package redis
+
+import (
+	"context"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Counter counts events per key in fixed windows.
+type Counter struct {
+	rdb *redis.Client
+}
+
+func NewCounter(rdb *redis.Client) *Counter {
+	return &Counter{rdb: rdb}
+}
+
+// Incr counts an event and returns the count in the current window, which
+// starts with the first event of a key.
+func (c *Counter) Incr(ctx context.Context, key string, window time.Duration) (int64, error) {
+	pipe := c.rdb.Pipeline()
+	incr := pipe.Incr(ctx, key)
+	pipe.ExpireNX(ctx, key, window)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
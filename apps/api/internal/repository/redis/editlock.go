@@ -0,0 +1,111 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// EditLockTTL is how long an edit lock lives without a heartbeat.
+const EditLockTTL = 60 * time.Second
+
+// EditLock is an advisory "currently being edited by" marker for a post.
+type EditLock struct {
+	PostID      string    `json:"post_id"`
+	UserID      string    `json:"user_id"`
+	UserName    string    `json:"user_name"`
+	AcquiredAt  time.Time `json:"acquired_at"`
+	HeartbeatAt time.Time `json:"heartbeat_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// EditLocks stores post edit locks in Redis. Locks are advisory: they tell
+// editors someone else is working on a post, but don't block writes.
+type EditLocks struct {
+	rdb *redis.Client
+}
+
+func NewEditLocks(rdb *redis.Client) *EditLocks {
+	return &EditLocks{rdb: rdb}
+}
+
+func editLockKey(postID string) string {
+	return "editlock:post:" + postID
+}
+
+// acquireScript sets the lock unless another user holds it, keeping the
+// original acquired_at on heartbeats. It returns the lock now stored.
+var acquireScript = redis.NewScript(`
+local v = redis.call("GET", KEYS[1])
+local new = ARGV[2]
+if v then
+	local cur = cjson.decode(v)
+	if cur.user_id ~= ARGV[1] then
+		return v
+	end
+	local lock = cjson.decode(new)
+	lock.acquired_at = cur.acquired_at
+	new = cjson.encode(lock)
+end
+redis.call("SET", KEYS[1], new, "PX", ARGV[3])
+return new`)
+
+// releaseScript deletes the lock only if it is still held by ARGV[1].
+var releaseScript = redis.NewScript(`
+local v = redis.call("GET", KEYS[1])
+if v and cjson.decode(v).user_id == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0`)
+
+// Get returns the current lock on a post, or nil if it is free.
+func (l *EditLocks) Get(ctx context.Context, postID string) (*EditLock, error) {
+	raw, err := l.rdb.Get(ctx, editLockKey(postID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var lock EditLock
+	if err := json.Unmarshal([]byte(raw), &lock); err != nil {
+		return nil, err
+	}
+	return &lock, nil
+}
+
+// Acquire takes the lock for userID or, if they already hold it, extends it
+// (the heartbeat). If someone else holds it, their lock is returned with ok=false.
+func (l *EditLocks) Acquire(ctx context.Context, postID, userID, userName string) (*EditLock, bool, error) {
+	now := time.Now()
+	raw, err := json.Marshal(&EditLock{
+		PostID:      postID,
+		UserID:      userID,
+		UserName:    userName,
+		AcquiredAt:  now,
+		HeartbeatAt: now,
+		ExpiresAt:   now.Add(EditLockTTL),
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	res, err := acquireScript.Run(ctx, l.rdb, []string{editLockKey(postID)},
+		userID, string(raw), EditLockTTL.Milliseconds()).Text()
+	if err != nil {
+		return nil, false, err
+	}
+	var lock EditLock
+	if err := json.Unmarshal([]byte(res), &lock); err != nil {
+		return nil, false, err
+	}
+	return &lock, lock.UserID == userID, nil
+}
+
+// Release drops the lock if userID holds it.
+func (l *EditLocks) Release(ctx context.Context, postID, userID string) error {
+	return releaseScript.Run(ctx, l.rdb, []string{editLockKey(postID)}, userID).Err()
+}
@@ -0,0 +1,59 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rapidtest/netpulse-api/internal/config"
+)
+
+// ErrInvalidPreviewToken is returned for malformed, tampered or expired tokens.
+var ErrInvalidPreviewToken = errors.New("invalid preview token")
+
+// PreviewSigner mints and verifies HMAC-signed post preview tokens.
+// A token has the form "<id>.<unix expiry>.<signature>".
+type PreviewSigner struct {
+	secret []byte
+}
+
+func NewPreviewSigner(cfg *config.Config) *PreviewSigner {
+	secret := cfg.PreviewSecret
+	if secret == "" {
+		secret = cfg.JWTAccessSecret
+	}
+	return &PreviewSigner{secret: []byte("preview:" + secret)}
+}
+
+// Sign returns the token for a preview record.
+func (s *PreviewSigner) Sign(id string, expiresAt time.Time) string {
+	payload := id + "." + strconv.FormatInt(expiresAt.Unix(), 10)
+	return payload + "." + s.mac(payload)
+}
+
+// Verify checks the signature and expiry and returns the preview record ID.
+func (s *PreviewSigner) Verify(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] == "" {
+		return "", ErrInvalidPreviewToken
+	}
+	payload := parts[0] + "." + parts[1]
+	if !hmac.Equal([]byte(parts[2]), []byte(s.mac(payload))) {
+		return "", ErrInvalidPreviewToken
+	}
+	exp, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil || time.Now().Unix() >= exp {
+		return "", ErrInvalidPreviewToken
+	}
+	return parts[0], nil
+}
+
+func (s *PreviewSigner) mac(payload string) string {
+	m := hmac.New(sha256.New, s.secret)
+	m.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(m.Sum(nil))
+}
@@ -1,6 +1,9 @@
 package utils
 
-import "time"
+import (
+	"fmt"
+	"time"
+)
 
 // FormatISO formats a time as ISO 8601 string.
 func FormatISO(t time.Time) string {
@@ -11,3 +14,38 @@ func FormatISO(t time.Time) string {
 func NowUTC() time.Time {
 	return time.Now().UTC()
 }
+
+// ParseDateRange parses from/to as RFC 3339 timestamps or YYYY-MM-DD dates;
+// empty bounds are nil. A date-only "to" includes that whole day.
+func ParseDateRange(from, to string) (*time.Time, *time.Time, error) {
+	var f, t *time.Time
+	if from != "" {
+		v, _, err := parseBound(from)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid from: %s", from)
+		}
+		f = &v
+	}
+	if to != "" {
+		v, dateOnly, err := parseBound(to)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid to: %s", to)
+		}
+		if dateOnly {
+			v = v.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+		t = &v
+	}
+	if f != nil && t != nil && t.Before(*f) {
+		return nil, nil, fmt.Errorf("to must not be before from")
+	}
+	return f, t, nil
+}
+
+func parseBound(s string) (time.Time, bool, error) {
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, true, nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	return t, false, err
+}
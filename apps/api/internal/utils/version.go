@@ -0,0 +1,22 @@
+package utils
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// SetVersionETag exposes a record version as a strong ETag.
+func SetVersionETag(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", `"`+strconv.Itoa(version)+`"`)
+}
+
+// IfMatchVersion reads a record version from an If-Match header ("3" or W/"3").
+func IfMatchVersion(r *http.Request) *int {
+	v := strings.TrimPrefix(strings.TrimSpace(r.Header.Get("If-Match")), "W/")
+	n, err := strconv.Atoi(strings.Trim(v, `"`))
+	if err != nil {
+		return nil
+	}
+	return &n
+}
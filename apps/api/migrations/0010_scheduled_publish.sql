@@ -0,0 +1,6 @@
+-- 0010_scheduled_publish.sql
+-- Partial index used by the background scheduler to find due SCHEDULED posts.
+
+CREATE INDEX IF NOT EXISTS idx_posts_scheduled_due
+    ON posts(scheduled_at)
+    WHERE status = 'SCHEDULED';
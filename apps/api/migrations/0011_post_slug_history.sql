@@ -0,0 +1,10 @@
+-- 0011_post_slug_history.sql
+-- Former post slugs, kept so renamed posts can 301 to their current URL.
+
+CREATE TABLE IF NOT EXISTS post_slug_history (
+    slug       TEXT PRIMARY KEY,
+    post_id    TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_slug_history_post ON post_slug_history(post_id);
@@ -0,0 +1,17 @@
+-- 0012_post_preview_tokens.sql
+-- Revocable preview links for unpublished posts. The token handed out is
+-- HMAC-signed over id + expiry; only the id is stored here.
+
+CREATE TABLE IF NOT EXISTS post_preview_tokens (
+    id             TEXT PRIMARY KEY,
+    post_id        TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    created_by     TEXT NOT NULL REFERENCES users(id),
+    note           TEXT NOT NULL DEFAULT '',
+    expires_at     TIMESTAMPTZ NOT NULL,
+    revoked_at     TIMESTAMPTZ,
+    view_count     INT NOT NULL DEFAULT 0,
+    last_viewed_at TIMESTAMPTZ,
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_preview_tokens_post ON post_preview_tokens(post_id);
@@ -0,0 +1,22 @@
+-- 0013_post_review_notes.sql
+-- Editorial feedback: change-request summaries, inline notes anchored to body
+-- ranges, and replies forming a review thread per post.
+
+CREATE TABLE IF NOT EXISTS post_review_notes (
+    id           BIGSERIAL PRIMARY KEY,
+    post_id      TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    parent_id    BIGINT REFERENCES post_review_notes(id) ON DELETE CASCADE,
+    author_id    TEXT NOT NULL REFERENCES users(id),
+    kind         TEXT NOT NULL CHECK (kind IN ('SUMMARY', 'INLINE', 'REPLY')),
+    body         TEXT NOT NULL,
+    anchor_start INT,
+    anchor_end   INT,
+    anchor_quote TEXT,
+    resolved_at  TIMESTAMPTZ,
+    resolved_by  TEXT REFERENCES users(id),
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_review_notes_post ON post_review_notes(post_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_post_review_notes_open
+    ON post_review_notes(post_id) WHERE parent_id IS NULL AND resolved_at IS NULL;
@@ -0,0 +1,5 @@
+-- 0014_post_version.sql
+-- Optimistic concurrency for post edits: every content update bumps version,
+-- and writers must name the version they started from.
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,14 @@
+-- 0015_taxonomy.sql
+-- Nested categories and former tag slugs (kept when tags are renamed or merged).
+
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS parent_id TEXT REFERENCES categories(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent ON categories(parent_id);
+
+CREATE TABLE IF NOT EXISTS tag_slug_history (
+    slug       TEXT PRIMARY KEY,
+    tag_id     TEXT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tag_slug_history_tag ON tag_slug_history(tag_id);
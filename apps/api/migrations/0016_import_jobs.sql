@@ -0,0 +1,24 @@
+-- 0016_import_jobs.sql
+-- Bulk content imports (WordPress WXR, Markdown zip) with per-item results.
+
+CREATE TABLE IF NOT EXISTS import_jobs (
+    id          TEXT PRIMARY KEY,
+    format      TEXT NOT NULL CHECK (format IN ('wxr', 'markdown')),
+    filename    TEXT NOT NULL DEFAULT '',
+    dry_run     BOOLEAN NOT NULL DEFAULT FALSE,
+    author_id   TEXT REFERENCES users(id) ON DELETE SET NULL,
+    status      TEXT NOT NULL DEFAULT 'PENDING' CHECK (status IN ('PENDING', 'RUNNING', 'DONE', 'FAILED')),
+    total       INT NOT NULL DEFAULT 0,
+    processed   INT NOT NULL DEFAULT 0,
+    created     INT NOT NULL DEFAULT 0,
+    skipped     INT NOT NULL DEFAULT 0,
+    failed      INT NOT NULL DEFAULT 0,
+    error       TEXT NOT NULL DEFAULT '',
+    items       JSONB NOT NULL DEFAULT '[]',
+    created_by  TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    started_at  TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_created ON import_jobs(created_at DESC);
@@ -0,0 +1,20 @@
+-- 0017_post_authors.sql
+-- Co-authors and contributor credits. posts.author_id stays the owner; the
+-- byline (owner included) lives in post_authors, ordered by position.
+
+CREATE TABLE IF NOT EXISTS post_authors (
+    post_id     TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    user_id     TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role        TEXT NOT NULL DEFAULT 'author'
+                CHECK (role IN ('author', 'editor', 'illustrator', 'technical_reviewer')),
+    position    INT NOT NULL DEFAULT 0,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (post_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_post_authors_user ON post_authors(user_id);
+
+-- Existing posts are credited to their owner.
+INSERT INTO post_authors (post_id, user_id, role, position)
+SELECT id, author_id, 'author', 0 FROM posts WHERE author_id IS NOT NULL
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,9 @@
+-- 0018_post_expiry.sql
+-- Optional embargo end for time-limited posts; the scheduler archives
+-- PUBLISHED posts once expires_at passes.
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS expires_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS idx_posts_expires_due
+    ON posts(expires_at)
+    WHERE status = 'PUBLISHED' AND expires_at IS NOT NULL;
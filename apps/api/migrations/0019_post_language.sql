@@ -0,0 +1,80 @@
+-- 0019_post_language.sql
+-- Per-post language driving the full-text search configuration, and an
+-- Indonesian configuration (netpulse_id): unaccent + the Snowball Indonesian
+-- stemmer with the stopwords of infra/postgres/tsearch_data.
+--
+-- Existing posts default to 'id' and their search vectors are rebuilt at
+-- the end of this file. To detect English posts among them, run:
+--   reindex -detect
+
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+ALTER TABLE posts ADD COLUMN IF NOT EXISTS language TEXT NOT NULL DEFAULT 'id';
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'posts_language_check') THEN
+        ALTER TABLE posts ADD CONSTRAINT posts_language_check CHECK (language IN ('id', 'en'));
+    END IF;
+END $$;
+
+-- The stopword file must be installed in $SHAREDIR/tsearch_data (see
+-- docker-compose.yml). Without it the stemmer runs without stopwords, and
+-- without the Snowball Indonesian stemmer words are only unaccented.
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_ts_dict WHERE dictname = 'netpulse_id_stem') THEN
+        BEGIN
+            CREATE TEXT SEARCH DICTIONARY netpulse_id_stem (
+                TEMPLATE = snowball, Language = indonesian, StopWords = netpulse_indonesian
+            );
+        EXCEPTION WHEN OTHERS THEN
+            BEGIN
+                CREATE TEXT SEARCH DICTIONARY netpulse_id_stem (TEMPLATE = snowball, Language = indonesian);
+            EXCEPTION WHEN OTHERS THEN
+                CREATE TEXT SEARCH DICTIONARY netpulse_id_stem (TEMPLATE = simple);
+            END;
+        END;
+    ELSE
+        -- Pick up the stopword file once it has been installed.
+        BEGIN
+            ALTER TEXT SEARCH DICTIONARY netpulse_id_stem (StopWords = netpulse_indonesian);
+        EXCEPTION WHEN OTHERS THEN
+            NULL;
+        END;
+    END IF;
+
+    IF NOT EXISTS (SELECT 1 FROM pg_ts_config WHERE cfgname = 'netpulse_id') THEN
+        CREATE TEXT SEARCH CONFIGURATION netpulse_id (COPY = simple);
+        ALTER TEXT SEARCH CONFIGURATION netpulse_id
+            ALTER MAPPING FOR asciiword, asciihword, hword_asciipart, word, hword, hword_part
+            WITH unaccent, netpulse_id_stem;
+    END IF;
+END $$;
+
+-- Maps a post language to its configuration; keep in sync with
+-- posts.Language.SearchConfig.
+CREATE OR REPLACE FUNCTION netpulse_ts_config(lang TEXT) RETURNS regconfig AS $$
+    SELECT CASE lang WHEN 'en' THEN 'english'::regconfig ELSE 'netpulse_id'::regconfig END
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION posts_search_vector(title TEXT, excerpt TEXT, body TEXT, lang TEXT)
+RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector(netpulse_ts_config(lang), COALESCE(title, '')), 'A') ||
+           setweight(to_tsvector(netpulse_ts_config(lang), COALESCE(excerpt, '')), 'B') ||
+           setweight(to_tsvector(netpulse_ts_config(lang), COALESCE(body, '')), 'C')
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION posts_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := posts_search_vector(NEW.title, NEW.excerpt, NEW.body, NEW.language);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Rebuild vectors still built by the English-only trigger of 0002, so
+-- search matches the netpulse_id queries right after deploy. Current rows
+-- are skipped, so re-running this file writes nothing.
+UPDATE posts
+SET search_vector = posts_search_vector(title, excerpt, body, language)
+WHERE search_vector IS DISTINCT FROM posts_search_vector(title, excerpt, body, language);
@@ -0,0 +1,8 @@
+-- 0020_search_trigram.sql
+-- Trigram indexes for typo-tolerant search: fuzzy title/tag matches when
+-- full-text search finds too little, and "did you mean" suggestions.
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX IF NOT EXISTS idx_posts_title_trgm ON posts USING GIN (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_tags_name_trgm ON tags USING GIN (name gin_trgm_ops);
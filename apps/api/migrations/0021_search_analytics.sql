@@ -0,0 +1,32 @@
+-- 0021_search_analytics.sql
+-- Search query log and result clicks for the admin search report. Queries
+-- are stored normalized (lowercased, whitespace collapsed) and IPs hashed.
+
+CREATE TABLE IF NOT EXISTS search_queries (
+    id             TEXT PRIMARY KEY,
+    query          TEXT NOT NULL,
+    source         TEXT NOT NULL CHECK (source IN ('search', 'suggest')),
+    content_type   TEXT NOT NULL DEFAULT 'post',
+    language       TEXT NOT NULL DEFAULT '',
+    results        INT NOT NULL DEFAULT 0,  -- total matches
+    first_position INT NOT NULL DEFAULT 1,  -- position of the first result shown
+    shown          INT NOT NULL DEFAULT 0,  -- results on the returned page
+    ip_hash        TEXT NOT NULL DEFAULT '',
+    created_at     TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_search_queries_created ON search_queries(created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_search_queries_query   ON search_queries(query, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_search_queries_zero    ON search_queries(created_at DESC) WHERE results = 0;
+
+CREATE TABLE IF NOT EXISTS search_clicks (
+    id          BIGSERIAL PRIMARY KEY,
+    search_id   TEXT NOT NULL REFERENCES search_queries(id) ON DELETE CASCADE,
+    position    INT NOT NULL,
+    result_type TEXT NOT NULL DEFAULT 'post',
+    result_id   TEXT NOT NULL DEFAULT '',
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- One click per result and search; repeated clicks do not inflate the CTR.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_search_clicks_search_position ON search_clicks(search_id, position);
@@ -0,0 +1,70 @@
+-- 0022_unified_search.sql
+-- Full-text search vectors for store listings and portfolio items, so
+-- /search?type=all can rank them together with posts. Store content is
+-- Indonesian and uses the netpulse_id configuration from 0019.
+
+ALTER TABLE listings ADD COLUMN IF NOT EXISTS search_vector tsvector;
+ALTER TABLE portfolio_items ADD COLUMN IF NOT EXISTS search_vector tsvector;
+
+-- Title (A), summary, features and tech stack (B), description (C).
+CREATE OR REPLACE FUNCTION listings_search_vector(title TEXT, short_desc TEXT, description TEXT,
+                                                  features TEXT[], tech_stack TEXT[])
+RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector(netpulse_ts_config('id'), COALESCE(title, '')), 'A') ||
+           setweight(to_tsvector(netpulse_ts_config('id'),
+               COALESCE(short_desc, '') || ' ' ||
+               array_to_string(COALESCE(features, '{}'), ' ') || ' ' ||
+               array_to_string(COALESCE(tech_stack, '{}'), ' ')), 'B') ||
+           setweight(to_tsvector(netpulse_ts_config('id'), COALESCE(description, '')), 'C')
+$$ LANGUAGE sql IMMUTABLE;
+
+-- Title (A), client and tech stack (B), description (C).
+CREATE OR REPLACE FUNCTION portfolio_search_vector(title TEXT, client_name TEXT, description TEXT,
+                                                   tech_stack TEXT[])
+RETURNS tsvector AS $$
+    SELECT setweight(to_tsvector(netpulse_ts_config('id'), COALESCE(title, '')), 'A') ||
+           setweight(to_tsvector(netpulse_ts_config('id'),
+               COALESCE(client_name, '') || ' ' ||
+               array_to_string(COALESCE(tech_stack, '{}'), ' ')), 'B') ||
+           setweight(to_tsvector(netpulse_ts_config('id'), COALESCE(description, '')), 'C')
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION listings_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := listings_search_vector(NEW.title, NEW.short_desc, NEW.description,
+                                                NEW.features, NEW.tech_stack);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION portfolio_search_vector_update() RETURNS trigger AS $$
+BEGIN
+    NEW.search_vector := portfolio_search_vector(NEW.title, NEW.client_name, NEW.description,
+                                                 NEW.tech_stack);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_listings_search_vector ON listings;
+CREATE TRIGGER trg_listings_search_vector
+    BEFORE INSERT OR UPDATE ON listings
+    FOR EACH ROW
+    EXECUTE FUNCTION listings_search_vector_update();
+
+DROP TRIGGER IF EXISTS trg_portfolio_search_vector ON portfolio_items;
+CREATE TRIGGER trg_portfolio_search_vector
+    BEFORE INSERT OR UPDATE ON portfolio_items
+    FOR EACH ROW
+    EXECUTE FUNCTION portfolio_search_vector_update();
+
+-- Backfill rows created before the triggers.
+UPDATE listings
+SET search_vector = listings_search_vector(title, short_desc, description, features, tech_stack)
+WHERE search_vector IS NULL;
+
+UPDATE portfolio_items
+SET search_vector = portfolio_search_vector(title, client_name, description, tech_stack)
+WHERE search_vector IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_listings_search ON listings USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_portfolio_items_search ON portfolio_items USING GIN (search_vector);
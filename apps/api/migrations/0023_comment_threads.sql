@@ -0,0 +1,24 @@
+-- 0023_comment_threads.sql
+-- Comment likes for users and guests, a denormalized like counter for the
+-- "top" sort, and the depth after which reply threads are shown flat.
+
+ALTER TABLE comment_likes ADD COLUMN IF NOT EXISTS user_id TEXT REFERENCES users(id) ON DELETE CASCADE;
+
+-- One like per user or per guest key, as for post likes (0004). The
+-- original UNIQUE(comment_id, guest_key) would allow only one user like.
+ALTER TABLE comment_likes DROP CONSTRAINT IF EXISTS comment_likes_comment_id_guest_key_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_comment_likes_user  ON comment_likes(comment_id, user_id) WHERE user_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_comment_likes_guest ON comment_likes(comment_id, guest_key) WHERE guest_key != '' AND user_id IS NULL;
+CREATE INDEX IF NOT EXISTS idx_comment_likes_comment ON comment_likes(comment_id);
+
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS likes_count INT NOT NULL DEFAULT 0;
+
+UPDATE comments c SET likes_count = l.n
+FROM (SELECT comment_id, COUNT(*) AS n FROM comment_likes GROUP BY comment_id) l
+WHERE l.comment_id = c.id AND c.likes_count != l.n;
+
+CREATE INDEX IF NOT EXISTS idx_comments_parent_created ON comments(parent_id, created_at);
+
+INSERT INTO site_settings (key, value) VALUES
+    ('comment_max_depth', '3')
+ON CONFLICT (key) DO NOTHING;
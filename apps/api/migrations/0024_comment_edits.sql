@@ -0,0 +1,21 @@
+-- 0024_comment_edits.sql
+-- Owners may edit or delete their comments for a configurable window after
+-- posting. Each edit keeps the replaced content as history.
+
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS edited_at TIMESTAMPTZ;
+
+CREATE TABLE IF NOT EXISTS comment_edits (
+    id         TEXT PRIMARY KEY DEFAULT encode(gen_random_bytes(16), 'hex'),
+    comment_id TEXT NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    content    TEXT NOT NULL,  -- content before the edit
+    edited_by  TEXT REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_comment_edits_comment ON comment_edits(comment_id, created_at DESC);
+
+-- Minutes after posting during which the owner may edit or delete; 0 turns
+-- owner edits and deletes off.
+INSERT INTO site_settings (key, value) VALUES
+    ('comment_edit_window_minutes', '15')
+ON CONFLICT (key) DO NOTHING;
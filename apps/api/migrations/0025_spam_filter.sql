@@ -0,0 +1,36 @@
+-- 0025_spam_filter.sql
+-- Spam scoring of new comments: a content hash for duplicate detection, the
+-- score given on insert, and a naive Bayes model trained from moderation.
+
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS content_hash TEXT NOT NULL DEFAULT '';
+ALTER TABLE comments ADD COLUMN IF NOT EXISTS spam_score REAL NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_comments_content_hash ON comments(content_hash, created_at DESC) WHERE content_hash != '';
+
+-- Per-token document counts of each class.
+CREATE TABLE IF NOT EXISTS spam_tokens (
+    token TEXT PRIMARY KEY,
+    spam  INT NOT NULL DEFAULT 0,
+    ham   INT NOT NULL DEFAULT 0
+);
+
+-- One row per trained comment with the tokens it was counted with, so a
+-- changed decision can be unlearned even after the comment was edited.
+-- No foreign key: the counts outlive deleted comments.
+CREATE TABLE IF NOT EXISTS spam_training (
+    comment_id TEXT PRIMARY KEY,
+    label      TEXT NOT NULL CHECK (label IN ('SPAM', 'APPROVED')),
+    tokens     TEXT[] NOT NULL DEFAULT '{}',
+    trained_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO site_settings (key, value) VALUES
+    ('spam_threshold', '0.9'),
+    ('spam_clean_threshold', '0.3'),
+    ('spam_trusted_min_approved', '3'),
+    ('spam_max_links', '2'),
+    ('spam_velocity_ip', '5'),
+    ('spam_velocity_email', '3'),
+    ('spam_blocklist_words', ''),
+    ('spam_blocklist_domains', '')
+ON CONFLICT (key) DO NOTHING;
@@ -0,0 +1,46 @@
+-- 0026_reports.sql
+-- Reader reports on posts and comments. Reports are grouped per reported
+-- item; an item auto-hides once its open reports reach a threshold and
+-- stays in the moderator queue until resolved.
+
+CREATE TABLE IF NOT EXISTS report_items (
+    id              TEXT PRIMARY KEY DEFAULT encode(gen_random_bytes(16), 'hex'),
+    target_type     TEXT NOT NULL CHECK (target_type IN ('post', 'comment')),
+    target_id       TEXT NOT NULL,
+    post_id         TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    status          TEXT NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'dismissed', 'removed')),
+    open_reports    INT NOT NULL DEFAULT 0,   -- reports since the last resolution
+    hidden_at       TIMESTAMPTZ,
+    prior_status    TEXT NOT NULL DEFAULT '', -- target status before auto-hide
+    resolved_by     TEXT REFERENCES users(id) ON DELETE SET NULL,
+    resolved_at     TIMESTAMPTZ,
+    resolution_note TEXT NOT NULL DEFAULT '',
+    created_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (target_type, target_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_report_items_queue ON report_items(status, updated_at DESC);
+
+-- One report per reporter and item; reporter_key is "u:<user id>" or
+-- "g:<guest key>".
+CREATE TABLE IF NOT EXISTS reports (
+    id           TEXT PRIMARY KEY DEFAULT encode(gen_random_bytes(16), 'hex'),
+    item_id      TEXT NOT NULL REFERENCES report_items(id) ON DELETE CASCADE,
+    reason       TEXT NOT NULL CHECK (reason IN ('spam', 'harassment', 'hate_speech', 'misinformation',
+                                                 'inappropriate', 'copyright', 'other')),
+    details      TEXT NOT NULL DEFAULT '',
+    reporter_id  TEXT REFERENCES users(id) ON DELETE SET NULL,
+    reporter_key TEXT NOT NULL,
+    ip_hash      TEXT NOT NULL DEFAULT '',
+    created_at   TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (item_id, reporter_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_reports_item ON reports(item_id, created_at DESC);
+
+-- Open reports that hide an item; 0 turns auto-hiding off.
+INSERT INTO site_settings (key, value) VALUES
+    ('report_hide_threshold_comments', '3'),
+    ('report_hide_threshold_posts', '5')
+ON CONFLICT (key) DO NOTHING;
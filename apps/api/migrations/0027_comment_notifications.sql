@@ -0,0 +1,79 @@
+-- 0027_comment_notifications.sql
+-- Usernames for @mentions in comments, in-app notifications for replies,
+-- mentions and comments on one's posts, and per-type opt-outs.
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS username TEXT;
+
+-- The display name cleaned to [a-z0-9_] (or "user") plus a random suffix,
+-- retried until unused. Nothing is taken from the email, which stays private.
+CREATE OR REPLACE FUNCTION users_default_username(name TEXT)
+RETURNS TEXT AS $$
+DECLARE
+    base TEXT := trim(both '_' from LEFT(trim(both '_' from
+        regexp_replace(lower(COALESCE(name, '')), '[^a-z0-9]+', '_', 'g')), 20));
+    candidate TEXT;
+BEGIN
+    IF length(base) < 3 THEN
+        base := 'user';
+    END IF;
+    LOOP
+        candidate := base || '_' || substr(md5(random()::text || clock_timestamp()::text), 1, 8);
+        EXIT WHEN NOT EXISTS (SELECT 1 FROM users u WHERE u.username = candidate);
+    END LOOP;
+    RETURN candidate;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION users_username_default() RETURNS trigger AS $$
+BEGIN
+    IF NEW.username IS NULL OR NEW.username = '' THEN
+        NEW.username := users_default_username(NEW.name);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_users_username_default ON users;
+CREATE TRIGGER trg_users_username_default
+    BEFORE INSERT ON users
+    FOR EACH ROW
+    EXECUTE FUNCTION users_username_default();
+
+-- Backfill one row at a time so every row sees the usernames given before it.
+DO $$
+DECLARE
+    u RECORD;
+BEGIN
+    FOR u IN SELECT id, name FROM users WHERE username IS NULL ORDER BY created_at, id LOOP
+        UPDATE users SET username = users_default_username(u.name) WHERE id = u.id;
+    END LOOP;
+END;
+$$;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_username ON users(username);
+
+-- One notification per recipient and comment. Text is joined from the
+-- comment and post when listed, so edits and deletions show through.
+CREATE TABLE IF NOT EXISTS notifications (
+    id         TEXT PRIMARY KEY,
+    user_id    TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type       TEXT NOT NULL CHECK (type IN ('comment_reply', 'comment_mention', 'post_comment')),
+    actor_id   TEXT REFERENCES users(id) ON DELETE SET NULL,
+    post_id    TEXT NOT NULL REFERENCES posts(id) ON DELETE CASCADE,
+    comment_id TEXT NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+    read_at    TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, comment_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user ON notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+-- Opt-outs per type; a missing row means enabled.
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id    TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    type       TEXT NOT NULL CHECK (type IN ('comment_reply', 'comment_mention', 'post_comment')),
+    enabled    BOOLEAN NOT NULL DEFAULT true,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, type)
+);
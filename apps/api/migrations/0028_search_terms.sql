@@ -0,0 +1,18 @@
+-- 0028_search_terms.sql
+-- Words of published titles and tag names for "did you mean" suggestions,
+-- kept in a materialized view with a trigram index so a suggestion looks
+-- up a few terms instead of splitting every title. The
+-- refresh_search_terms job refreshes it (SEARCH_TERMS_INTERVAL).
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS search_terms AS
+    SELECT DISTINCT lower(w) AS term
+    FROM posts p, regexp_split_to_table(p.title, '[^[:alnum:]]+') w
+    WHERE p.status = 'PUBLISHED' AND p.deleted_at IS NULL AND length(w) >= 3
+    UNION
+    SELECT lower(name) FROM tags;
+
+-- Unique so the view can be refreshed concurrently.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_search_terms_term ON search_terms(term);
+CREATE INDEX IF NOT EXISTS idx_search_terms_trgm ON search_terms USING GIN (term gin_trgm_ops);
@@ -69,6 +69,7 @@ export default function IntegrationsPage() {
     { method: "GET", path: "/admin/stats/dashboard", desc: "Dashboard stats", auth: true },
     { method: "GET", path: "/admin/stats/traffic", desc: "Traffic overview (30d/24h)", auth: true },
     { method: "GET", path: "/admin/stats/top-posts", desc: "Top posts by views", auth: true },
+    { method: "GET", path: "/admin/stats/search", desc: "Kueri teratas, tanpa hasil & CTR per posisi", auth: true },
     { method: "GET", path: "/admin/users", desc: "List users", auth: true },
     { method: "GET", path: "/admin/settings", desc: "Get all settings", auth: true },
     { method: "PUT", path: "/admin/settings", desc: "Update settings", auth: true },
@@ -13,6 +13,7 @@ interface PostMeta {
   meta_description?: string;
   focus_keyword?: string;
   status: string;
+  version: number;
 }
 
 export default function AdminSeoPage() {
@@ -69,6 +70,7 @@ export default function AdminSeoPage() {
               meta_description: p.meta_description || "",
               focus_keyword: p.focus_keyword || "",
               status: p.status,
+              version: p.version,
             })),
           );
         })
@@ -942,16 +944,22 @@ export default function AdminSeoPage() {
                                 onClick={async () => {
                                   setSaving(true);
                                   try {
-                                    await adminAPI.updatePost(post.id, {
-                                      meta_title: metaForm.meta_title,
-                                      meta_description:
-                                        metaForm.meta_description,
-                                      focus_keyword: metaForm.focus_keyword,
-                                    });
+                                    const updated =
+                                      await adminAPI.updatePost(post.id, {
+                                        meta_title: metaForm.meta_title,
+                                        meta_description:
+                                          metaForm.meta_description,
+                                        focus_keyword: metaForm.focus_keyword,
+                                        version: post.version,
+                                      });
                                     setPosts((ps) =>
                                       ps.map((p) =>
                                         p.id === post.id
-                                          ? { ...p, ...metaForm }
+                                          ? {
+                                              ...p,
+                                              ...metaForm,
+                                              version: updated.version,
+                                            }
                                           : p,
                                       ),
                                     );
@@ -42,6 +42,13 @@ export async function generateMetadata({ params }: Props): Promise<Metadata> {
   };
 }
 
+const contributorRoleLabels: Record<string, string> = {
+  author: "Penulis",
+  editor: "Editor",
+  illustrator: "Ilustrator",
+  technical_reviewer: "Peninjau Teknis",
+};
+
 function estimateReadingTime(html: string): number {
   const text = html.replace(/<[^>]*>/g, "");
   const words = text.split(/\s+/).filter(Boolean).length;
@@ -56,21 +63,35 @@ export default async function PostPage({ params }: Props) {
     notFound();
   }
 
-  const readingTime = estimateReadingTime(post.body || "");
+  const readingTime =
+    post.reading_time ?? estimateReadingTime(post.body || "");
 
-  // Fetch related posts from the same category
+  // Byline: co-authors and contributors in order, or the single author.
+  const byline: { id: string; name: string; avatar?: string; role: string }[] =
+    post.authors?.length
+      ? post.authors.map((c: any) => ({
+          id: c.user_id,
+          name: c.name,
+          avatar: c.avatar,
+          role: c.role,
+        }))
+      : post.author
+        ? [
+            {
+              id: post.author_id || post.author.id,
+              name: post.author.name,
+              avatar: post.author.avatar,
+              role: "author",
+            },
+          ]
+        : [];
+
+  // Related posts ranked by the API (tags, category, text and co-views)
   let relatedPosts: any[] = [];
-  if (post.category?.slug) {
-    try {
-      const related = await apiClient.getPosts({
-        category: post.category.slug,
-        limit: 5,
-      });
-      relatedPosts = (related.items || [])
-        .filter((p: any) => p.slug !== slug)
-        .slice(0, 4);
-    } catch {}
-  }
+  try {
+    const related = await apiClient.getRelatedPosts(post.id, 4);
+    relatedPosts = related.items || [];
+  } catch {}
 
   return (
     <div className="flex min-h-screen flex-col">
@@ -148,30 +169,33 @@ export default async function PostPage({ params }: Props) {
 
               {/* Author + Meta Row */}
               <div className="mt-6 flex flex-wrap items-center gap-6 border-t border-b border-gray-100 py-5">
-                {post.author && (
+                {byline.map((person) => (
                   <Link
-                    href={`/u/${post.author_id || post.author.id}`}
+                    key={person.id}
+                    href={`/u/${person.id}`}
                     className="flex items-center gap-3 group/author"
                   >
                     <div className="flex h-10 w-10 items-center justify-center rounded-full bg-linear-to-br from-brand-500 to-brand-700 text-sm font-bold text-white">
-                      {post.author.avatar ? (
+                      {person.avatar ? (
                         <img
-                          src={post.author.avatar}
-                          alt={post.author.name}
+                          src={person.avatar}
+                          alt={person.name}
                           className="h-full w-full rounded-full object-cover"
                         />
                       ) : (
-                        post.author.name?.charAt(0).toUpperCase() || "A"
+                        person.name?.charAt(0).toUpperCase() || "A"
                       )}
                     </div>
                     <div>
                       <p className="text-sm font-semibold text-gray-900 group-hover/author:text-brand-600 transition-colors">
-                        {post.author.name}
+                        {person.name}
+                      </p>
+                      <p className="text-xs text-gray-400">
+                        {contributorRoleLabels[person.role] || "Penulis"}
                       </p>
-                      <p className="text-xs text-gray-400">Penulis</p>
                     </div>
                   </Link>
-                )}
+                ))}
                 <div className="flex items-center gap-4 text-sm text-gray-500">
                   {post.published_at && (
                     <div className="flex items-center gap-1.5">
@@ -232,7 +256,10 @@ export default async function PostPage({ params }: Props) {
             )}
 
             {/* Body with In-Article Ads */}
-            <ArticleBodyWithAds html={post.body || ""} adInterval={4} />
+            <ArticleBodyWithAds
+              html={post.body_html ?? post.body ?? ""}
+              adInterval={4}
+            />
 
             {/* Tags */}
             {post.tags && post.tags.length > 0 && (
@@ -290,8 +317,10 @@ export default async function PostPage({ params }: Props) {
                   headline: post.title,
                   description: post.excerpt,
                   datePublished: post.published_at,
-                  author: post.author
-                    ? { "@type": "Person", name: post.author.name }
+                  author: byline.length
+                    ? byline
+                        .filter((person) => person.role === "author")
+                        .map((person) => ({ "@type": "Person", name: person.name }))
                     : undefined,
                   image: post.cover_url || undefined,
                 }),
@@ -10,6 +10,9 @@ import type {
   SavedPost,
   UserComment,
   AuthorRequest,
+  Notification,
+  NotificationPreferences,
+  NotificationType,
 } from "@/types";
 
 /* ─── Types ────────────────────────────────────── */
@@ -19,6 +22,7 @@ type TabKey =
   | "saved"
   | "likes"
   | "comments"
+  | "notifications"
   | "profile"
   | "security"
   | "request-author";
@@ -36,6 +40,7 @@ interface AffiliateData {
 interface Profile {
   id: string;
   name: string;
+  username?: string;
   email: string;
   bio?: string;
   avatar?: string;
@@ -96,6 +101,11 @@ function getNavSections(isAuthor: boolean) {
   ];
 
   const accountItems = [
+    {
+      key: "notifications" as TabKey,
+      label: "Notifikasi",
+      icon: "M14.857 17.082a23.848 23.848 0 005.454-1.31A8.967 8.967 0 0118 9.75v-.7V9A6 6 0 006 9v.75a8.967 8.967 0 01-2.312 6.022c1.733.64 3.56 1.085 5.455 1.31m5.714 0a24.255 24.255 0 01-5.714 0m5.714 0a3 3 0 11-5.714 0",
+    },
     {
       key: "profile" as TabKey,
       label: "Profil",
@@ -146,6 +156,7 @@ const validTabs: TabKey[] = [
   "saved",
   "likes",
   "comments",
+  "notifications",
   "profile",
   "security",
   "request-author",
@@ -383,6 +394,7 @@ function UserProfilePageInner() {
         {activeTab === "saved" && <SavedTab />}
         {activeTab === "likes" && <LikesTab />}
         {activeTab === "comments" && <CommentsTab />}
+        {activeTab === "notifications" && <NotificationsTab />}
         {activeTab === "profile" && <ProfileTab />}
         {activeTab === "security" && <SecurityTab />}
         {activeTab === "request-author" && <RequestAuthorTab />}
@@ -1037,6 +1049,191 @@ function CommentsTab() {
   );
 }
 
+/* ═══════════════════════════════════════════════════
+   TAB: Notifications
+   ═══════════════════════════════════════════════════ */
+const notificationTypes: {
+  type: NotificationType;
+  label: string;
+  verb: string;
+}[] = [
+  {
+    type: "comment_reply",
+    label: "Balasan untuk komentar saya",
+    verb: "membalas komentar Anda di",
+  },
+  {
+    type: "comment_mention",
+    label: "Disebut (@username) di komentar",
+    verb: "menyebut Anda di",
+  },
+  {
+    type: "post_comment",
+    label: "Komentar di artikel saya",
+    verb: "mengomentari",
+  },
+];
+
+function NotificationsTab() {
+  const [items, setItems] = useState<Notification[]>([]);
+  const [loading, setLoading] = useState(true);
+  const [page, setPage] = useState(1);
+  const [totalPages, setTotalPages] = useState(0);
+  const [unread, setUnread] = useState(0);
+  const [prefs, setPrefs] = useState<NotificationPreferences | null>(null);
+
+  const load = useCallback(() => {
+    setLoading(true);
+    userAPI
+      .getNotifications(page, 20)
+      .then((r) => {
+        setItems(r.items || []);
+        setTotalPages(r.total_pages || 0);
+        setUnread(r.unread || 0);
+      })
+      .catch(() => setItems([]))
+      .finally(() => setLoading(false));
+  }, [page]);
+
+  useEffect(() => {
+    load();
+  }, [load]);
+
+  useEffect(() => {
+    userAPI
+      .getNotificationPreferences()
+      .then(setPrefs)
+      .catch(() => {});
+  }, []);
+
+  const markRead = async (n: Notification) => {
+    if (n.read_at) return;
+    try {
+      await userAPI.markNotificationRead(n.id);
+      setItems((prev) =>
+        prev.map((x) =>
+          x.id === n.id ? { ...x, read_at: new Date().toISOString() } : x,
+        ),
+      );
+      setUnread((u) => Math.max(u - 1, 0));
+    } catch {
+      // Marking read is best effort.
+    }
+  };
+
+  const markAllRead = async () => {
+    try {
+      await userAPI.markAllNotificationsRead();
+      load();
+    } catch {
+      // Keep the list as it is.
+    }
+  };
+
+  const togglePref = async (type: NotificationType) => {
+    if (!prefs) return;
+    const next = { ...prefs, [type]: !prefs[type] };
+    setPrefs(next);
+    try {
+      setPrefs(
+        await userAPI.updateNotificationPreferences({ [type]: next[type] }),
+      );
+    } catch {
+      setPrefs(prefs);
+    }
+  };
+
+  return (
+    <div className="space-y-5">
+      <div className="flex items-end justify-between">
+        <div>
+          <h2 className="text-2xl font-bold text-gray-900">Notifikasi</h2>
+          <p className="mt-1 text-sm text-gray-500">{unread} belum dibaca</p>
+        </div>
+        {unread > 0 && (
+          <button
+            onClick={markAllRead}
+            className="text-sm font-medium text-sky-600 hover:text-sky-700"
+          >
+            Tandai semua dibaca
+          </button>
+        )}
+      </div>
+
+      {loading ? (
+        <Spinner />
+      ) : items.length === 0 ? (
+        <EmptyState
+          icon="M14.857 17.082a23.848 23.848 0 005.454-1.31A8.967 8.967 0 0118 9.75v-.7V9A6 6 0 006 9v.75a8.967 8.967 0 01-2.312 6.022c1.733.64 3.56 1.085 5.455 1.31m5.714 0a24.255 24.255 0 01-5.714 0m5.714 0a3 3 0 11-5.714 0"
+          title="Belum ada notifikasi"
+          subtitle="Balasan dan sebutan di komentar akan muncul di sini"
+          linkHref="/blog"
+          linkText="Jelajahi Artikel"
+        />
+      ) : (
+        <div className="space-y-2">
+          {items.map((n) => (
+            <Link
+              key={n.id}
+              href={`/posts/${n.post_slug}#comment-${n.comment_id}`}
+              onClick={() => markRead(n)}
+              className={`block rounded-xl border p-4 shadow-sm transition-colors ${n.read_at ? "border-gray-100 bg-white" : "border-sky-100 bg-sky-50/60"}`}
+            >
+              <p className="text-sm text-gray-700">
+                <span className="font-semibold text-gray-900">
+                  {n.actor_name || "Seseorang"}
+                </span>{" "}
+                {notificationTypes.find((t) => t.type === n.type)?.verb}{" "}
+                <span className="font-medium text-sky-600">
+                  {n.post_title}
+                </span>
+              </p>
+              {n.excerpt && (
+                <p className="mt-1 line-clamp-2 text-sm text-gray-500">
+                  {n.excerpt}
+                </p>
+              )}
+              <p className="mt-1.5 text-xs text-gray-400">
+                {timeAgo(n.created_at)}
+              </p>
+            </Link>
+          ))}
+        </div>
+      )}
+      {totalPages > 1 && (
+        <Pagination
+          page={page}
+          totalPages={totalPages}
+          onPageChange={setPage}
+        />
+      )}
+
+      {prefs && (
+        <div className="rounded-xl border border-gray-200 bg-white p-5">
+          <h3 className="mb-3 text-sm font-semibold text-gray-700">
+            Kirim notifikasi untuk
+          </h3>
+          <div className="space-y-2">
+            {notificationTypes.map((t) => (
+              <label
+                key={t.type}
+                className="flex items-center gap-2 text-sm text-gray-600"
+              >
+                <input
+                  type="checkbox"
+                  checked={prefs[t.type]}
+                  onChange={() => togglePref(t.type)}
+                />
+                {t.label}
+              </label>
+            ))}
+          </div>
+        </div>
+      )}
+    </div>
+  );
+}
+
 /* ═══════════════════════════════════════════════════
    TAB: Profile
    ═══════════════════════════════════════════════════ */
@@ -1047,6 +1244,7 @@ function ProfileTab() {
   const [error, setError] = useState("");
   const [success, setSuccess] = useState("");
   const [name, setName] = useState("");
+  const [username, setUsername] = useState("");
   const [bio, setBio] = useState("");
   const [avatar, setAvatar] = useState("");
   const [website, setWebsite] = useState("");
@@ -1064,6 +1262,7 @@ function ProfileTab() {
       .then((d: Profile) => {
         setProfile(d);
         setName(d.name || "");
+        setUsername(d.username || "");
         setBio(d.bio || "");
         setAvatar(d.avatar || "");
         setWebsite(d.website || "");
@@ -1093,6 +1292,7 @@ function ProfileTab() {
     try {
       const updated = await userAPI.updateMe({
         name,
+        ...(username ? { username } : {}),
         bio,
         avatar,
         website,
@@ -1229,6 +1429,25 @@ function ProfileTab() {
                 className="w-full rounded-lg border border-gray-200 px-3 py-2 text-sm focus:border-sky-500 focus:outline-none focus:ring-1 focus:ring-sky-500"
               />
             </div>
+            <div>
+              <label className="mb-1 block text-sm font-medium text-gray-600">
+                Username
+              </label>
+              <div className="flex items-center rounded-lg border border-gray-200 focus-within:border-sky-500 focus-within:ring-1 focus-within:ring-sky-500">
+                <span className="pl-3 text-sm text-gray-400">@</span>
+                <input
+                  type="text"
+                  value={username}
+                  onChange={(e) => setUsername(e.target.value.toLowerCase())}
+                  pattern="[a-z0-9_]{3,30}"
+                  className="w-full rounded-lg px-1 py-2 text-sm focus:outline-none"
+                />
+              </div>
+              <p className="mt-1 text-xs text-gray-400">
+                3–30 huruf kecil, angka atau _. Dipakai untuk menyebut Anda di
+                komentar.
+              </p>
+            </div>
             <div>
               <label className="mb-1 block text-sm font-medium text-gray-600">
                 Email
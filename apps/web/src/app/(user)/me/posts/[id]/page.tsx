@@ -1,6 +1,6 @@
 "use client";
 
-import { useEffect, useState } from "react";
+import { useEffect, useRef, useState } from "react";
 import { useRouter, useParams } from "next/navigation";
 import { userAPI } from "@/lib/auth-api";
 import { apiClient } from "@/lib/api-client";
@@ -25,6 +25,8 @@ export default function EditPostPage() {
   const [saving, setSaving] = useState(false);
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState("");
+  // Version of the post last loaded or saved, echoed back on every update
+  const version = useRef(0);
 
   useEffect(() => {
     async function load() {
@@ -43,6 +45,7 @@ export default function EditPostPage() {
         setMetaTitle(post.meta_title || "");
         setMetaDesc(post.meta_description || "");
         setStatus(post.status);
+        version.current = post.version;
         setCategories(cats || []);
         setTags(tgs || []);
       } catch (e: any) {
@@ -64,7 +67,8 @@ export default function EditPostPage() {
     setSaving(true);
     setError("");
     try {
-      await userAPI.updatePost(postId, {
+      const saved = await userAPI.updatePost(postId, {
+        version: version.current,
         title,
         body,
         excerpt,
@@ -74,6 +78,7 @@ export default function EditPostPage() {
         meta_title: metaTitle || undefined,
         meta_description: metaDesc || undefined,
       });
+      version.current = saved.version;
       router.push("/me/posts");
     } catch (e: any) {
       setError(e.message);
@@ -90,7 +95,8 @@ export default function EditPostPage() {
     setSaving(true);
     setError("");
     try {
-      await userAPI.updatePost(postId, {
+      const saved = await userAPI.updatePost(postId, {
+        version: version.current,
         title,
         body,
         excerpt,
@@ -100,6 +106,7 @@ export default function EditPostPage() {
         meta_title: metaTitle || undefined,
         meta_description: metaDesc || undefined,
       });
+      version.current = saved.version;
       await userAPI.submitPostForReview(postId);
       router.push("/me/posts");
     } catch (e: any) {
@@ -19,6 +19,7 @@ export default function EditPostPage() {
   const [tagIds, setTagIds] = useState<string[]>([]);
   const [metaTitle, setMetaTitle] = useState("");
   const [metaDesc, setMetaDesc] = useState("");
+  const [language, setLanguage] = useState("id");
   const [status, setStatus] = useState("");
   const [categories, setCategories] = useState<any[]>([]);
   const [tags, setTags] = useState<any[]>([]);
@@ -30,6 +31,8 @@ export default function EditPostPage() {
 
   const autosaveTimer = useRef<NodeJS.Timeout | null>(null);
   const lastSaved = useRef({ title: "", body: "" });
+  // Version of the post last loaded or saved, echoed back on every update
+  const version = useRef(0);
 
   useEffect(() => {
     const user = authAPI.getUser();
@@ -53,7 +56,9 @@ export default function EditPostPage() {
         setTagIds(post.tags?.map((t: any) => t.id) || []);
         setMetaTitle(post.meta_title || "");
         setMetaDesc(post.meta_description || "");
+        setLanguage(post.language || "id");
         setStatus(post.status);
+        version.current = post.version;
         setCategories(cats || []);
         setTags(tgs || []);
         lastSaved.current = { title: post.title || "", body: post.body || "" };
@@ -74,7 +79,8 @@ export default function EditPostPage() {
     if (title === lastSaved.current.title && body === lastSaved.current.body)
       return;
     try {
-      await userAPI.updatePost(postId, {
+      const saved = await userAPI.updatePost(postId, {
+        version: version.current,
         title,
         body,
         excerpt,
@@ -83,7 +89,9 @@ export default function EditPostPage() {
         tag_ids: tagIds.length > 0 ? tagIds : undefined,
         meta_title: metaTitle || undefined,
         meta_description: metaDesc || undefined,
+        language,
       });
+      version.current = saved.version;
       lastSaved.current = { title, body };
       setAutoSaved(
         new Date().toLocaleTimeString("id-ID", {
@@ -101,6 +109,7 @@ export default function EditPostPage() {
     tagIds,
     metaTitle,
     metaDesc,
+    language,
     postId,
     canEdit,
   ]);
@@ -121,7 +130,8 @@ export default function EditPostPage() {
     setSaving(true);
     setError("");
     try {
-      await userAPI.updatePost(postId, {
+      const saved = await userAPI.updatePost(postId, {
+        version: version.current,
         title,
         body,
         excerpt,
@@ -130,7 +140,9 @@ export default function EditPostPage() {
         tag_ids: tagIds.length > 0 ? tagIds : undefined,
         meta_title: metaTitle || undefined,
         meta_description: metaDesc || undefined,
+        language,
       });
+      version.current = saved.version;
       router.push("/me/posts");
     } catch (e: any) {
       setError(e.message);
@@ -147,7 +159,8 @@ export default function EditPostPage() {
     setSaving(true);
     setError("");
     try {
-      await userAPI.updatePost(postId, {
+      const saved = await userAPI.updatePost(postId, {
+        version: version.current,
         title,
         body,
         excerpt,
@@ -155,6 +168,7 @@ export default function EditPostPage() {
         category_id: categoryId || undefined,
         tag_ids: tagIds.length > 0 ? tagIds : undefined,
       });
+      version.current = saved.version;
       await userAPI.submitPostForReview(postId);
       router.push("/me/posts");
     } catch (e: any) {
@@ -379,6 +393,20 @@ export default function EditPostPage() {
                   className="w-full rounded-lg border border-gray-200 px-3 py-2 text-sm focus:border-sky-500 focus:outline-none disabled:opacity-60"
                 />
               </div>
+              <div>
+                <label className="mb-1 block text-xs font-medium text-gray-500">
+                  Bahasa Artikel
+                </label>
+                <select
+                  value={language}
+                  onChange={(e) => setLanguage(e.target.value)}
+                  disabled={!canEdit}
+                  className="w-full rounded-lg border border-gray-200 px-3 py-2 text-sm focus:border-sky-500 focus:outline-none disabled:opacity-60"
+                >
+                  <option value="id">Bahasa Indonesia</option>
+                  <option value="en">English</option>
+                </select>
+              </div>
             </div>
           )}
 
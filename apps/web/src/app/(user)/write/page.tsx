@@ -26,6 +26,8 @@ export default function WritePage() {
 
   const autosaveTimer = useRef<NodeJS.Timeout | null>(null);
   const lastSaved = useRef({ title: "", body: "" });
+  // Version of the post last loaded or saved, echoed back on every update
+  const version = useRef(0);
 
   useEffect(() => {
     const user = authAPI.getUser();
@@ -53,7 +55,8 @@ export default function WritePage() {
 
     try {
       if (postId) {
-        await userAPI.updatePost(postId, {
+        const saved = await userAPI.updatePost(postId, {
+          version: version.current,
           title,
           body,
           excerpt,
@@ -63,6 +66,7 @@ export default function WritePage() {
           meta_title: metaTitle || undefined,
           meta_description: metaDesc || undefined,
         });
+        version.current = saved.version;
       } else {
         const post = await userAPI.createPost({
           title,
@@ -74,7 +78,10 @@ export default function WritePage() {
           meta_title: metaTitle || undefined,
           meta_description: metaDesc || undefined,
         });
-        if (post?.id) setPostId(post.id);
+        if (post?.id) {
+          setPostId(post.id);
+          version.current = post.version;
+        }
       }
       lastSaved.current = { title, body };
       setAutoSaved(
@@ -113,7 +120,8 @@ export default function WritePage() {
     setError("");
     try {
       if (postId) {
-        await userAPI.updatePost(postId, {
+        const saved = await userAPI.updatePost(postId, {
+          version: version.current,
           title,
           body,
           excerpt,
@@ -123,6 +131,7 @@ export default function WritePage() {
           meta_title: metaTitle || undefined,
           meta_description: metaDesc || undefined,
         });
+        version.current = saved.version;
       } else {
         await userAPI.createPost({
           title,
@@ -163,7 +172,8 @@ export default function WritePage() {
         });
         id = post?.id;
       } else {
-        await userAPI.updatePost(id, {
+        const saved = await userAPI.updatePost(id, {
+          version: version.current,
           title,
           body,
           excerpt,
@@ -171,6 +181,7 @@ export default function WritePage() {
           category_id: categoryId || undefined,
           tag_ids: tagIds.length > 0 ? tagIds : undefined,
         });
+        version.current = saved.version;
       }
       if (id) await userAPI.submitPostForReview(id);
       router.push("/me/posts");
@@ -3,6 +3,7 @@
 import { useState } from "react";
 import ShareModal from "./ShareModal";
 import { LikeButton, PostStatsBar } from "./Engagement";
+import { ReportButton } from "./ReportButton";
 
 /* ── Share + Engagement Bar ──────────────── */
 export function ShareAndEngagement({
@@ -56,7 +57,13 @@ export function ShareAndEngagement({
       {/* Engagement Bar */}
       <div className="mt-10 flex flex-wrap items-center justify-between gap-4 rounded-2xl border border-gray-200 bg-white p-5">
         <PostStatsBar postId={postId} />
-        <LikeButton postId={postId} />
+        <div className="flex items-center gap-4">
+          <ReportButton
+            postId={postId}
+            className="text-sm text-gray-400 hover:text-red-600"
+          />
+          <LikeButton postId={postId} />
+        </div>
       </div>
 
       {/* Share Modal */}
@@ -3,24 +3,32 @@
 import { useState, useEffect, useCallback } from "react";
 import { engagementClient } from "@/lib/engagement-client";
 import { isAuthenticated, getUser } from "@/lib/auth";
-import type { Comment } from "@/types";
+import { ReportButton } from "./ReportButton";
+import type { Comment, CommentSort } from "@/types";
+
+const SORT_LABELS: Record<CommentSort, string> = {
+  newest: "Terbaru",
+  oldest: "Terlama",
+  top: "Terpopuler",
+};
 
 export function CommentSection({ postId }: { postId: string }) {
   const [comments, setComments] = useState<Comment[]>([]);
   const [total, setTotal] = useState(0);
   const [page, setPage] = useState(1);
+  const [sort, setSort] = useState<CommentSort>("newest");
   const [loading, setLoading] = useState(true);
 
   const loadComments = useCallback(
     async (p: number) => {
       setLoading(true);
-      const res = await engagementClient.getComments(postId, p);
+      const res = await engagementClient.getComments(postId, p, 20, sort);
       setComments(res.items || []);
       setTotal(res.total);
       setPage(p);
       setLoading(false);
     },
-    [postId],
+    [postId, sort],
   );
 
   useEffect(() => {
@@ -29,9 +37,25 @@ export function CommentSection({ postId }: { postId: string }) {
 
   return (
     <div className="mt-12" id="comments">
-      <h3 className="text-xl font-bold text-gray-900">
-        Komentar {total > 0 && <span className="text-gray-400">({total})</span>}
-      </h3>
+      <div className="flex items-center justify-between gap-4">
+        <h3 className="text-xl font-bold text-gray-900">
+          Komentar{" "}
+          {total > 0 && <span className="text-gray-400">({total})</span>}
+        </h3>
+        {total > 1 && (
+          <select
+            value={sort}
+            onChange={(e) => setSort(e.target.value as CommentSort)}
+            className="rounded-lg border border-gray-200 px-3 py-1.5 text-sm text-gray-600 outline-none focus:border-indigo-500"
+          >
+            {(Object.keys(SORT_LABELS) as CommentSort[]).map((s) => (
+              <option key={s} value={s}>
+                {SORT_LABELS[s]}
+              </option>
+            ))}
+          </select>
+        )}
+      </div>
 
       {/* Comment Form */}
       <CommentForm postId={postId} onCommentPosted={() => loadComments(1)} />
@@ -84,25 +108,50 @@ export function CommentSection({ postId }: { postId: string }) {
   );
 }
 
+// MentionText highlights @username mentions in comment text.
+function MentionText({ text }: { text: string }) {
+  const parts = text.split(/((?:^|[^\w@.])@\w{3,30}\b)/);
+  return (
+    <>
+      {parts.map((part, i) => {
+        const at = part.indexOf("@");
+        if (i % 2 === 0 || at < 0) return part;
+        return (
+          <span key={i}>
+            {part.slice(0, at)}
+            <span className="font-medium text-indigo-600">
+              {part.slice(at)}
+            </span>
+          </span>
+        );
+      })}
+    </>
+  );
+}
+
 function CommentForm({
   postId,
   parentId,
+  mention,
   onCommentPosted,
   onCancel,
 }: {
   postId: string;
   parentId?: string;
+  mention?: string;
   onCommentPosted: () => void;
   onCancel?: () => void;
 }) {
   const [authed, setAuthed] = useState(false);
   const [user, setUser] = useState<{ name?: string } | null>(null);
-  const [body, setBody] = useState("");
+  const [body, setBody] = useState(mention ? `@${mention} ` : "");
   const [authorName, setAuthorName] = useState("");
   const [authorEmail, setAuthorEmail] = useState("");
   const [error, setError] = useState("");
   const [loading, setLoading] = useState(false);
-  const [success, setSuccess] = useState(false);
+  const [success, setSuccess] = useState<"approved" | "pending" | null>(
+    null,
+  );
 
   useEffect(() => {
     setAuthed(isAuthenticated());
@@ -116,15 +165,18 @@ function CommentForm({
     setError("");
     setLoading(true);
     try {
-      await engagementClient.createComment(postId, {
+      const created = await engagementClient.createComment(postId, {
         body: body.trim(),
         author_name: authed ? undefined : authorName,
         author_email: authed ? undefined : authorEmail,
         parent_id: parentId,
       });
       setBody("");
-      setSuccess(true);
-      setTimeout(() => setSuccess(false), 3000);
+      // Signed-in comments may be held for moderation too
+      setSuccess(
+        created.status?.toLowerCase() === "approved" ? "approved" : "pending",
+      );
+      setTimeout(() => setSuccess(null), 3000);
       onCommentPosted();
     } catch (err: any) {
       setError(err.message || "Gagal mengirim komentar");
@@ -141,7 +193,7 @@ function CommentForm({
       )}
       {success && (
         <div className="mb-3 rounded-lg bg-emerald-50 border border-emerald-100 px-3 py-2 text-sm text-emerald-600">
-          {authed
+          {success === "approved"
             ? "Komentar berhasil dikirim!"
             : "Komentar dikirim dan menunggu moderasi."}
         </div>
@@ -214,6 +266,78 @@ function CommentItem({
 }) {
   const [showReply, setShowReply] = useState(false);
   const [showReplies, setShowReplies] = useState(false);
+  const [replies, setReplies] = useState<Comment[]>(comment.replies || []);
+  const [repliesPage, setRepliesPage] = useState(0);
+  const [loadingReplies, setLoadingReplies] = useState(false);
+  const [liked, setLiked] = useState(comment.liked ?? false);
+  const [likes, setLikes] = useState(comment.likes_count ?? 0);
+  const [text, setText] = useState(comment.content || comment.body);
+  const [editedAt, setEditedAt] = useState(comment.edited_at);
+  const [editing, setEditing] = useState(false);
+  const [draft, setDraft] = useState("");
+  const [actionError, setActionError] = useState("");
+  const [isOwner, setIsOwner] = useState(false);
+  const replyCount = comment.reply_count ?? 0;
+
+  useEffect(() => {
+    setIsOwner(!!comment.user_id && getUser()?.id === comment.user_id);
+  }, [comment.user_id]);
+
+  const saveEdit = async () => {
+    setActionError("");
+    try {
+      const updated = await engagementClient.updateComment(
+        postId,
+        comment.id,
+        draft.trim(),
+      );
+      setText(updated.content);
+      setEditedAt(updated.edited_at);
+      setEditing(false);
+    } catch (err: any) {
+      setActionError(err.message || "Gagal menyimpan komentar");
+    }
+  };
+
+  const remove = async () => {
+    if (!confirm("Hapus komentar ini?")) return;
+    setActionError("");
+    try {
+      await engagementClient.deleteComment(postId, comment.id);
+      onReply();
+    } catch (err: any) {
+      setActionError(err.message || "Gagal menghapus komentar");
+    }
+  };
+
+  // Replies come from the API page by page; the preview from the
+  // comment list is shown until the first page is loaded.
+  const loadReplies = async (p: number) => {
+    setLoadingReplies(true);
+    const res = await engagementClient.getReplies(postId, comment.id, p);
+    setReplies((prev) => (p === 1 ? res.items : [...prev, ...res.items]));
+    setRepliesPage(p);
+    setLoadingReplies(false);
+  };
+
+  const toggleReplies = () => {
+    if (!showReplies && repliesPage === 0) loadReplies(1);
+    setShowReplies(!showReplies);
+  };
+
+  const toggleLike = async () => {
+    const next = !liked;
+    setLiked(next);
+    setLikes((n) => Math.max(0, n + (next ? 1 : -1)));
+    try {
+      const res = await engagementClient.likeComment(postId, comment.id, next);
+      setLiked(res.liked);
+      setLikes(res.total);
+    } catch {
+      setLiked(!next);
+      setLikes(comment.likes_count ?? 0);
+    }
+  };
 
   const timeAgo = (dateStr: string) => {
     const diff = Date.now() - new Date(dateStr).getTime();
@@ -228,10 +352,12 @@ function CommentItem({
   };
 
   return (
-    <div className="group">
+    <div id={`comment-${comment.id}`} className="group scroll-mt-24">
       <div className="flex gap-3">
         {/* Avatar */}
-        {comment.user_id ? (
+        {comment.deleted ? (
+          <div className="h-9 w-9 shrink-0 rounded-full bg-gray-100" />
+        ) : comment.user_id ? (
           <a
             href={`/u/${comment.user_id}`}
             className="flex h-9 w-9 shrink-0 items-center justify-center rounded-full bg-indigo-100 text-sm font-semibold text-indigo-600 transition-colors hover:bg-indigo-200"
@@ -246,7 +372,9 @@ function CommentItem({
 
         <div className="flex-1 min-w-0">
           <div className="flex items-center gap-2">
-            {comment.user_id ? (
+            {comment.deleted ? (
+              <span className="text-sm italic text-gray-400">[deleted]</span>
+            ) : comment.user_id ? (
               <a
                 href={`/u/${comment.user_id}`}
                 className="text-sm font-semibold text-gray-900 hover:text-indigo-600 transition-colors"
@@ -261,27 +389,111 @@ function CommentItem({
             <span className="text-xs text-gray-400">
               {timeAgo(comment.created_at)}
             </span>
+            {editedAt && !comment.deleted && (
+              <span
+                className="text-xs text-gray-400"
+                title={new Date(editedAt).toLocaleString("id-ID")}
+              >
+                · diedit
+              </span>
+            )}
           </div>
 
-          <p className="mt-1 text-sm text-gray-700 leading-relaxed">
-            {comment.body}
-          </p>
+          {comment.reply_to && (
+            <div className="mt-0.5 text-xs text-gray-400">
+              membalas{" "}
+              <span className="font-medium text-gray-500">
+                {comment.reply_to}
+              </span>
+            </div>
+          )}
+
+          {comment.deleted ? (
+            <p className="mt-1 text-sm italic text-gray-400">
+              Komentar ini telah dihapus.
+            </p>
+          ) : editing ? (
+            <div className="mt-2">
+              <textarea
+                value={draft}
+                onChange={(e) => setDraft(e.target.value)}
+                rows={3}
+                className="w-full rounded-xl border border-gray-200 px-4 py-3 text-sm outline-none focus:border-indigo-500 resize-none"
+              />
+              <div className="mt-2 flex items-center gap-2">
+                <button
+                  onClick={saveEdit}
+                  disabled={!draft.trim()}
+                  className="rounded-xl bg-indigo-600 px-4 py-1.5 text-xs font-medium text-white hover:bg-indigo-700 disabled:opacity-50"
+                >
+                  Simpan
+                </button>
+                <button
+                  onClick={() => setEditing(false)}
+                  className="rounded-xl bg-gray-100 px-4 py-1.5 text-xs font-medium text-gray-600 hover:bg-gray-200"
+                >
+                  Batal
+                </button>
+              </div>
+            </div>
+          ) : (
+            <p className="mt-1 text-sm text-gray-700 leading-relaxed">
+              <MentionText text={text} />
+            </p>
+          )}
+
+          {actionError && (
+            <p className="mt-1 text-xs text-red-600">{actionError}</p>
+          )}
 
           <div className="mt-2 flex items-center gap-3">
-            <button
-              onClick={() => setShowReply(!showReply)}
-              className="text-xs font-medium text-gray-400 hover:text-indigo-600"
-            >
-              Balas
-            </button>
-            {(comment.reply_count ?? 0) > 0 && (
+            {!comment.deleted && (
+              <>
+                <button
+                  onClick={toggleLike}
+                  aria-pressed={liked}
+                  className={`text-xs font-medium ${
+                    liked ? "text-rose-500" : "text-gray-400 hover:text-rose-500"
+                  }`}
+                >
+                  {liked ? "♥" : "♡"} {likes > 0 ? likes : "Suka"}
+                </button>
+                <button
+                  onClick={() => setShowReply(!showReply)}
+                  className="text-xs font-medium text-gray-400 hover:text-indigo-600"
+                >
+                  Balas
+                </button>
+                {isOwner && !editing && (
+                  <>
+                    <button
+                      onClick={() => {
+                        setDraft(text);
+                        setEditing(true);
+                      }}
+                      className="text-xs font-medium text-gray-400 hover:text-indigo-600"
+                    >
+                      Edit
+                    </button>
+                    <button
+                      onClick={remove}
+                      className="text-xs font-medium text-gray-400 hover:text-red-600"
+                    >
+                      Hapus
+                    </button>
+                  </>
+                )}
+                {!isOwner && (
+                  <ReportButton postId={postId} commentId={comment.id} />
+                )}
+              </>
+            )}
+            {replyCount > 0 && (
               <button
-                onClick={() => setShowReplies(!showReplies)}
+                onClick={toggleReplies}
                 className="text-xs font-medium text-indigo-500 hover:text-indigo-600"
               >
-                {showReplies
-                  ? "Sembunyikan"
-                  : `Lihat ${comment.reply_count} balasan`}
+                {showReplies ? "Sembunyikan" : `Lihat ${replyCount} balasan`}
               </button>
             )}
           </div>
@@ -292,8 +504,10 @@ function CommentItem({
               <CommentForm
                 postId={postId}
                 parentId={comment.id}
+                mention={isOwner ? undefined : comment.author_username}
                 onCommentPosted={() => {
                   setShowReply(false);
+                  if (showReplies) loadReplies(1);
                   onReply();
                 }}
                 onCancel={() => setShowReply(false)}
@@ -301,10 +515,10 @@ function CommentItem({
             </div>
           )}
 
-          {/* Replies preview */}
-          {showReplies && comment.replies && comment.replies.length > 0 && (
+          {/* Replies; past the maximum depth they arrive as one flat list */}
+          {showReplies && replies.length > 0 && (
             <div className="mt-4 space-y-4 pl-3 border-l-2 border-gray-100">
-              {comment.replies.map((reply) => (
+              {replies.map((reply) => (
                 <CommentItem
                   key={reply.id}
                   comment={reply}
@@ -312,6 +526,15 @@ function CommentItem({
                   onReply={onReply}
                 />
               ))}
+              {replies.length < replyCount && (
+                <button
+                  onClick={() => loadReplies(repliesPage + 1)}
+                  disabled={loadingReplies}
+                  className="text-xs font-medium text-indigo-500 hover:text-indigo-600 disabled:opacity-50"
+                >
+                  {loadingReplies ? "Memuat..." : "Muat balasan lainnya"}
+                </button>
+              )}
             </div>
           )}
         </div>
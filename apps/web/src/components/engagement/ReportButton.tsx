@@ -0,0 +1,112 @@
+"use client";
+
+import { useState } from "react";
+import { engagementClient } from "@/lib/engagement-client";
+import type { ReportReason } from "@/types";
+
+const REASONS: { value: ReportReason; label: string }[] = [
+  { value: "spam", label: "Spam" },
+  { value: "harassment", label: "Pelecehan atau perundungan" },
+  { value: "hate_speech", label: "Ujaran kebencian" },
+  { value: "misinformation", label: "Informasi menyesatkan" },
+  { value: "inappropriate", label: "Konten tidak pantas" },
+  { value: "copyright", label: "Pelanggaran hak cipta" },
+  { value: "other", label: "Lainnya" },
+];
+
+export function ReportButton({
+  postId,
+  commentId,
+  className = "text-xs font-medium text-gray-400 hover:text-red-600",
+}: {
+  postId: string;
+  commentId?: string;
+  className?: string;
+}) {
+  const [open, setOpen] = useState(false);
+  const [reason, setReason] = useState<ReportReason>("spam");
+  const [details, setDetails] = useState("");
+  const [status, setStatus] = useState<"idle" | "loading" | "done">("idle");
+  const [error, setError] = useState("");
+
+  const submit = async (e: React.FormEvent) => {
+    e.preventDefault();
+    setError("");
+    setStatus("loading");
+    try {
+      await engagementClient.report(
+        postId,
+        { reason, details: details.trim() || undefined },
+        commentId,
+      );
+      setStatus("done");
+    } catch (err: any) {
+      setError(err.message || "Gagal mengirim laporan");
+      setStatus("idle");
+    }
+  };
+
+  if (status === "done") {
+    return <span className="text-xs text-gray-400">Terima kasih, laporan terkirim.</span>;
+  }
+
+  return (
+    <span className="relative inline-block">
+      <button type="button" onClick={() => setOpen(!open)} className={className}>
+        Laporkan
+      </button>
+      {open && (
+        <form
+          onSubmit={submit}
+          className="absolute right-0 z-20 mt-2 w-72 rounded-xl border border-gray-200 bg-white p-4 text-left shadow-lg"
+        >
+          <p className="text-sm font-semibold text-gray-900">
+            {commentId ? "Laporkan komentar" : "Laporkan artikel"}
+          </p>
+          <div className="mt-3 space-y-1.5">
+            {REASONS.map((r) => (
+              <label
+                key={r.value}
+                className="flex items-center gap-2 text-sm text-gray-600"
+              >
+                <input
+                  type="radio"
+                  name="reason"
+                  value={r.value}
+                  checked={reason === r.value}
+                  onChange={() => setReason(r.value)}
+                />
+                {r.label}
+              </label>
+            ))}
+          </div>
+          <textarea
+            value={details}
+            onChange={(e) => setDetails(e.target.value)}
+            maxLength={1000}
+            rows={2}
+            placeholder="Keterangan (opsional)"
+            className="mt-3 w-full rounded-lg border border-gray-200 px-3 py-2 text-sm outline-none focus:border-indigo-500 resize-none"
+          />
+          {error && <p className="mt-2 text-xs text-red-600">{error}</p>}
+          <div className="mt-3 flex justify-end gap-2">
+            <button
+              type="button"
+              onClick={() => setOpen(false)}
+              className="rounded-lg bg-gray-100 px-3 py-1.5 text-xs font-medium text-gray-600 hover:bg-gray-200"
+            >
+              Batal
+            </button>
+            <button
+              type="submit"
+              disabled={status === "loading"}
+              className="rounded-lg bg-red-600 px-3 py-1.5 text-xs font-medium text-white hover:bg-red-700 disabled:opacity-50"
+            >
+              {status === "loading" ? "Mengirim..." : "Kirim Laporan"}
+            </button>
+          </div>
+        </form>
+      )}
+    </span>
+  );
+}
@@ -6,6 +6,26 @@ import { useState, useEffect, useRef, useCallback } from "react";
 import { usePathname, useRouter } from "next/navigation";
 import ProfileMenu from "@/components/layout/ProfileMenu";
 
+type SearchResult = {
+  type: "post" | "listing" | "portfolio";
+  id: string;
+  title: string;
+  slug: string;
+  excerpt: string;
+  category?: string;
+  url: string;
+};
+
+const resultTypeLabels: Record<SearchResult["type"], string> = {
+  post: "Artikel",
+  listing: "Toko",
+  portfolio: "Portfolio",
+};
+
+// Posts open in the blog; listings and portfolio items on the store
+const resultHref = (r: SearchResult) =>
+  r.type === "post" ? `/posts/${r.slug}` : r.url;
+
 export function Navbar() {
   const [mobileOpen, setMobileOpen] = useState(false);
   const [visible, setVisible] = useState(true);
@@ -15,16 +35,9 @@ export function Navbar() {
   const [suggestions, setSuggestions] = useState<
     { title: string; slug: string; category?: string }[]
   >([]);
-  const [searchResults, setSearchResults] = useState<
-    {
-      id: string;
-      title: string;
-      slug: string;
-      excerpt: string;
-      category_name?: string;
-      author_name?: string;
-    }[]
-  >([]);
+  const [didYouMean, setDidYouMean] = useState<string[]>([]);
+  const [searchId, setSearchId] = useState("");
+  const [searchResults, setSearchResults] = useState<SearchResult[]>([]);
   const [showResults, setShowResults] = useState(false);
   const [searchLoading, setSearchLoading] = useState(false);
   const [activeIdx, setActiveIdx] = useState(-1);
@@ -40,10 +53,23 @@ export function Navbar() {
 
   const apiUrl = process.env.NEXT_PUBLIC_API_URL || "http://localhost:8080";
 
+  // Report a result click for search analytics (1-based position)
+  const trackClick = useCallback(
+    (position: number, id: string, type: SearchResult["type"] = "post") => {
+      if (!searchId || typeof navigator === "undefined") return;
+      navigator.sendBeacon?.(
+        `${apiUrl}/search/click`,
+        JSON.stringify({ search_id: searchId, position, type, id }),
+      );
+    },
+    [searchId, apiUrl],
+  );
+
   // Fetch suggestions on query change
   useEffect(() => {
     if (searchQuery.length < 2) {
       setSuggestions([]);
+      setDidYouMean([]);
       setShowResults(false);
       setSearchResults([]);
       return;
@@ -59,7 +85,9 @@ export function Navbar() {
         );
         if (res.ok) {
           const data = await res.json();
-          setSuggestions(data || []);
+          setSuggestions(data.items || []);
+          setDidYouMean(data.did_you_mean || []);
+          setSearchId(data.search_id || "");
           setActiveIdx(-1);
         }
       } catch {
@@ -80,11 +108,12 @@ export function Navbar() {
     setSuggestions([]);
     try {
       const res = await fetch(
-        `${apiUrl}/search?q=${encodeURIComponent(searchQuery)}&limit=8`,
+        `${apiUrl}/search?q=${encodeURIComponent(searchQuery)}&type=all&limit=8`,
       );
       if (res.ok) {
         const data = await res.json();
         setSearchResults(data?.items || data || []);
+        setSearchId(data?.search_id || "");
         setShowResults(true);
       }
     } catch {
@@ -100,6 +129,7 @@ export function Navbar() {
       const q = searchQuery.trim();
       if (!q) return;
       if (activeIdx >= 0 && suggestions.length > 0) {
+        trackClick(activeIdx + 1, suggestions[activeIdx].slug);
         router.push(`/posts/${suggestions[activeIdx].slug}`);
         setSearchOpen(false);
         setSearchQuery("");
@@ -109,7 +139,7 @@ export function Navbar() {
         doFullSearch();
       }
     },
-    [searchQuery, router, activeIdx, suggestions, doFullSearch],
+    [searchQuery, router, activeIdx, suggestions, doFullSearch, trackClick],
   );
 
   const handleKeyDown = useCallback(
@@ -124,8 +154,16 @@ export function Navbar() {
         setActiveIdx((p) => (p > 0 ? p - 1 : items.length - 1));
       } else if (e.key === "Enter" && activeIdx >= 0) {
         e.preventDefault();
-        const item = items[activeIdx];
-        router.push(`/posts/${item.slug}`);
+        if (showResults) {
+          const item = searchResults[activeIdx];
+          trackClick(activeIdx + 1, item.id, item.type);
+          if (item.type === "post") router.push(resultHref(item));
+          else window.location.assign(resultHref(item));
+        } else {
+          const item = suggestions[activeIdx];
+          trackClick(activeIdx + 1, item.slug);
+          router.push(`/posts/${item.slug}`);
+        }
         setSearchOpen(false);
         setSearchQuery("");
         setSuggestions([]);
@@ -137,7 +175,7 @@ export function Navbar() {
         setShowResults(false);
       }
     },
-    [suggestions, searchResults, showResults, activeIdx, router],
+    [suggestions, searchResults, showResults, activeIdx, router, trackClick],
   );
 
   // Focus input when search opens
@@ -389,8 +427,27 @@ export function Navbar() {
               )}
 
               {/* Search Results Dropdown */}
-              {searchOpen && (suggestions.length > 0 || showResults) && (
+              {searchOpen &&
+                (suggestions.length > 0 ||
+                  didYouMean.length > 0 ||
+                  showResults) && (
                 <div className="absolute right-0 top-full mt-2 w-72 max-w-[calc(100vw-2rem)] overflow-hidden rounded-xl border border-gray-200 bg-white shadow-xl shadow-gray-200/50 z-50">
+                  {/* Did you mean */}
+                  {!showResults && didYouMean.length > 0 && (
+                    <div className="flex flex-wrap items-center gap-1 border-b border-gray-100 px-3 py-2 text-xs text-gray-500">
+                      Mungkin maksud Anda:
+                      {didYouMean.map((term) => (
+                        <button
+                          key={term}
+                          onClick={() => setSearchQuery(term)}
+                          className="font-medium text-sky-600 hover:underline"
+                        >
+                          {term}
+                        </button>
+                      ))}
+                    </div>
+                  )}
+
                   {/* Suggestions */}
                   {!showResults && suggestions.length > 0 && (
                     <>
@@ -402,6 +459,7 @@ export function Navbar() {
                           key={i}
                           href={`/posts/${s.slug}`}
                           onClick={() => {
+                            trackClick(i + 1, s.slug);
                             setSearchOpen(false);
                             setSearchQuery("");
                             setSuggestions([]);
@@ -466,9 +524,10 @@ export function Navbar() {
                       {searchResults.length > 0 ? (
                         searchResults.map((r, i) => (
                           <Link
-                            key={r.id}
-                            href={`/posts/${r.slug}`}
+                            key={`${r.type}-${r.id}`}
+                            href={resultHref(r)}
                             onClick={() => {
+                              trackClick(i + 1, r.id, r.type);
                               setSearchOpen(false);
                               setSearchQuery("");
                               setSearchResults([]);
@@ -476,17 +535,22 @@ export function Navbar() {
                             }}
                             className={`block px-3 py-2.5 transition-colors ${i === activeIdx ? "bg-sky-50" : "hover:bg-gray-50"}`}
                           >
-                            <p className="truncate text-sm font-medium text-gray-900">
-                              {r.title}
+                            <p className="flex items-center gap-1.5 text-sm font-medium text-gray-900">
+                              <span className="truncate">{r.title}</span>
+                              {r.type !== "post" && (
+                                <span className="shrink-0 rounded bg-amber-50 px-1.5 py-0.5 text-[10px] font-semibold text-amber-700">
+                                  {resultTypeLabels[r.type]}
+                                </span>
+                              )}
                             </p>
                             <p className="mt-0.5 line-clamp-1 text-xs text-gray-400">
-                              {r.excerpt || r.category_name || ""}
+                              {r.excerpt || r.category || ""}
                             </p>
                           </Link>
                         ))
                       ) : (
                         <div className="px-3 py-6 text-center text-sm text-gray-400">
-                          Tidak ada artikel yang cocok.
+                          Tidak ada hasil yang cocok.
                         </div>
                       )}
                     </>
@@ -589,6 +653,7 @@ export function Navbar() {
                     key={i}
                     href={`/posts/${s.slug}`}
                     onClick={() => {
+                      trackClick(i + 1, s.slug);
                       setSearchOpen(false);
                       setSearchQuery("");
                       setSuggestions([]);
@@ -616,9 +681,10 @@ export function Navbar() {
               {showResults &&
                 searchResults.map((r, i) => (
                   <Link
-                    key={r.id}
-                    href={`/posts/${r.slug}`}
+                    key={`${r.type}-${r.id}`}
+                    href={resultHref(r)}
                     onClick={() => {
+                      trackClick(i + 1, r.id, r.type);
                       setSearchOpen(false);
                       setSearchQuery("");
                       setSearchResults([]);
@@ -626,8 +692,13 @@ export function Navbar() {
                     }}
                     className={`block px-4 py-2.5 transition-colors ${i === activeIdx ? "bg-sky-50" : "hover:bg-gray-50"}`}
                   >
-                    <p className="truncate text-sm font-medium text-gray-900">
-                      {r.title}
+                    <p className="flex items-center gap-1.5 text-sm font-medium text-gray-900">
+                      <span className="truncate">{r.title}</span>
+                      {r.type !== "post" && (
+                        <span className="shrink-0 rounded bg-amber-50 px-1.5 py-0.5 text-[10px] font-semibold text-amber-700">
+                          {resultTypeLabels[r.type]}
+                        </span>
+                      )}
                     </p>
                     <p className="mt-0.5 line-clamp-1 text-xs text-gray-400">
                       {r.excerpt || ""}
@@ -62,6 +62,11 @@ export const apiClient = {
     }
   },
 
+  getRelatedPosts: (id: string, limit = 4) =>
+    fetchAPI<any>(`/posts/${id}/related?limit=${limit}`, {
+      fallback: { items: [] },
+    }),
+
   getCategories: () => fetchAPI<any[]>("/categories", { fallback: [] }),
 
   getTags: () => fetchAPI<any[]>("/tags", { fallback: [] }),
@@ -71,12 +76,26 @@ export const apiClient = {
     page?: number;
     limit?: number;
     sort?: string;
+    lang?: string;
+    type?: "post" | "listing" | "portfolio" | "all";
+    category?: string;
+    tags?: string[];
+    author?: string;
+    from?: string;
+    to?: string;
   }) => {
     const sp = new URLSearchParams();
     sp.set("q", params.q);
     if (params.page) sp.set("page", String(params.page));
     if (params.limit) sp.set("limit", String(params.limit));
     if (params.sort) sp.set("sort", params.sort);
+    if (params.lang) sp.set("lang", params.lang);
+    if (params.type) sp.set("type", params.type);
+    if (params.category) sp.set("category", params.category);
+    params.tags?.forEach((t) => sp.append("tag", t));
+    if (params.author) sp.set("author", params.author);
+    if (params.from) sp.set("from", params.from);
+    if (params.to) sp.set("to", params.to);
     return fetchAPI<any>(`/search?${sp.toString()}`, {
       fallback: {
         items: [],
@@ -89,9 +108,9 @@ export const apiClient = {
   },
 
   suggest: (q: string, limit = 5) =>
-    fetchAPI<any[]>(
+    fetchAPI<{ items: any[]; did_you_mean: string[] }>(
       `/search/suggest?q=${encodeURIComponent(q)}&limit=${limit}`,
-      { fallback: [] },
+      { fallback: { items: [], did_you_mean: [] } },
     ),
 
   // ── Public user profiles ───────────────────────
@@ -294,6 +294,25 @@ export const userAPI = {
   getMyComments: (page = 1, limit = 10) =>
     authFetch<any>(`/user/comments?page=${page}&limit=${limit}`),
 
+  // Notifications
+  getNotifications: (page = 1, limit = 20, unread = false) =>
+    authFetch<any>(
+      `/user/notifications?page=${page}&limit=${limit}${unread ? "&unread=true" : ""}`,
+    ),
+  getUnreadNotifications: () =>
+    authFetch<{ unread: number }>("/user/notifications/unread-count"),
+  markNotificationRead: (id: string) =>
+    authFetch<any>(`/user/notifications/${id}/read`, { method: "POST" }),
+  markAllNotificationsRead: () =>
+    authFetch<any>("/user/notifications/read-all", { method: "POST" }),
+  getNotificationPreferences: () =>
+    authFetch<any>("/user/notifications/preferences"),
+  updateNotificationPreferences: (data: Record<string, boolean>) =>
+    authFetch<any>("/user/notifications/preferences", {
+      method: "PUT",
+      body: JSON.stringify(data),
+    }),
+
   // Author request
   createAuthorRequest: (reason: string) =>
     authFetch<any>("/user/author-request", {
@@ -373,6 +392,13 @@ export const adminAPI = {
   getDashboardStats: () => authFetch<any>("/admin/stats/dashboard"),
   getTopPosts: () => authFetch<any>("/admin/stats/top-posts"),
   getTrafficOverview: () => authFetch<any>("/admin/stats/traffic"),
+  getSearchStats: (params?: { days?: number; limit?: number; source?: "search" | "suggest" }) => {
+    const sp = new URLSearchParams();
+    if (params?.days) sp.set("days", String(params.days));
+    if (params?.limit) sp.set("limit", String(params.limit));
+    if (params?.source) sp.set("source", params.source);
+    return authFetch<any>(`/admin/stats/search?${sp.toString()}`);
+  },
 
   // Affiliate admin
   getAffiliateSettings: () => authFetch<any>("/admin/affiliate/settings"),
@@ -2,6 +2,8 @@
 
 import type {
   Comment,
+  CommentSort,
+  ReportReason,
   PostStats,
   LikeResponse,
   PaginatedResult,
@@ -30,12 +32,15 @@ export const engagementClient = {
     postId: string,
     page = 1,
     limit = 20,
+    sort: CommentSort = "newest",
   ): Promise<PaginatedResult<Comment>> => {
     const sp = new URLSearchParams({
       page: String(page),
       limit: String(limit),
+      sort,
     });
     const res = await fetch(`${API_URL}/posts/${postId}/comments?${sp}`, {
+      headers: headers(true),
       cache: "no-store",
     });
     if (!res.ok)
@@ -43,6 +48,39 @@ export const engagementClient = {
     return res.json();
   },
 
+  getReplies: async (
+    postId: string,
+    commentId: string,
+    page = 1,
+    limit = 20,
+  ): Promise<PaginatedResult<Comment>> => {
+    const sp = new URLSearchParams({
+      page: String(page),
+      limit: String(limit),
+    });
+    const res = await fetch(
+      `${API_URL}/posts/${postId}/comments/${commentId}/replies?${sp}`,
+      { headers: headers(true), cache: "no-store" },
+    );
+    if (!res.ok)
+      return { items: [], page: 1, limit, total: 0, total_pages: 0 };
+    return res.json();
+  },
+
+  likeComment: async (
+    postId: string,
+    commentId: string,
+    liked: boolean,
+  ): Promise<LikeResponse> => {
+    const res = await fetch(
+      `${API_URL}/posts/${postId}/comments/${commentId}/like`,
+      { method: liked ? "POST" : "DELETE", headers: headers(true) },
+    );
+    if (!res.ok) throw new Error("Failed to like comment");
+    const data = await res.json();
+    return { liked: data.liked, total: data.likes_count ?? 0 };
+  },
+
   createComment: async (
     postId: string,
     body: {
@@ -64,6 +102,56 @@ export const engagementClient = {
     return res.json();
   },
 
+  updateComment: async (
+    postId: string,
+    commentId: string,
+    content: string,
+  ): Promise<Comment> => {
+    const res = await fetch(`${API_URL}/posts/${postId}/comments/${commentId}`, {
+      method: "PATCH",
+      headers: headers(true),
+      body: JSON.stringify({ content }),
+    });
+    if (!res.ok) {
+      const err = await res.json().catch(() => ({}));
+      throw new Error(err.error || "Failed to update comment");
+    }
+    return res.json();
+  },
+
+  deleteComment: async (postId: string, commentId: string): Promise<void> => {
+    const res = await fetch(`${API_URL}/posts/${postId}/comments/${commentId}`, {
+      method: "DELETE",
+      headers: headers(true),
+    });
+    if (!res.ok) {
+      const err = await res.json().catch(() => ({}));
+      throw new Error(err.error || "Failed to delete comment");
+    }
+  },
+
+  // ── Reports ───────────────────────────────────
+
+  // Reports a post, or one of its comments when commentId is given.
+  report: async (
+    postId: string,
+    body: { reason: ReportReason; details?: string },
+    commentId?: string,
+  ): Promise<void> => {
+    const path = commentId
+      ? `/posts/${postId}/comments/${commentId}/report`
+      : `/posts/${postId}/report`;
+    const res = await fetch(`${API_URL}${path}`, {
+      method: "POST",
+      headers: headers(true),
+      body: JSON.stringify(body),
+    });
+    if (!res.ok) {
+      const err = await res.json().catch(() => ({}));
+      throw new Error(err.error || "Failed to send report");
+    }
+  },
+
   // ── Likes ─────────────────────────────────────
 
   toggleLike: async (postId: string): Promise<LikeResponse> => {
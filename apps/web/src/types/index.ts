@@ -20,14 +20,27 @@ export interface Post {
   category_id?: string;
   published_at?: string;
   scheduled_at?: string;
+  expires_at?: string;
   meta_title?: string;
   meta_description?: string;
   canonical?: string;
+  language?: "id" | "en";
   created_at: string;
   updated_at: string;
   tags?: Tag[];
   category?: Category;
   author?: Author;
+  authors?: Contributor[];
+  body_html?: string;
+  toc?: TocEntry[];
+  reading_time?: number;
+  version: number;
+}
+
+export interface TocEntry {
+  level: number;
+  id: string;
+  text: string;
 }
 
 export interface Category {
@@ -49,6 +62,20 @@ export interface Author {
   avatar?: string;
 }
 
+export type ContributorRole =
+  | "author"
+  | "editor"
+  | "illustrator"
+  | "technical_reviewer";
+
+export interface Contributor {
+  user_id: string;
+  name: string;
+  avatar?: string;
+  role: ContributorRole;
+  position: number;
+}
+
 // ── Pagination ───────────────────────────────────────
 
 export interface PaginatedResult<T> {
@@ -127,6 +154,7 @@ export interface Comment {
   post_title?: string;
   user_id?: string;
   author_name: string;
+  author_username?: string;
   author_email: string;
   body: string;
   content: string;
@@ -135,8 +163,25 @@ export interface Comment {
   created_at: string;
   reply_count?: number;
   replies?: Comment[];
+  likes_count?: number;
+  liked?: boolean;
+  depth?: number;
+  reply_to?: string;
+  edited_at?: string;
+  deleted?: boolean;
 }
 
+export type CommentSort = "newest" | "oldest" | "top";
+
+export type ReportReason =
+  | "spam"
+  | "harassment"
+  | "hate_speech"
+  | "misinformation"
+  | "inappropriate"
+  | "copyright"
+  | "other";
+
 export interface PostStats {
   post_id: string;
   views: number;
@@ -410,6 +455,28 @@ export interface AuthorRequestStatus {
 
 // ── User Comment (with post info) ────────────────────
 
+export type NotificationType =
+  | "comment_reply"
+  | "comment_mention"
+  | "post_comment";
+
+export interface Notification {
+  id: string;
+  type: NotificationType;
+  actor_id?: string;
+  actor_name?: string;
+  actor_avatar?: string;
+  post_id: string;
+  post_title?: string;
+  post_slug?: string;
+  comment_id: string;
+  excerpt?: string;
+  read_at?: string;
+  created_at: string;
+}
+
+export type NotificationPreferences = Record<NotificationType, boolean>;
+
 export interface UserComment {
   id: string;
   post_id: string;